@@ -27,3 +27,8 @@ const FederationNameAnnotation = "federation.alpha.kubernetes.io/federation-name
 // not associated with any cluster, then this annotation is not
 // required.
 const ClusterNameAnnotation = "federation.alpha.kubernetes.io/cluster-name"
+
+// CredentialsRotatedAnnotation is the annotation which holds the time the
+// credentials of a cluster were last rotated. Changing it makes the
+// federation controllers reconnect to the cluster with the new credentials.
+const CredentialsRotatedAnnotation = "federation.kubernetes.io/credentials-rotated-at"
@@ -0,0 +1,28 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package federation
+
+// ClusterZonesField is the field selector that matches the clusters with a
+// given zone, e.g. status.zones=us-east1-b.
+const ClusterZonesField = "status.zones"
+
+// ClusterZoneFieldLabel returns the field under which the registry indexes a
+// zone of a cluster. Field sets hold a single value per field, so the
+// ClusterZonesField selector is converted to the field of the selected zone.
+func ClusterZoneFieldLabel(zone string) string {
+	return ClusterZonesField + "." + zone
+}
@@ -40,6 +40,8 @@ func Install(groupFactoryRegistry announced.APIGroupFactoryRegistry, registry *r
 			AddInternalObjectsToScheme: federation.AddToScheme,
 			RootScopedKinds: sets.NewString(
 				"Cluster",
+				"PropagationPolicy",
+				"ClusterSet",
 			),
 		},
 		announced.VersionToSchemeFunc{
@@ -18,11 +18,13 @@ package install
 
 import (
 	"encoding/json"
+	"reflect"
 	"testing"
 
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/diff"
 	"k8s.io/federation/apis/federation"
 	"k8s.io/federation/apis/federation/v1beta1"
 	"k8s.io/kubernetes/pkg/api/legacyscheme"
@@ -66,6 +68,105 @@ func TestCodec(t *testing.T) {
 	}
 }
 
+func TestProtobufRoundTrip(t *testing.T) {
+	// The federation API server stores objects as protobuf, so every field must
+	// survive a round trip through the protobuf codec.
+	mediaType := "application/vnd.kubernetes.protobuf"
+	info, ok := runtime.SerializerInfoForMediaType(legacyscheme.Codecs.SupportedMediaTypes(), mediaType)
+	if !ok {
+		t.Fatalf("no serializer for %s", mediaType)
+	}
+	gv := legacyscheme.Registry.GroupOrDie(federation.GroupName).GroupVersion
+	codec := legacyscheme.Codecs.CodecForVersions(info.Serializer, info.Serializer, gv, nil)
+
+	testCases := []runtime.Object{
+		&federation.PropagationPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "policy"},
+			Spec: federation.PropagationPolicySpec{
+				ResourceSelectors: []federation.ResourceSelector{
+					{
+						Kind:          "ReplicaSet",
+						Namespaces:    []string{"default"},
+						LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+					},
+				},
+				Priority: 10,
+				ClusterSelector: []federation.ClusterSelectorRequirement{
+					{Key: "env", Operator: "in", Values: []string{"prod"}},
+				},
+				ReplicaPreferences: &federation.ReplicaAllocationPreferences{
+					Rebalance: true,
+					Clusters: map[string]federation.ClusterPreferences{
+						"*": {Weight: 1},
+					},
+				},
+				ClusterSet: "eu",
+			},
+		},
+		&federation.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "failover"},
+			Status: federation.ClusterStatus{
+				ActiveServerAddress: "10.0.0.2:443",
+				ServerAddresses: []federation.ServerAddressStatus{
+					{ServerAddress: "10.0.0.1:443", Healthy: false, Message: "connection refused", LastProbeTime: metav1.Unix(1500000000, 0)},
+					{ServerAddress: "10.0.0.2:443", Healthy: true, LastProbeTime: metav1.Unix(1500000000, 0)},
+				},
+			},
+		},
+		&federation.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "capabilities"},
+			Status: federation.ClusterStatus{
+				Version: "v1.9.3",
+				APIResources: []federation.ClusterAPIResources{
+					{GroupVersion: "v1", Resources: []string{"configmaps", "secrets"}},
+					{GroupVersion: "autoscaling/v1", Resources: []string{"horizontalpodautoscalers"}},
+				},
+			},
+		},
+		&federation.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "dampened"},
+			Status: federation.ClusterStatus{
+				ProbeHistory: []federation.ClusterProbe{
+					{Time: metav1.Unix(1500000000, 0), Healthy: true},
+					{Time: metav1.Unix(1500000040, 0), Healthy: false, Message: "connection refused"},
+				},
+			},
+		},
+		&federation.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cordoned"},
+			Spec:       federation.ClusterSpec{Maintenance: federation.ClusterCordoned},
+		},
+		&federation.ClusterSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "eu"},
+			Spec: federation.ClusterSetSpec{
+				Clusters: []string{"c1"},
+				ClusterSelector: []federation.ClusterSelectorRequirement{
+					{Key: "region", Operator: "in", Values: []string{"europe-west1"}},
+				},
+			},
+			Status: federation.ClusterSetStatus{Clusters: []string{"c1", "c2"}},
+		},
+		&federation.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "pull"},
+			Spec:       federation.ClusterSpec{ConnectionMode: federation.ClusterConnectionPull},
+		},
+	}
+
+	for i, obj := range testCases {
+		data, err := runtime.Encode(codec, obj)
+		if err != nil {
+			t.Fatalf("[%d] unexpected error: %v", i, err)
+		}
+		decoded, err := runtime.Decode(codec, data)
+		if err != nil {
+			t.Fatalf("[%d] unexpected error: %v", i, err)
+		}
+		if !reflect.DeepEqual(obj, decoded) {
+			t.Errorf("[%d] object changed in a round trip: %s", i, diff.ObjectReflectDiff(obj, decoded))
+		}
+	}
+}
+
 func TestInterfacesFor(t *testing.T) {
 	if _, err := legacyscheme.Registry.GroupOrDie(federation.GroupName).InterfacesFor(federation.SchemeGroupVersion); err == nil {
 		t.Fatalf("unexpected non-error: %v", err)
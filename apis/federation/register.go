@@ -46,6 +46,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&Cluster{},
 		&ClusterList{},
+		&PropagationPolicy{},
+		&PropagationPolicyList{},
+		&ClusterSet{},
+		&ClusterSetList{},
 	)
 	return nil
 }
@@ -45,8 +45,44 @@ type ClusterSpec struct {
 	// This can be left empty if the cluster allows insecure access.
 	// +optional
 	SecretRef *api.LocalObjectReference
+	// Maintenance puts the cluster in maintenance, e.g. while it is upgraded.
+	// The cluster stays joined and keeps its federated objects.
+	// +optional
+	Maintenance ClusterMaintenance
+	// ConnectionMode is how the federation reaches the cluster, Push if not set.
+	// The federation controllers do not operate on clusters in Pull mode, whose
+	// agent applies their federated objects and reports their status instead.
+	// +optional
+	ConnectionMode ClusterConnectionMode
 }
 
+// ClusterMaintenance is the maintenance mode of a cluster.
+type ClusterMaintenance string
+
+const (
+	// ClusterCordoned means no new federated objects or replicas are placed
+	// in the cluster. The objects and replicas it runs are kept.
+	ClusterCordoned ClusterMaintenance = "Cordoned"
+	// ClusterDrained means the cluster is cordoned and the replicas of
+	// federated workloads are moved to other clusters as they become ready
+	// there. DNS records stop pointing to the services of the cluster.
+	ClusterDrained ClusterMaintenance = "Drained"
+)
+
+// ClusterConnectionMode is how the federation reaches a cluster.
+type ClusterConnectionMode string
+
+const (
+	// ClusterConnectionPush means the federation controllers connect to the
+	// API servers of the cluster.
+	ClusterConnectionPush ClusterConnectionMode = "Push"
+	// ClusterConnectionPull means the cluster can not be reached by the
+	// federation. An agent running in the cluster connects to the federation
+	// API server, applies the federated objects selected for the cluster and
+	// reports its status.
+	ClusterConnectionPull ClusterConnectionMode = "Pull"
+)
+
 type ClusterConditionType string
 
 // These are valid conditions of a cluster.
@@ -55,6 +91,17 @@ const (
 	ClusterReady ClusterConditionType = "Ready"
 	// ClusterOffline means the cluster is temporarily down or not reachable
 	ClusterOffline ClusterConditionType = "Offline"
+	// ClusterCredentialsExpiring means the credentials used to access the
+	// cluster expire soon, or have expired.
+	ClusterCredentialsExpiring ClusterConditionType = "CredentialsExpiring"
+	// ClusterMissingResources means the cluster does not serve the API
+	// resources some federated types require, and is skipped by their
+	// sync controllers.
+	ClusterMissingResources ClusterConditionType = "MissingResources"
+	// ClusterDegraded means the latest health checks of a ready cluster failed,
+	// but not enough of them to make it unready. Degraded clusters keep their
+	// federated objects, but no new objects are placed in them.
+	ClusterDegraded ClusterConditionType = "Degraded"
 )
 
 // ClusterCondition describes current state of a cluster.
@@ -89,6 +136,58 @@ type ClusterStatus struct {
 	// Region is the name of the region in which all of the nodes in the cluster exist.  e.g. 'us-east1'.
 	// +optional
 	Region string
+	// ActiveServerAddress is the address the federation control plane currently uses to reach the cluster,
+	// one of the addresses in ServerAddressByClientCIDRs matching its client CIDR. Clients fail over to
+	// another healthy address when the active one stops answering its health checks.
+	// +optional
+	ActiveServerAddress string
+	// ServerAddresses is the result of the last health check of each address of the cluster matching the
+	// client CIDR of the federation control plane, in the order they are listed in ServerAddressByClientCIDRs.
+	// +optional
+	ServerAddresses []ServerAddressStatus
+	// Version is the Kubernetes version of the cluster, e.g. 'v1.9.3'.
+	// +optional
+	Version string
+	// APIResources are the API resources served by the cluster, by group version.
+	// +optional
+	APIResources []ClusterAPIResources
+	// ProbeHistory holds the results of the latest health checks of the cluster, oldest first.
+	// +optional
+	ProbeHistory []ClusterProbe
+}
+
+// ClusterProbe is the result of a health check of a cluster.
+type ClusterProbe struct {
+	// Time of the health check.
+	Time metav1.Time
+	// Healthy is whether the active API server of the cluster answered the health check with ok.
+	Healthy bool
+	// Human readable message describing the result of the health check.
+	// +optional
+	Message string
+}
+
+// ClusterAPIResources lists the API resources a cluster serves in an API group version.
+type ClusterAPIResources struct {
+	// GroupVersion of the resources, e.g. 'extensions/v1beta1' or 'v1' for the core group.
+	GroupVersion string
+	// Resources are the plural names of the resources, e.g. 'replicasets'.
+	// +optional
+	Resources []string
+}
+
+// ServerAddressStatus is the health of one of the API server addresses of a cluster.
+type ServerAddressStatus struct {
+	// Address of the server, as listed in ServerAddressByClientCIDRs.
+	ServerAddress string
+	// Healthy is whether the server answered its last health check with ok.
+	Healthy bool
+	// Human readable message indicating the result of the last health check.
+	// +optional
+	Message string
+	// Last time the server was checked.
+	// +optional
+	LastProbeTime metav1.Time
 }
 
 // +genclient
@@ -125,6 +224,164 @@ type ClusterList struct {
 	Items []Cluster
 }
 
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterProxyOptions is the query options to a Cluster's proxy call.
+type ClusterProxyOptions struct {
+	metav1.TypeMeta
+
+	// Path is the URL path to use for the current proxy request to the cluster.
+	Path string
+}
+
+// ClusterSelectorRequirement contains values, a key, and an operator that relates the key and values.
+// The zero value of ClusterSelectorRequirement is invalid.
+// ClusterSelectorRequirement implements both set based match and exact match
+type ClusterSelectorRequirement struct {
+	Key string
+	// The Operator defines how the Key is matched to the Values. One of "in", "notin",
+	// "exists", "!", "=", "!=", "gt" or "lt".
+	Operator string
+	// An array of string values. If the operator is "in" or "notin",
+	// the values array must be non-empty. If the operator is "exists" or "!",
+	// the values array must be empty. If the operator is "gt" or "lt", the values
+	// array must have a single element, which will be interpreted as an integer.
+	// +optional
+	Values []string
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PropagationPolicy selects federated objects and decides to which clusters they are
+// propagated and how their replicas are placed in these clusters. Annotations on the
+// objects themselves take precedence over the policy.
+type PropagationPolicy struct {
+	metav1.TypeMeta
+	// Standard object's metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+	// +optional
+	metav1.ObjectMeta
+
+	// Spec defines the objects the policy applies to and their placement.
+	// +optional
+	Spec PropagationPolicySpec
+}
+
+// PropagationPolicySpec describes the objects a propagation policy applies to and
+// how they are placed.
+type PropagationPolicySpec struct {
+	// The policy applies to the objects matched by any of the selectors.
+	ResourceSelectors []ResourceSelector
+
+	// Of several policies applying to an object the one with the highest priority
+	// is used; policies of equal priority are ordered by name. 0 by default.
+	// +optional
+	Priority int32
+
+	// Requirements on the labels of the clusters the objects are propagated to,
+	// as in the cluster selector annotation. Objects are propagated to all clusters
+	// if empty.
+	// +optional
+	ClusterSelector []ClusterSelectorRequirement
+
+	// Placement of the replicas of replica sets, deployments and jobs, as in the
+	// replica preferences annotations.
+	// +optional
+	ReplicaPreferences *ReplicaAllocationPreferences
+
+	// Name of the cluster set the objects are propagated to, as in the cluster
+	// set annotation. Applies in addition to ClusterSelector.
+	// +optional
+	ClusterSet string
+}
+
+// ResourceSelector selects federated objects by kind, namespace and labels.
+type ResourceSelector struct {
+	// Kind of the selected objects, e.g. "ReplicaSet".
+	Kind string
+
+	// Namespaces of the selected objects. All namespaces if empty.
+	// +optional
+	Namespaces []string
+
+	// Selector on the labels of the selected objects. All objects if not set.
+	// +optional
+	LabelSelector *metav1.LabelSelector
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// A list of propagation policies.
+type PropagationPolicyList struct {
+	metav1.TypeMeta
+	// Standard list metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+	// +optional
+	metav1.ListMeta
+
+	// List of PropagationPolicy objects.
+	Items []PropagationPolicy
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterSet is a named group of member clusters, such as "prod-eu" or "canary",
+// that the placement of federated objects can refer to.
+type ClusterSet struct {
+	metav1.TypeMeta
+	// Standard object's metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+	// +optional
+	metav1.ObjectMeta
+
+	// Spec defines the members of the set.
+	// +optional
+	Spec ClusterSetSpec
+
+	// Status reports the clusters of the federation that are members of the set.
+	// +optional
+	Status ClusterSetStatus
+}
+
+// ClusterSetSpec defines the members of a cluster set. A cluster is a member if it
+// is listed by name or if its labels satisfy the cluster selector.
+type ClusterSetSpec struct {
+	// Names of the member clusters. Clusters that have not joined the federation
+	// yet may be listed.
+	// +optional
+	Clusters []string
+
+	// Requirements on the labels of the member clusters, as in the cluster selector
+	// annotation. No cluster is a member by its labels if empty.
+	// +optional
+	ClusterSelector []ClusterSelectorRequirement
+}
+
+// ClusterSetStatus is the resolved membership of a cluster set.
+type ClusterSetStatus struct {
+	// Sorted names of the clusters of the federation that are members of the set.
+	// +optional
+	Clusters []string
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// A list of cluster sets.
+type ClusterSetList struct {
+	metav1.TypeMeta
+	// Standard list metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+	// +optional
+	metav1.ListMeta
+
+	// List of ClusterSet objects.
+	Items []ClusterSet
+}
+
 // Temporary/alpha structures to support custom replica assignments within Federated workloads.
 
 // A set of preferences that can be added to federated version of workloads (deployments, replicasets, ..)
@@ -143,6 +400,86 @@ type ReplicaAllocationPreferences struct {
 	// If omitted, clusters without explicit preferences should not have any replicas scheduled.
 	// +optional
 	Clusters map[string]ClusterPreferences
+
+	// Name of a cluster set. If set, "*" applies only to the members of the set.
+	// +optional
+	ClusterSet string
+
+	// Constraints on how replicas are spread across the regions and zones reported
+	// by the clusters. At most one constraint per topology key is honoured; a
+	// region constraint is applied before a zone constraint.
+	// +optional
+	TopologySpreadConstraints []TopologySpreadConstraint
+
+	// Maximum number of replicas moved back from lower priority clusters to higher
+	// priority clusters in a single scheduling pass once the latter regain capacity.
+	// Only used if Rebalance is true. Unbounded if no value provided (default).
+	// +optional
+	MaxDrainBackReplicas *int64
+
+	// Limits on how quickly replicas are moved between clusters.
+	// Replicas are moved all at once if no value provided (default).
+	// +optional
+	RebalanceStrategy *RebalanceStrategy
+}
+
+// RebalanceStrategy bounds the churn caused by moving replicas between clusters.
+// Clusters that should run more replicas are scaled up before clusters that should
+// run fewer replicas are scaled down, so that the number of ready replicas across
+// all clusters does not drop below the desired number minus MaxUnavailable.
+type RebalanceStrategy struct {
+	// Maximum number of replicas moved to other clusters within Interval.
+	// Unbounded if no value provided (default).
+	// +optional
+	MaxMovedReplicas *int64
+
+	// The period MaxMovedReplicas applies to. 1 minute by default.
+	// +optional
+	Interval metav1.Duration
+
+	// Maximum number of replicas that may be scheduled above the desired number
+	// across all clusters while replicas are moved.
+	// Unbounded if no value provided (default).
+	// +optional
+	MaxSurge *int64
+
+	// Maximum number of the desired replicas that may be unavailable across all
+	// clusters while replicas are moved. 0 by default. If both MaxSurge and
+	// MaxUnavailable are 0, a surge of 1 replica is allowed.
+	// +optional
+	MaxUnavailable int64
+}
+
+// TopologyKey names a cluster topology that replicas can be spread across.
+type TopologyKey string
+
+const (
+	// TopologyKeyRegion groups clusters by ClusterStatus.Region.
+	TopologyKeyRegion TopologyKey = "region"
+	// TopologyKeyZone groups clusters by the set of zones in ClusterStatus.Zones.
+	TopologyKeyZone TopologyKey = "zone"
+)
+
+// TopologySpreadConstraint restricts how the replicas of a federated workload are
+// spread across the topology domains (regions or zones) of the clusters.
+type TopologySpreadConstraint struct {
+	// The cluster topology to spread across, "region" or "zone".
+	TopologyKey TopologyKey
+
+	// Maximum number of clusters in a single domain that may get replicas.
+	// Unbounded if no value provided (default).
+	// +optional
+	MaxClustersPerDomain *int64
+
+	// Maximum permitted difference between the number of replicas in any two
+	// domains that can run replicas. Unbounded if no value provided (default).
+	// +optional
+	MaxSkew *int64
+
+	// Minimum number of domains that should get replicas. Scheduling fails
+	// rather than place replicas in fewer domains.
+	// +optional
+	MinDomains *int64
 }
 
 // Preferences regarding number of replicas assigned to a cluster workload object (dep, rs, ..) within
@@ -160,6 +497,54 @@ type ClusterPreferences struct {
 	// A number expressing the preference to put an additional replica to this cluster workload object.
 	// 0 by default.
 	Weight int64
+
+	// Clusters with higher priority are filled up to their maximum or estimated capacity before
+	// any replicas are assigned to clusters with lower priority. Weights only matter among
+	// clusters of the same priority. 0 by default.
+	// +optional
+	Priority int64
+}
+
+// HpaScalingMode selects how a federated horizontal pod autoscaler moves the
+// replica bounds of its local autoscalers between clusters.
+type HpaScalingMode string
+
+const (
+	// HpaScalingModeLocal moves bounds to a cluster once its local autoscaler
+	// is saturated, away from clusters whose autoscalers do not use them.
+	HpaScalingModeLocal HpaScalingMode = "Local"
+	// HpaScalingModeGlobal moves bounds toward clusters that are more utilized
+	// than the federation as a whole, before their local autoscalers saturate.
+	HpaScalingModeGlobal HpaScalingMode = "Global"
+)
+
+// A set of preferences that can be added to a federated horizontal pod autoscaler
+// as a json-serialized annotation.
+type HpaPreferences struct {
+	// Local (default) or Global.
+	// +optional
+	Mode HpaScalingMode
+
+	// Percentage of the difference between the current and the target bounds
+	// of a cluster that is moved in a single scheduling pass. Only used in
+	// Global mode. 50 by default.
+	// +optional
+	DampingPercent *int64
+
+	// Clusters whose utilization differs from the federation-wide utilization
+	// by at most this percentage keep their bounds. Only used in Global mode.
+	// 10 by default.
+	// +optional
+	TolerancePercent *int64
+
+	// Maximum number of max replicas moved between clusters within Interval.
+	// Only used in Global mode. Unbounded if no value provided (default).
+	// +optional
+	MaxMovedReplicas *int64
+
+	// The period MaxMovedReplicas applies to. 1 minute by default.
+	// +optional
+	Interval metav1.Duration
 }
 
 // Annotation for a federated service to keep record of service loadbalancer ingresses in federated cluster
@@ -46,8 +46,44 @@ type ClusterSpec struct {
 	// This can be left empty if the cluster allows insecure access.
 	// +optional
 	SecretRef *v1.LocalObjectReference `json:"secretRef,omitempty" protobuf:"bytes,2,opt,name=secretRef"`
+	// Maintenance puts the cluster in maintenance, e.g. while it is upgraded.
+	// The cluster stays joined and keeps its federated objects.
+	// +optional
+	Maintenance ClusterMaintenance `json:"maintenance,omitempty" protobuf:"bytes,3,opt,name=maintenance,casttype=ClusterMaintenance"`
+	// ConnectionMode is how the federation reaches the cluster, Push if not set.
+	// The federation controllers do not operate on clusters in Pull mode, whose
+	// agent applies their federated objects and reports their status instead.
+	// +optional
+	ConnectionMode ClusterConnectionMode `json:"connectionMode,omitempty" protobuf:"bytes,4,opt,name=connectionMode,casttype=ClusterConnectionMode"`
 }
 
+// ClusterMaintenance is the maintenance mode of a cluster.
+type ClusterMaintenance string
+
+const (
+	// ClusterCordoned means no new federated objects or replicas are placed
+	// in the cluster. The objects and replicas it runs are kept.
+	ClusterCordoned ClusterMaintenance = "Cordoned"
+	// ClusterDrained means the cluster is cordoned and the replicas of
+	// federated workloads are moved to other clusters as they become ready
+	// there. DNS records stop pointing to the services of the cluster.
+	ClusterDrained ClusterMaintenance = "Drained"
+)
+
+// ClusterConnectionMode is how the federation reaches a cluster.
+type ClusterConnectionMode string
+
+const (
+	// ClusterConnectionPush means the federation controllers connect to the
+	// API servers of the cluster.
+	ClusterConnectionPush ClusterConnectionMode = "Push"
+	// ClusterConnectionPull means the cluster can not be reached by the
+	// federation. An agent running in the cluster connects to the federation
+	// API server, applies the federated objects selected for the cluster and
+	// reports its status.
+	ClusterConnectionPull ClusterConnectionMode = "Pull"
+)
+
 type ClusterConditionType string
 
 // These are valid conditions of a cluster.
@@ -56,6 +92,17 @@ const (
 	ClusterReady ClusterConditionType = "Ready"
 	// ClusterOffline means the cluster is temporarily down or not reachable
 	ClusterOffline ClusterConditionType = "Offline"
+	// ClusterCredentialsExpiring means the credentials used to access the
+	// cluster expire soon, or have expired.
+	ClusterCredentialsExpiring ClusterConditionType = "CredentialsExpiring"
+	// ClusterMissingResources means the cluster does not serve the API
+	// resources some federated types require, and is skipped by their
+	// sync controllers.
+	ClusterMissingResources ClusterConditionType = "MissingResources"
+	// ClusterDegraded means the latest health checks of a ready cluster failed,
+	// but not enough of them to make it unready. Degraded clusters keep their
+	// federated objects, but no new objects are placed in them.
+	ClusterDegraded ClusterConditionType = "Degraded"
 )
 
 // ClusterCondition describes current state of a cluster.
@@ -90,6 +137,58 @@ type ClusterStatus struct {
 	// Region is the name of the region in which all of the nodes in the cluster exist.  e.g. 'us-east1'.
 	// +optional
 	Region string `json:"region,omitempty" protobuf:"bytes,6,opt,name=region"`
+	// ActiveServerAddress is the address the federation control plane currently uses to reach the cluster,
+	// one of the addresses in ServerAddressByClientCIDRs matching its client CIDR. Clients fail over to
+	// another healthy address when the active one stops answering its health checks.
+	// +optional
+	ActiveServerAddress string `json:"activeServerAddress,omitempty" protobuf:"bytes,7,opt,name=activeServerAddress"`
+	// ServerAddresses is the result of the last health check of each address of the cluster matching the
+	// client CIDR of the federation control plane, in the order they are listed in ServerAddressByClientCIDRs.
+	// +optional
+	ServerAddresses []ServerAddressStatus `json:"serverAddresses,omitempty" protobuf:"bytes,8,rep,name=serverAddresses"`
+	// Version is the Kubernetes version of the cluster, e.g. 'v1.9.3'.
+	// +optional
+	Version string `json:"version,omitempty" protobuf:"bytes,9,opt,name=version"`
+	// APIResources are the API resources served by the cluster, by group version.
+	// +optional
+	APIResources []ClusterAPIResources `json:"apiResources,omitempty" protobuf:"bytes,10,rep,name=apiResources"`
+	// ProbeHistory holds the results of the latest health checks of the cluster, oldest first.
+	// +optional
+	ProbeHistory []ClusterProbe `json:"probeHistory,omitempty" protobuf:"bytes,11,rep,name=probeHistory"`
+}
+
+// ClusterProbe is the result of a health check of a cluster.
+type ClusterProbe struct {
+	// Time of the health check.
+	Time metav1.Time `json:"time" protobuf:"bytes,1,opt,name=time"`
+	// Healthy is whether the active API server of the cluster answered the health check with ok.
+	Healthy bool `json:"healthy" protobuf:"varint,2,opt,name=healthy"`
+	// Human readable message describing the result of the health check.
+	// +optional
+	Message string `json:"message,omitempty" protobuf:"bytes,3,opt,name=message"`
+}
+
+// ClusterAPIResources lists the API resources a cluster serves in an API group version.
+type ClusterAPIResources struct {
+	// GroupVersion of the resources, e.g. 'extensions/v1beta1' or 'v1' for the core group.
+	GroupVersion string `json:"groupVersion" protobuf:"bytes,1,opt,name=groupVersion"`
+	// Resources are the plural names of the resources, e.g. 'replicasets'.
+	// +optional
+	Resources []string `json:"resources,omitempty" protobuf:"bytes,2,rep,name=resources"`
+}
+
+// ServerAddressStatus is the health of one of the API server addresses of a cluster.
+type ServerAddressStatus struct {
+	// Address of the server, as listed in ServerAddressByClientCIDRs.
+	ServerAddress string `json:"serverAddress" protobuf:"bytes,1,opt,name=serverAddress"`
+	// Healthy is whether the server answered its last health check with ok.
+	Healthy bool `json:"healthy" protobuf:"varint,2,opt,name=healthy"`
+	// Human readable message indicating the result of the last health check.
+	// +optional
+	Message string `json:"message,omitempty" protobuf:"bytes,3,opt,name=message"`
+	// Last time the server was checked.
+	// +optional
+	LastProbeTime metav1.Time `json:"lastProbeTime,omitempty" protobuf:"bytes,4,opt,name=lastProbeTime"`
 }
 
 // +genclient
@@ -126,6 +225,17 @@ type ClusterList struct {
 	Items []Cluster `json:"items" protobuf:"bytes,2,rep,name=items"`
 }
 
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterProxyOptions is the query options to a Cluster's proxy call.
+type ClusterProxyOptions struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Path is the URL path to use for the current proxy request to the cluster.
+	// +optional
+	Path string `json:"path,omitempty" protobuf:"bytes,1,opt,name=path"`
+}
+
 // Expressed as value of annotation for selecting the clusters on which a resource is created.
 type ClusterSelector []ClusterSelectorRequirement
 
@@ -148,6 +258,252 @@ type ClusterSelectorRequirement struct {
 	Values []string `json:"values,omitempty" protobuf:"bytes,3,rep,name=values"`
 }
 
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PropagationPolicy selects federated objects and decides to which clusters they are
+// propagated and how their replicas are placed in these clusters. Annotations on the
+// objects themselves take precedence over the policy.
+type PropagationPolicy struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object's metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	// Spec defines the objects the policy applies to and their placement.
+	// +optional
+	Spec PropagationPolicySpec `json:"spec,omitempty" protobuf:"bytes,2,opt,name=spec"`
+}
+
+// PropagationPolicySpec describes the objects a propagation policy applies to and
+// how they are placed.
+type PropagationPolicySpec struct {
+	// The policy applies to the objects matched by any of the selectors.
+	ResourceSelectors []ResourceSelector `json:"resourceSelectors" protobuf:"bytes,1,rep,name=resourceSelectors"`
+
+	// Of several policies applying to an object the one with the highest priority
+	// is used; policies of equal priority are ordered by name. 0 by default.
+	// +optional
+	Priority int32 `json:"priority,omitempty" protobuf:"varint,2,opt,name=priority"`
+
+	// Requirements on the labels of the clusters the objects are propagated to,
+	// as in the cluster selector annotation. Objects are propagated to all clusters
+	// if empty.
+	// +optional
+	ClusterSelector []ClusterSelectorRequirement `json:"clusterSelector,omitempty" protobuf:"bytes,3,rep,name=clusterSelector"`
+
+	// Placement of the replicas of replica sets, deployments and jobs, as in the
+	// replica preferences annotations.
+	// +optional
+	ReplicaPreferences *ReplicaAllocationPreferences `json:"replicaPreferences,omitempty" protobuf:"bytes,4,opt,name=replicaPreferences"`
+
+	// Name of the cluster set the objects are propagated to, as in the cluster
+	// set annotation. Applies in addition to ClusterSelector.
+	// +optional
+	ClusterSet string `json:"clusterSet,omitempty" protobuf:"bytes,5,opt,name=clusterSet"`
+}
+
+// ResourceSelector selects federated objects by kind, namespace and labels.
+type ResourceSelector struct {
+	// Kind of the selected objects, e.g. "ReplicaSet".
+	Kind string `json:"kind" protobuf:"bytes,1,opt,name=kind"`
+
+	// Namespaces of the selected objects. All namespaces if empty.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty" protobuf:"bytes,2,rep,name=namespaces"`
+
+	// Selector on the labels of the selected objects. All objects if not set.
+	// +optional
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty" protobuf:"bytes,3,opt,name=labelSelector"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// A list of propagation policies.
+type PropagationPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard list metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	// List of PropagationPolicy objects.
+	Items []PropagationPolicy `json:"items" protobuf:"bytes,2,rep,name=items"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterSet is a named group of member clusters, such as "prod-eu" or "canary",
+// that the placement of federated objects can refer to.
+type ClusterSet struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object's metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	// Spec defines the members of the set.
+	// +optional
+	Spec ClusterSetSpec `json:"spec,omitempty" protobuf:"bytes,2,opt,name=spec"`
+
+	// Status reports the clusters of the federation that are members of the set.
+	// +optional
+	Status ClusterSetStatus `json:"status,omitempty" protobuf:"bytes,3,opt,name=status"`
+}
+
+// ClusterSetSpec defines the members of a cluster set. A cluster is a member if it
+// is listed by name or if its labels satisfy the cluster selector.
+type ClusterSetSpec struct {
+	// Names of the member clusters. Clusters that have not joined the federation
+	// yet may be listed.
+	// +optional
+	Clusters []string `json:"clusters,omitempty" protobuf:"bytes,1,rep,name=clusters"`
+
+	// Requirements on the labels of the member clusters, as in the cluster selector
+	// annotation. No cluster is a member by its labels if empty.
+	// +optional
+	ClusterSelector []ClusterSelectorRequirement `json:"clusterSelector,omitempty" protobuf:"bytes,2,rep,name=clusterSelector"`
+}
+
+// ClusterSetStatus is the resolved membership of a cluster set.
+type ClusterSetStatus struct {
+	// Sorted names of the clusters of the federation that are members of the set.
+	// +optional
+	Clusters []string `json:"clusters,omitempty" protobuf:"bytes,1,rep,name=clusters"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// A list of cluster sets.
+type ClusterSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard list metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	// List of ClusterSet objects.
+	Items []ClusterSet `json:"items" protobuf:"bytes,2,rep,name=items"`
+}
+
+// ReplicaAllocationPreferences expresses in which clusters the replicas of a
+// federated workload are placed.
+type ReplicaAllocationPreferences struct {
+	// If set to true then already scheduled and running replicas may be moved to other clusters
+	// in order to match current state to the specified preferences. Otherwise, if set to false,
+	// up and running replicas will not be moved.
+	// +optional
+	Rebalance bool `json:"rebalance,omitempty" protobuf:"varint,1,opt,name=rebalance"`
+
+	// A mapping between cluster names and preferences regarding a local workload object (dep, rs, .. ) in
+	// these clusters.
+	// "*" (if provided) applies to all clusters if an explicit mapping is not provided.
+	// If omitted, clusters without explicit preferences should not have any replicas scheduled.
+	// +optional
+	Clusters map[string]ClusterPreferences `json:"clusters,omitempty" protobuf:"bytes,2,rep,name=clusters"`
+
+	// Name of a cluster set. If set, "*" applies only to the members of the set.
+	// +optional
+	ClusterSet string `json:"clusterSet,omitempty" protobuf:"bytes,6,opt,name=clusterSet"`
+
+	// Constraints on how replicas are spread across the regions and zones reported
+	// by the clusters. At most one constraint per topology key is honoured; a
+	// region constraint is applied before a zone constraint.
+	// +optional
+	TopologySpreadConstraints []TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty" protobuf:"bytes,3,rep,name=topologySpreadConstraints"`
+
+	// Maximum number of replicas moved back from lower priority clusters to higher
+	// priority clusters in a single scheduling pass once the latter regain capacity.
+	// Only used if Rebalance is true. Unbounded if no value provided (default).
+	// +optional
+	MaxDrainBackReplicas *int64 `json:"maxDrainBackReplicas,omitempty" protobuf:"varint,4,opt,name=maxDrainBackReplicas"`
+
+	// Limits on how quickly replicas are moved between clusters.
+	// Replicas are moved all at once if no value provided (default).
+	// +optional
+	RebalanceStrategy *RebalanceStrategy `json:"rebalanceStrategy,omitempty" protobuf:"bytes,5,opt,name=rebalanceStrategy"`
+}
+
+// RebalanceStrategy bounds the churn caused by moving replicas between clusters.
+type RebalanceStrategy struct {
+	// Maximum number of replicas moved to other clusters within Interval.
+	// Unbounded if no value provided (default).
+	// +optional
+	MaxMovedReplicas *int64 `json:"maxMovedReplicas,omitempty" protobuf:"varint,1,opt,name=maxMovedReplicas"`
+
+	// The period MaxMovedReplicas applies to. 1 minute by default.
+	// +optional
+	Interval metav1.Duration `json:"interval,omitempty" protobuf:"bytes,2,opt,name=interval"`
+
+	// Maximum number of replicas that may be scheduled above the desired number
+	// across all clusters while replicas are moved.
+	// Unbounded if no value provided (default).
+	// +optional
+	MaxSurge *int64 `json:"maxSurge,omitempty" protobuf:"varint,3,opt,name=maxSurge"`
+
+	// Maximum number of the desired replicas that may be unavailable across all
+	// clusters while replicas are moved. 0 by default.
+	// +optional
+	MaxUnavailable int64 `json:"maxUnavailable,omitempty" protobuf:"varint,4,opt,name=maxUnavailable"`
+}
+
+// TopologyKey names a cluster topology that replicas can be spread across.
+type TopologyKey string
+
+const (
+	// TopologyKeyRegion groups clusters by ClusterStatus.Region.
+	TopologyKeyRegion TopologyKey = "region"
+	// TopologyKeyZone groups clusters by the set of zones in ClusterStatus.Zones.
+	TopologyKeyZone TopologyKey = "zone"
+)
+
+// TopologySpreadConstraint restricts how the replicas of a federated workload are
+// spread across the topology domains (regions or zones) of the clusters.
+type TopologySpreadConstraint struct {
+	// The cluster topology to spread across, "region" or "zone".
+	TopologyKey TopologyKey `json:"topologyKey" protobuf:"bytes,1,opt,name=topologyKey,casttype=TopologyKey"`
+
+	// Maximum number of clusters in a single domain that may get replicas.
+	// Unbounded if no value provided (default).
+	// +optional
+	MaxClustersPerDomain *int64 `json:"maxClustersPerDomain,omitempty" protobuf:"varint,2,opt,name=maxClustersPerDomain"`
+
+	// Maximum permitted difference between the number of replicas in any two
+	// domains that can run replicas. Unbounded if no value provided (default).
+	// +optional
+	MaxSkew *int64 `json:"maxSkew,omitempty" protobuf:"varint,3,opt,name=maxSkew"`
+
+	// Minimum number of domains that should get replicas.
+	// +optional
+	MinDomains *int64 `json:"minDomains,omitempty" protobuf:"varint,4,opt,name=minDomains"`
+}
+
+// Preferences regarding number of replicas assigned to a cluster workload object (dep, rs, ..)
+// within a federated workload object.
+type ClusterPreferences struct {
+	// Minimum number of replicas that should be assigned to this cluster workload object. 0 by default.
+	// +optional
+	MinReplicas int64 `json:"minReplicas,omitempty" protobuf:"varint,1,opt,name=minReplicas"`
+
+	// Maximum number of replicas that should be assigned to this cluster workload object.
+	// Unbounded if no value provided (default).
+	// +optional
+	MaxReplicas *int64 `json:"maxReplicas,omitempty" protobuf:"varint,2,opt,name=maxReplicas"`
+
+	// A number expressing the preference to put an additional replica to this cluster workload object.
+	// 0 by default.
+	Weight int64 `json:"weight,omitempty" protobuf:"varint,3,opt,name=weight"`
+
+	// Clusters with higher priority are filled up to their maximum or estimated capacity before
+	// any replicas are assigned to clusters with lower priority. 0 by default.
+	// +optional
+	Priority int64 `json:"priority,omitempty" protobuf:"varint,4,opt,name=priority"`
+}
+
 const (
 	// FederationNamespaceSystem is the system namespace where we place federation control plane components.
 	FederationNamespaceSystem string = "federation-system"
@@ -155,6 +511,10 @@ const (
 	// FederationClusterSelectorAnnotation is used to determine placement of objects on federated clusters
 	FederationClusterSelectorAnnotation string = "federation.alpha.kubernetes.io/cluster-selector"
 
+	// FederationClusterSetAnnotation names the cluster set whose members objects are
+	// placed on. Applies in addition to the cluster selector annotation.
+	FederationClusterSetAnnotation string = "federation.alpha.kubernetes.io/cluster-set"
+
 	// FederationOnlyClusterSelector is the cluster selector to indicate any object in
 	// federation having this annotation should not be synced to federated clusters.
 	FederationOnlyClusterSelector string = "federation.kubernetes.io/federation-control-plane=true"
@@ -38,6 +38,16 @@ func (Cluster) SwaggerDoc() map[string]string {
 	return map_Cluster
 }
 
+var map_ClusterAPIResources = map[string]string{
+	"":             "ClusterAPIResources lists the API resources a cluster serves in an API group version.",
+	"groupVersion": "GroupVersion of the resources, e.g. 'extensions/v1beta1' or 'v1' for the core group.",
+	"resources":    "Resources are the plural names of the resources, e.g. 'replicasets'.",
+}
+
+func (ClusterAPIResources) SwaggerDoc() map[string]string {
+	return map_ClusterAPIResources
+}
+
 var map_ClusterCondition = map[string]string{
 	"":                   "ClusterCondition describes current state of a cluster.",
 	"type":               "Type of cluster condition, Complete or Failed.",
@@ -62,6 +72,38 @@ func (ClusterList) SwaggerDoc() map[string]string {
 	return map_ClusterList
 }
 
+var map_ClusterPreferences = map[string]string{
+	"":            "Preferences regarding number of replicas assigned to a cluster workload object (dep, rs, ..) within a federated workload object.",
+	"minReplicas": "Minimum number of replicas that should be assigned to this cluster workload object. 0 by default.",
+	"maxReplicas": "Maximum number of replicas that should be assigned to this cluster workload object. Unbounded if no value provided (default).",
+	"weight":      "A number expressing the preference to put an additional replica to this cluster workload object. 0 by default.",
+	"priority":    "Clusters with higher priority are filled up to their maximum or estimated capacity before any replicas are assigned to clusters with lower priority. 0 by default.",
+}
+
+func (ClusterPreferences) SwaggerDoc() map[string]string {
+	return map_ClusterPreferences
+}
+
+var map_ClusterProbe = map[string]string{
+	"":        "ClusterProbe is the result of a health check of a cluster.",
+	"time":    "Time of the health check.",
+	"healthy": "Healthy is whether the active API server of the cluster answered the health check with ok.",
+	"message": "Human readable message describing the result of the health check.",
+}
+
+func (ClusterProbe) SwaggerDoc() map[string]string {
+	return map_ClusterProbe
+}
+
+var map_ClusterProxyOptions = map[string]string{
+	"":     "ClusterProxyOptions is the query options to a Cluster's proxy call.",
+	"path": "Path is the URL path to use for the current proxy request to the cluster.",
+}
+
+func (ClusterProxyOptions) SwaggerDoc() map[string]string {
+	return map_ClusterProxyOptions
+}
+
 var map_ClusterSelectorRequirement = map[string]string{
 	"":         "ClusterSelectorRequirement contains values, a key, and an operator that relates the key and values. The zero value of ClusterSelectorRequirement is invalid. ClusterSelectorRequirement implements both set based match and exact match",
 	"operator": "The Operator defines how the Key is matched to the Values. One of \"in\", \"notin\", \"exists\", \"!\", \"=\", \"!=\", \"gt\" or \"lt\".",
@@ -72,10 +114,52 @@ func (ClusterSelectorRequirement) SwaggerDoc() map[string]string {
 	return map_ClusterSelectorRequirement
 }
 
+var map_ClusterSet = map[string]string{
+	"":         "ClusterSet is a named group of member clusters, such as \"prod-eu\" or \"canary\", that the placement of federated objects can refer to.",
+	"metadata": "Standard object's metadata. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata",
+	"spec":     "Spec defines the members of the set.",
+	"status":   "Status reports the clusters of the federation that are members of the set.",
+}
+
+func (ClusterSet) SwaggerDoc() map[string]string {
+	return map_ClusterSet
+}
+
+var map_ClusterSetList = map[string]string{
+	"":         "A list of cluster sets.",
+	"metadata": "Standard list metadata. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+	"items":    "List of ClusterSet objects.",
+}
+
+func (ClusterSetList) SwaggerDoc() map[string]string {
+	return map_ClusterSetList
+}
+
+var map_ClusterSetSpec = map[string]string{
+	"":                "ClusterSetSpec defines the members of a cluster set. A cluster is a member if it is listed by name or if its labels satisfy the cluster selector.",
+	"clusters":        "Names of the member clusters. Clusters that have not joined the federation yet may be listed.",
+	"clusterSelector": "Requirements on the labels of the member clusters, as in the cluster selector annotation. No cluster is a member by its labels if empty.",
+}
+
+func (ClusterSetSpec) SwaggerDoc() map[string]string {
+	return map_ClusterSetSpec
+}
+
+var map_ClusterSetStatus = map[string]string{
+	"":         "ClusterSetStatus is the resolved membership of a cluster set.",
+	"clusters": "Sorted names of the clusters of the federation that are members of the set.",
+}
+
+func (ClusterSetStatus) SwaggerDoc() map[string]string {
+	return map_ClusterSetStatus
+}
+
 var map_ClusterSpec = map[string]string{
 	"": "ClusterSpec describes the attributes of a kubernetes cluster.",
 	"serverAddressByClientCIDRs": "A map of client CIDR to server address. This is to help clients reach servers in the most network-efficient way possible. Clients can use the appropriate server address as per the CIDR that they match. In case of multiple matches, clients should use the longest matching CIDR.",
 	"secretRef":                  "Name of the secret containing kubeconfig to access this cluster. The secret is read from the kubernetes cluster that is hosting federation control plane. Admin needs to ensure that the required secret exists. Secret should be in the same namespace where federation control plane is hosted and it should have kubeconfig in its data with key \"kubeconfig\". This will later be changed to a reference to secret in federation control plane when the federation control plane supports secrets. This can be left empty if the cluster allows insecure access.",
+	"maintenance":                "Maintenance puts the cluster in maintenance, e.g. while it is upgraded. The cluster stays joined and keeps its federated objects.",
+	"connectionMode":             "ConnectionMode is how the federation reaches the cluster, Push if not set. The federation controllers do not operate on clusters in Pull mode, whose agent applies their federated objects and reports their status instead.",
 }
 
 func (ClusterSpec) SwaggerDoc() map[string]string {
@@ -83,16 +167,91 @@ func (ClusterSpec) SwaggerDoc() map[string]string {
 }
 
 var map_ClusterStatus = map[string]string{
-	"":           "ClusterStatus is information about the current status of a cluster updated by cluster controller periodically.",
-	"conditions": "Conditions is an array of current cluster conditions.",
-	"zones":      "Zones is the list of availability zones in which the nodes of the cluster exist, e.g. 'us-east1-a'. These will always be in the same region.",
-	"region":     "Region is the name of the region in which all of the nodes in the cluster exist.  e.g. 'us-east1'.",
+	"":                    "ClusterStatus is information about the current status of a cluster updated by cluster controller periodically.",
+	"conditions":          "Conditions is an array of current cluster conditions.",
+	"zones":               "Zones is the list of availability zones in which the nodes of the cluster exist, e.g. 'us-east1-a'. These will always be in the same region.",
+	"region":              "Region is the name of the region in which all of the nodes in the cluster exist.  e.g. 'us-east1'.",
+	"activeServerAddress": "ActiveServerAddress is the address the federation control plane currently uses to reach the cluster, one of the addresses in ServerAddressByClientCIDRs matching its client CIDR. Clients fail over to another healthy address when the active one stops answering its health checks.",
+	"serverAddresses":     "ServerAddresses is the result of the last health check of each address of the cluster matching the client CIDR of the federation control plane, in the order they are listed in ServerAddressByClientCIDRs.",
+	"version":             "Version is the Kubernetes version of the cluster, e.g. 'v1.9.3'.",
+	"apiResources":        "APIResources are the API resources served by the cluster, by group version.",
+	"probeHistory":        "ProbeHistory holds the results of the latest health checks of the cluster, oldest first.",
 }
 
 func (ClusterStatus) SwaggerDoc() map[string]string {
 	return map_ClusterStatus
 }
 
+var map_PropagationPolicy = map[string]string{
+	"":         "PropagationPolicy selects federated objects and decides to which clusters they are propagated and how their replicas are placed in these clusters. Annotations on the objects themselves take precedence over the policy.",
+	"metadata": "Standard object's metadata. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata",
+	"spec":     "Spec defines the objects the policy applies to and their placement.",
+}
+
+func (PropagationPolicy) SwaggerDoc() map[string]string {
+	return map_PropagationPolicy
+}
+
+var map_PropagationPolicyList = map[string]string{
+	"":         "A list of propagation policies.",
+	"metadata": "Standard list metadata. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+	"items":    "List of PropagationPolicy objects.",
+}
+
+func (PropagationPolicyList) SwaggerDoc() map[string]string {
+	return map_PropagationPolicyList
+}
+
+var map_PropagationPolicySpec = map[string]string{
+	"":                   "PropagationPolicySpec describes the objects a propagation policy applies to and how they are placed.",
+	"resourceSelectors":  "The policy applies to the objects matched by any of the selectors.",
+	"priority":           "Of several policies applying to an object the one with the highest priority is used; policies of equal priority are ordered by name. 0 by default.",
+	"clusterSelector":    "Requirements on the labels of the clusters the objects are propagated to, as in the cluster selector annotation. Objects are propagated to all clusters if empty.",
+	"replicaPreferences": "Placement of the replicas of replica sets, deployments and jobs, as in the replica preferences annotations.",
+	"clusterSet":         "Name of the cluster set the objects are propagated to, as in the cluster set annotation. Applies in addition to ClusterSelector.",
+}
+
+func (PropagationPolicySpec) SwaggerDoc() map[string]string {
+	return map_PropagationPolicySpec
+}
+
+var map_RebalanceStrategy = map[string]string{
+	"":                 "RebalanceStrategy bounds the churn caused by moving replicas between clusters.",
+	"maxMovedReplicas": "Maximum number of replicas moved to other clusters within Interval. Unbounded if no value provided (default).",
+	"interval":         "The period MaxMovedReplicas applies to. 1 minute by default.",
+	"maxSurge":         "Maximum number of replicas that may be scheduled above the desired number across all clusters while replicas are moved. Unbounded if no value provided (default).",
+	"maxUnavailable":   "Maximum number of the desired replicas that may be unavailable across all clusters while replicas are moved. 0 by default.",
+}
+
+func (RebalanceStrategy) SwaggerDoc() map[string]string {
+	return map_RebalanceStrategy
+}
+
+var map_ReplicaAllocationPreferences = map[string]string{
+	"":                          "ReplicaAllocationPreferences expresses in which clusters the replicas of a federated workload are placed.",
+	"rebalance":                 "If set to true then already scheduled and running replicas may be moved to other clusters in order to match current state to the specified preferences. Otherwise, if set to false, up and running replicas will not be moved.",
+	"clusters":                  "A mapping between cluster names and preferences regarding a local workload object (dep, rs, .. ) in these clusters. \"*\" (if provided) applies to all clusters if an explicit mapping is not provided. If omitted, clusters without explicit preferences should not have any replicas scheduled.",
+	"clusterSet":                "Name of a cluster set. If set, \"*\" applies only to the members of the set.",
+	"topologySpreadConstraints": "Constraints on how replicas are spread across the regions and zones reported by the clusters. At most one constraint per topology key is honoured; a region constraint is applied before a zone constraint.",
+	"maxDrainBackReplicas":      "Maximum number of replicas moved back from lower priority clusters to higher priority clusters in a single scheduling pass once the latter regain capacity. Only used if Rebalance is true. Unbounded if no value provided (default).",
+	"rebalanceStrategy":         "Limits on how quickly replicas are moved between clusters. Replicas are moved all at once if no value provided (default).",
+}
+
+func (ReplicaAllocationPreferences) SwaggerDoc() map[string]string {
+	return map_ReplicaAllocationPreferences
+}
+
+var map_ResourceSelector = map[string]string{
+	"":              "ResourceSelector selects federated objects by kind, namespace and labels.",
+	"kind":          "Kind of the selected objects, e.g. \"ReplicaSet\".",
+	"namespaces":    "Namespaces of the selected objects. All namespaces if empty.",
+	"labelSelector": "Selector on the labels of the selected objects. All objects if not set.",
+}
+
+func (ResourceSelector) SwaggerDoc() map[string]string {
+	return map_ResourceSelector
+}
+
 var map_ServerAddressByClientCIDR = map[string]string{
 	"":              "ServerAddressByClientCIDR helps the client to determine the server address that they should use, depending on the clientCIDR that they match.",
 	"clientCIDR":    "The CIDR with which clients can match their IP to figure out the server address that they should use.",
@@ -103,4 +262,28 @@ func (ServerAddressByClientCIDR) SwaggerDoc() map[string]string {
 	return map_ServerAddressByClientCIDR
 }
 
+var map_ServerAddressStatus = map[string]string{
+	"":              "ServerAddressStatus is the health of one of the API server addresses of a cluster.",
+	"serverAddress": "Address of the server, as listed in ServerAddressByClientCIDRs.",
+	"healthy":       "Healthy is whether the server answered its last health check with ok.",
+	"message":       "Human readable message indicating the result of the last health check.",
+	"lastProbeTime": "Last time the server was checked.",
+}
+
+func (ServerAddressStatus) SwaggerDoc() map[string]string {
+	return map_ServerAddressStatus
+}
+
+var map_TopologySpreadConstraint = map[string]string{
+	"":                     "TopologySpreadConstraint restricts how the replicas of a federated workload are spread across the topology domains (regions or zones) of the clusters.",
+	"topologyKey":          "The cluster topology to spread across, \"region\" or \"zone\".",
+	"maxClustersPerDomain": "Maximum number of clusters in a single domain that may get replicas. Unbounded if no value provided (default).",
+	"maxSkew":              "Maximum permitted difference between the number of replicas in any two domains that can run replicas. Unbounded if no value provided (default).",
+	"minDomains":           "Minimum number of domains that should get replicas.",
+}
+
+func (TopologySpreadConstraint) SwaggerDoc() map[string]string {
+	return map_TopologySpreadConstraint
+}
+
 // AUTO-GENERATED FUNCTIONS END HERE
@@ -24,6 +24,7 @@ import (
 	unsafe "unsafe"
 
 	v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	federation "k8s.io/federation/apis/federation"
@@ -40,16 +41,50 @@ func RegisterConversions(scheme *runtime.Scheme) error {
 	return scheme.AddGeneratedConversionFuncs(
 		Convert_v1beta1_Cluster_To_federation_Cluster,
 		Convert_federation_Cluster_To_v1beta1_Cluster,
+		Convert_v1beta1_ClusterAPIResources_To_federation_ClusterAPIResources,
+		Convert_federation_ClusterAPIResources_To_v1beta1_ClusterAPIResources,
 		Convert_v1beta1_ClusterCondition_To_federation_ClusterCondition,
 		Convert_federation_ClusterCondition_To_v1beta1_ClusterCondition,
 		Convert_v1beta1_ClusterList_To_federation_ClusterList,
 		Convert_federation_ClusterList_To_v1beta1_ClusterList,
+		Convert_v1beta1_ClusterPreferences_To_federation_ClusterPreferences,
+		Convert_federation_ClusterPreferences_To_v1beta1_ClusterPreferences,
+		Convert_v1beta1_ClusterProbe_To_federation_ClusterProbe,
+		Convert_federation_ClusterProbe_To_v1beta1_ClusterProbe,
+		Convert_v1beta1_ClusterProxyOptions_To_federation_ClusterProxyOptions,
+		Convert_federation_ClusterProxyOptions_To_v1beta1_ClusterProxyOptions,
+		Convert_v1beta1_ClusterSelectorRequirement_To_federation_ClusterSelectorRequirement,
+		Convert_federation_ClusterSelectorRequirement_To_v1beta1_ClusterSelectorRequirement,
+		Convert_v1beta1_ClusterSet_To_federation_ClusterSet,
+		Convert_federation_ClusterSet_To_v1beta1_ClusterSet,
+		Convert_v1beta1_ClusterSetList_To_federation_ClusterSetList,
+		Convert_federation_ClusterSetList_To_v1beta1_ClusterSetList,
+		Convert_v1beta1_ClusterSetSpec_To_federation_ClusterSetSpec,
+		Convert_federation_ClusterSetSpec_To_v1beta1_ClusterSetSpec,
+		Convert_v1beta1_ClusterSetStatus_To_federation_ClusterSetStatus,
+		Convert_federation_ClusterSetStatus_To_v1beta1_ClusterSetStatus,
 		Convert_v1beta1_ClusterSpec_To_federation_ClusterSpec,
 		Convert_federation_ClusterSpec_To_v1beta1_ClusterSpec,
 		Convert_v1beta1_ClusterStatus_To_federation_ClusterStatus,
 		Convert_federation_ClusterStatus_To_v1beta1_ClusterStatus,
+		Convert_v1beta1_PropagationPolicy_To_federation_PropagationPolicy,
+		Convert_federation_PropagationPolicy_To_v1beta1_PropagationPolicy,
+		Convert_v1beta1_PropagationPolicyList_To_federation_PropagationPolicyList,
+		Convert_federation_PropagationPolicyList_To_v1beta1_PropagationPolicyList,
+		Convert_v1beta1_PropagationPolicySpec_To_federation_PropagationPolicySpec,
+		Convert_federation_PropagationPolicySpec_To_v1beta1_PropagationPolicySpec,
+		Convert_v1beta1_RebalanceStrategy_To_federation_RebalanceStrategy,
+		Convert_federation_RebalanceStrategy_To_v1beta1_RebalanceStrategy,
+		Convert_v1beta1_ReplicaAllocationPreferences_To_federation_ReplicaAllocationPreferences,
+		Convert_federation_ReplicaAllocationPreferences_To_v1beta1_ReplicaAllocationPreferences,
+		Convert_v1beta1_ResourceSelector_To_federation_ResourceSelector,
+		Convert_federation_ResourceSelector_To_v1beta1_ResourceSelector,
 		Convert_v1beta1_ServerAddressByClientCIDR_To_federation_ServerAddressByClientCIDR,
 		Convert_federation_ServerAddressByClientCIDR_To_v1beta1_ServerAddressByClientCIDR,
+		Convert_v1beta1_ServerAddressStatus_To_federation_ServerAddressStatus,
+		Convert_federation_ServerAddressStatus_To_v1beta1_ServerAddressStatus,
+		Convert_v1beta1_TopologySpreadConstraint_To_federation_TopologySpreadConstraint,
+		Convert_federation_TopologySpreadConstraint_To_v1beta1_TopologySpreadConstraint,
 	)
 }
 
@@ -85,6 +120,28 @@ func Convert_federation_Cluster_To_v1beta1_Cluster(in *federation.Cluster, out *
 	return autoConvert_federation_Cluster_To_v1beta1_Cluster(in, out, s)
 }
 
+func autoConvert_v1beta1_ClusterAPIResources_To_federation_ClusterAPIResources(in *ClusterAPIResources, out *federation.ClusterAPIResources, s conversion.Scope) error {
+	out.GroupVersion = in.GroupVersion
+	out.Resources = *(*[]string)(unsafe.Pointer(&in.Resources))
+	return nil
+}
+
+// Convert_v1beta1_ClusterAPIResources_To_federation_ClusterAPIResources is an autogenerated conversion function.
+func Convert_v1beta1_ClusterAPIResources_To_federation_ClusterAPIResources(in *ClusterAPIResources, out *federation.ClusterAPIResources, s conversion.Scope) error {
+	return autoConvert_v1beta1_ClusterAPIResources_To_federation_ClusterAPIResources(in, out, s)
+}
+
+func autoConvert_federation_ClusterAPIResources_To_v1beta1_ClusterAPIResources(in *federation.ClusterAPIResources, out *ClusterAPIResources, s conversion.Scope) error {
+	out.GroupVersion = in.GroupVersion
+	out.Resources = *(*[]string)(unsafe.Pointer(&in.Resources))
+	return nil
+}
+
+// Convert_federation_ClusterAPIResources_To_v1beta1_ClusterAPIResources is an autogenerated conversion function.
+func Convert_federation_ClusterAPIResources_To_v1beta1_ClusterAPIResources(in *federation.ClusterAPIResources, out *ClusterAPIResources, s conversion.Scope) error {
+	return autoConvert_federation_ClusterAPIResources_To_v1beta1_ClusterAPIResources(in, out, s)
+}
+
 func autoConvert_v1beta1_ClusterCondition_To_federation_ClusterCondition(in *ClusterCondition, out *federation.ClusterCondition, s conversion.Scope) error {
 	out.Type = federation.ClusterConditionType(in.Type)
 	out.Status = core.ConditionStatus(in.Status)
@@ -137,9 +194,201 @@ func Convert_federation_ClusterList_To_v1beta1_ClusterList(in *federation.Cluste
 	return autoConvert_federation_ClusterList_To_v1beta1_ClusterList(in, out, s)
 }
 
+func autoConvert_v1beta1_ClusterPreferences_To_federation_ClusterPreferences(in *ClusterPreferences, out *federation.ClusterPreferences, s conversion.Scope) error {
+	out.MinReplicas = in.MinReplicas
+	out.MaxReplicas = (*int64)(unsafe.Pointer(in.MaxReplicas))
+	out.Weight = in.Weight
+	out.Priority = in.Priority
+	return nil
+}
+
+// Convert_v1beta1_ClusterPreferences_To_federation_ClusterPreferences is an autogenerated conversion function.
+func Convert_v1beta1_ClusterPreferences_To_federation_ClusterPreferences(in *ClusterPreferences, out *federation.ClusterPreferences, s conversion.Scope) error {
+	return autoConvert_v1beta1_ClusterPreferences_To_federation_ClusterPreferences(in, out, s)
+}
+
+func autoConvert_federation_ClusterPreferences_To_v1beta1_ClusterPreferences(in *federation.ClusterPreferences, out *ClusterPreferences, s conversion.Scope) error {
+	out.MinReplicas = in.MinReplicas
+	out.MaxReplicas = (*int64)(unsafe.Pointer(in.MaxReplicas))
+	out.Weight = in.Weight
+	out.Priority = in.Priority
+	return nil
+}
+
+// Convert_federation_ClusterPreferences_To_v1beta1_ClusterPreferences is an autogenerated conversion function.
+func Convert_federation_ClusterPreferences_To_v1beta1_ClusterPreferences(in *federation.ClusterPreferences, out *ClusterPreferences, s conversion.Scope) error {
+	return autoConvert_federation_ClusterPreferences_To_v1beta1_ClusterPreferences(in, out, s)
+}
+
+func autoConvert_v1beta1_ClusterProbe_To_federation_ClusterProbe(in *ClusterProbe, out *federation.ClusterProbe, s conversion.Scope) error {
+	out.Time = in.Time
+	out.Healthy = in.Healthy
+	out.Message = in.Message
+	return nil
+}
+
+// Convert_v1beta1_ClusterProbe_To_federation_ClusterProbe is an autogenerated conversion function.
+func Convert_v1beta1_ClusterProbe_To_federation_ClusterProbe(in *ClusterProbe, out *federation.ClusterProbe, s conversion.Scope) error {
+	return autoConvert_v1beta1_ClusterProbe_To_federation_ClusterProbe(in, out, s)
+}
+
+func autoConvert_federation_ClusterProbe_To_v1beta1_ClusterProbe(in *federation.ClusterProbe, out *ClusterProbe, s conversion.Scope) error {
+	out.Time = in.Time
+	out.Healthy = in.Healthy
+	out.Message = in.Message
+	return nil
+}
+
+// Convert_federation_ClusterProbe_To_v1beta1_ClusterProbe is an autogenerated conversion function.
+func Convert_federation_ClusterProbe_To_v1beta1_ClusterProbe(in *federation.ClusterProbe, out *ClusterProbe, s conversion.Scope) error {
+	return autoConvert_federation_ClusterProbe_To_v1beta1_ClusterProbe(in, out, s)
+}
+
+func autoConvert_v1beta1_ClusterProxyOptions_To_federation_ClusterProxyOptions(in *ClusterProxyOptions, out *federation.ClusterProxyOptions, s conversion.Scope) error {
+	out.Path = in.Path
+	return nil
+}
+
+// Convert_v1beta1_ClusterProxyOptions_To_federation_ClusterProxyOptions is an autogenerated conversion function.
+func Convert_v1beta1_ClusterProxyOptions_To_federation_ClusterProxyOptions(in *ClusterProxyOptions, out *federation.ClusterProxyOptions, s conversion.Scope) error {
+	return autoConvert_v1beta1_ClusterProxyOptions_To_federation_ClusterProxyOptions(in, out, s)
+}
+
+func autoConvert_federation_ClusterProxyOptions_To_v1beta1_ClusterProxyOptions(in *federation.ClusterProxyOptions, out *ClusterProxyOptions, s conversion.Scope) error {
+	out.Path = in.Path
+	return nil
+}
+
+// Convert_federation_ClusterProxyOptions_To_v1beta1_ClusterProxyOptions is an autogenerated conversion function.
+func Convert_federation_ClusterProxyOptions_To_v1beta1_ClusterProxyOptions(in *federation.ClusterProxyOptions, out *ClusterProxyOptions, s conversion.Scope) error {
+	return autoConvert_federation_ClusterProxyOptions_To_v1beta1_ClusterProxyOptions(in, out, s)
+}
+
+func autoConvert_v1beta1_ClusterSelectorRequirement_To_federation_ClusterSelectorRequirement(in *ClusterSelectorRequirement, out *federation.ClusterSelectorRequirement, s conversion.Scope) error {
+	out.Key = in.Key
+	out.Operator = in.Operator
+	out.Values = *(*[]string)(unsafe.Pointer(&in.Values))
+	return nil
+}
+
+// Convert_v1beta1_ClusterSelectorRequirement_To_federation_ClusterSelectorRequirement is an autogenerated conversion function.
+func Convert_v1beta1_ClusterSelectorRequirement_To_federation_ClusterSelectorRequirement(in *ClusterSelectorRequirement, out *federation.ClusterSelectorRequirement, s conversion.Scope) error {
+	return autoConvert_v1beta1_ClusterSelectorRequirement_To_federation_ClusterSelectorRequirement(in, out, s)
+}
+
+func autoConvert_federation_ClusterSelectorRequirement_To_v1beta1_ClusterSelectorRequirement(in *federation.ClusterSelectorRequirement, out *ClusterSelectorRequirement, s conversion.Scope) error {
+	out.Key = in.Key
+	out.Operator = in.Operator
+	out.Values = *(*[]string)(unsafe.Pointer(&in.Values))
+	return nil
+}
+
+// Convert_federation_ClusterSelectorRequirement_To_v1beta1_ClusterSelectorRequirement is an autogenerated conversion function.
+func Convert_federation_ClusterSelectorRequirement_To_v1beta1_ClusterSelectorRequirement(in *federation.ClusterSelectorRequirement, out *ClusterSelectorRequirement, s conversion.Scope) error {
+	return autoConvert_federation_ClusterSelectorRequirement_To_v1beta1_ClusterSelectorRequirement(in, out, s)
+}
+
+func autoConvert_v1beta1_ClusterSet_To_federation_ClusterSet(in *ClusterSet, out *federation.ClusterSet, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1beta1_ClusterSetSpec_To_federation_ClusterSetSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1beta1_ClusterSetStatus_To_federation_ClusterSetStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_ClusterSet_To_federation_ClusterSet is an autogenerated conversion function.
+func Convert_v1beta1_ClusterSet_To_federation_ClusterSet(in *ClusterSet, out *federation.ClusterSet, s conversion.Scope) error {
+	return autoConvert_v1beta1_ClusterSet_To_federation_ClusterSet(in, out, s)
+}
+
+func autoConvert_federation_ClusterSet_To_v1beta1_ClusterSet(in *federation.ClusterSet, out *ClusterSet, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_federation_ClusterSetSpec_To_v1beta1_ClusterSetSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_federation_ClusterSetStatus_To_v1beta1_ClusterSetStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_federation_ClusterSet_To_v1beta1_ClusterSet is an autogenerated conversion function.
+func Convert_federation_ClusterSet_To_v1beta1_ClusterSet(in *federation.ClusterSet, out *ClusterSet, s conversion.Scope) error {
+	return autoConvert_federation_ClusterSet_To_v1beta1_ClusterSet(in, out, s)
+}
+
+func autoConvert_v1beta1_ClusterSetList_To_federation_ClusterSetList(in *ClusterSetList, out *federation.ClusterSetList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]federation.ClusterSet)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_v1beta1_ClusterSetList_To_federation_ClusterSetList is an autogenerated conversion function.
+func Convert_v1beta1_ClusterSetList_To_federation_ClusterSetList(in *ClusterSetList, out *federation.ClusterSetList, s conversion.Scope) error {
+	return autoConvert_v1beta1_ClusterSetList_To_federation_ClusterSetList(in, out, s)
+}
+
+func autoConvert_federation_ClusterSetList_To_v1beta1_ClusterSetList(in *federation.ClusterSetList, out *ClusterSetList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]ClusterSet)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_federation_ClusterSetList_To_v1beta1_ClusterSetList is an autogenerated conversion function.
+func Convert_federation_ClusterSetList_To_v1beta1_ClusterSetList(in *federation.ClusterSetList, out *ClusterSetList, s conversion.Scope) error {
+	return autoConvert_federation_ClusterSetList_To_v1beta1_ClusterSetList(in, out, s)
+}
+
+func autoConvert_v1beta1_ClusterSetSpec_To_federation_ClusterSetSpec(in *ClusterSetSpec, out *federation.ClusterSetSpec, s conversion.Scope) error {
+	out.Clusters = *(*[]string)(unsafe.Pointer(&in.Clusters))
+	out.ClusterSelector = *(*[]federation.ClusterSelectorRequirement)(unsafe.Pointer(&in.ClusterSelector))
+	return nil
+}
+
+// Convert_v1beta1_ClusterSetSpec_To_federation_ClusterSetSpec is an autogenerated conversion function.
+func Convert_v1beta1_ClusterSetSpec_To_federation_ClusterSetSpec(in *ClusterSetSpec, out *federation.ClusterSetSpec, s conversion.Scope) error {
+	return autoConvert_v1beta1_ClusterSetSpec_To_federation_ClusterSetSpec(in, out, s)
+}
+
+func autoConvert_federation_ClusterSetSpec_To_v1beta1_ClusterSetSpec(in *federation.ClusterSetSpec, out *ClusterSetSpec, s conversion.Scope) error {
+	out.Clusters = *(*[]string)(unsafe.Pointer(&in.Clusters))
+	out.ClusterSelector = *(*[]ClusterSelectorRequirement)(unsafe.Pointer(&in.ClusterSelector))
+	return nil
+}
+
+// Convert_federation_ClusterSetSpec_To_v1beta1_ClusterSetSpec is an autogenerated conversion function.
+func Convert_federation_ClusterSetSpec_To_v1beta1_ClusterSetSpec(in *federation.ClusterSetSpec, out *ClusterSetSpec, s conversion.Scope) error {
+	return autoConvert_federation_ClusterSetSpec_To_v1beta1_ClusterSetSpec(in, out, s)
+}
+
+func autoConvert_v1beta1_ClusterSetStatus_To_federation_ClusterSetStatus(in *ClusterSetStatus, out *federation.ClusterSetStatus, s conversion.Scope) error {
+	out.Clusters = *(*[]string)(unsafe.Pointer(&in.Clusters))
+	return nil
+}
+
+// Convert_v1beta1_ClusterSetStatus_To_federation_ClusterSetStatus is an autogenerated conversion function.
+func Convert_v1beta1_ClusterSetStatus_To_federation_ClusterSetStatus(in *ClusterSetStatus, out *federation.ClusterSetStatus, s conversion.Scope) error {
+	return autoConvert_v1beta1_ClusterSetStatus_To_federation_ClusterSetStatus(in, out, s)
+}
+
+func autoConvert_federation_ClusterSetStatus_To_v1beta1_ClusterSetStatus(in *federation.ClusterSetStatus, out *ClusterSetStatus, s conversion.Scope) error {
+	out.Clusters = *(*[]string)(unsafe.Pointer(&in.Clusters))
+	return nil
+}
+
+// Convert_federation_ClusterSetStatus_To_v1beta1_ClusterSetStatus is an autogenerated conversion function.
+func Convert_federation_ClusterSetStatus_To_v1beta1_ClusterSetStatus(in *federation.ClusterSetStatus, out *ClusterSetStatus, s conversion.Scope) error {
+	return autoConvert_federation_ClusterSetStatus_To_v1beta1_ClusterSetStatus(in, out, s)
+}
+
 func autoConvert_v1beta1_ClusterSpec_To_federation_ClusterSpec(in *ClusterSpec, out *federation.ClusterSpec, s conversion.Scope) error {
 	out.ServerAddressByClientCIDRs = *(*[]federation.ServerAddressByClientCIDR)(unsafe.Pointer(&in.ServerAddressByClientCIDRs))
 	out.SecretRef = (*core.LocalObjectReference)(unsafe.Pointer(in.SecretRef))
+	out.Maintenance = federation.ClusterMaintenance(in.Maintenance)
+	out.ConnectionMode = federation.ClusterConnectionMode(in.ConnectionMode)
 	return nil
 }
 
@@ -151,6 +400,8 @@ func Convert_v1beta1_ClusterSpec_To_federation_ClusterSpec(in *ClusterSpec, out
 func autoConvert_federation_ClusterSpec_To_v1beta1_ClusterSpec(in *federation.ClusterSpec, out *ClusterSpec, s conversion.Scope) error {
 	out.ServerAddressByClientCIDRs = *(*[]ServerAddressByClientCIDR)(unsafe.Pointer(&in.ServerAddressByClientCIDRs))
 	out.SecretRef = (*v1.LocalObjectReference)(unsafe.Pointer(in.SecretRef))
+	out.Maintenance = ClusterMaintenance(in.Maintenance)
+	out.ConnectionMode = ClusterConnectionMode(in.ConnectionMode)
 	return nil
 }
 
@@ -163,6 +414,11 @@ func autoConvert_v1beta1_ClusterStatus_To_federation_ClusterStatus(in *ClusterSt
 	out.Conditions = *(*[]federation.ClusterCondition)(unsafe.Pointer(&in.Conditions))
 	out.Zones = *(*[]string)(unsafe.Pointer(&in.Zones))
 	out.Region = in.Region
+	out.ActiveServerAddress = in.ActiveServerAddress
+	out.ServerAddresses = *(*[]federation.ServerAddressStatus)(unsafe.Pointer(&in.ServerAddresses))
+	out.Version = in.Version
+	out.APIResources = *(*[]federation.ClusterAPIResources)(unsafe.Pointer(&in.APIResources))
+	out.ProbeHistory = *(*[]federation.ClusterProbe)(unsafe.Pointer(&in.ProbeHistory))
 	return nil
 }
 
@@ -175,6 +431,11 @@ func autoConvert_federation_ClusterStatus_To_v1beta1_ClusterStatus(in *federatio
 	out.Conditions = *(*[]ClusterCondition)(unsafe.Pointer(&in.Conditions))
 	out.Zones = *(*[]string)(unsafe.Pointer(&in.Zones))
 	out.Region = in.Region
+	out.ActiveServerAddress = in.ActiveServerAddress
+	out.ServerAddresses = *(*[]ServerAddressStatus)(unsafe.Pointer(&in.ServerAddresses))
+	out.Version = in.Version
+	out.APIResources = *(*[]ClusterAPIResources)(unsafe.Pointer(&in.APIResources))
+	out.ProbeHistory = *(*[]ClusterProbe)(unsafe.Pointer(&in.ProbeHistory))
 	return nil
 }
 
@@ -183,6 +444,162 @@ func Convert_federation_ClusterStatus_To_v1beta1_ClusterStatus(in *federation.Cl
 	return autoConvert_federation_ClusterStatus_To_v1beta1_ClusterStatus(in, out, s)
 }
 
+func autoConvert_v1beta1_PropagationPolicy_To_federation_PropagationPolicy(in *PropagationPolicy, out *federation.PropagationPolicy, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1beta1_PropagationPolicySpec_To_federation_PropagationPolicySpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_PropagationPolicy_To_federation_PropagationPolicy is an autogenerated conversion function.
+func Convert_v1beta1_PropagationPolicy_To_federation_PropagationPolicy(in *PropagationPolicy, out *federation.PropagationPolicy, s conversion.Scope) error {
+	return autoConvert_v1beta1_PropagationPolicy_To_federation_PropagationPolicy(in, out, s)
+}
+
+func autoConvert_federation_PropagationPolicy_To_v1beta1_PropagationPolicy(in *federation.PropagationPolicy, out *PropagationPolicy, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_federation_PropagationPolicySpec_To_v1beta1_PropagationPolicySpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_federation_PropagationPolicy_To_v1beta1_PropagationPolicy is an autogenerated conversion function.
+func Convert_federation_PropagationPolicy_To_v1beta1_PropagationPolicy(in *federation.PropagationPolicy, out *PropagationPolicy, s conversion.Scope) error {
+	return autoConvert_federation_PropagationPolicy_To_v1beta1_PropagationPolicy(in, out, s)
+}
+
+func autoConvert_v1beta1_PropagationPolicyList_To_federation_PropagationPolicyList(in *PropagationPolicyList, out *federation.PropagationPolicyList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]federation.PropagationPolicy)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_v1beta1_PropagationPolicyList_To_federation_PropagationPolicyList is an autogenerated conversion function.
+func Convert_v1beta1_PropagationPolicyList_To_federation_PropagationPolicyList(in *PropagationPolicyList, out *federation.PropagationPolicyList, s conversion.Scope) error {
+	return autoConvert_v1beta1_PropagationPolicyList_To_federation_PropagationPolicyList(in, out, s)
+}
+
+func autoConvert_federation_PropagationPolicyList_To_v1beta1_PropagationPolicyList(in *federation.PropagationPolicyList, out *PropagationPolicyList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]PropagationPolicy)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_federation_PropagationPolicyList_To_v1beta1_PropagationPolicyList is an autogenerated conversion function.
+func Convert_federation_PropagationPolicyList_To_v1beta1_PropagationPolicyList(in *federation.PropagationPolicyList, out *PropagationPolicyList, s conversion.Scope) error {
+	return autoConvert_federation_PropagationPolicyList_To_v1beta1_PropagationPolicyList(in, out, s)
+}
+
+func autoConvert_v1beta1_PropagationPolicySpec_To_federation_PropagationPolicySpec(in *PropagationPolicySpec, out *federation.PropagationPolicySpec, s conversion.Scope) error {
+	out.ResourceSelectors = *(*[]federation.ResourceSelector)(unsafe.Pointer(&in.ResourceSelectors))
+	out.Priority = in.Priority
+	out.ClusterSelector = *(*[]federation.ClusterSelectorRequirement)(unsafe.Pointer(&in.ClusterSelector))
+	out.ReplicaPreferences = (*federation.ReplicaAllocationPreferences)(unsafe.Pointer(in.ReplicaPreferences))
+	out.ClusterSet = in.ClusterSet
+	return nil
+}
+
+// Convert_v1beta1_PropagationPolicySpec_To_federation_PropagationPolicySpec is an autogenerated conversion function.
+func Convert_v1beta1_PropagationPolicySpec_To_federation_PropagationPolicySpec(in *PropagationPolicySpec, out *federation.PropagationPolicySpec, s conversion.Scope) error {
+	return autoConvert_v1beta1_PropagationPolicySpec_To_federation_PropagationPolicySpec(in, out, s)
+}
+
+func autoConvert_federation_PropagationPolicySpec_To_v1beta1_PropagationPolicySpec(in *federation.PropagationPolicySpec, out *PropagationPolicySpec, s conversion.Scope) error {
+	out.ResourceSelectors = *(*[]ResourceSelector)(unsafe.Pointer(&in.ResourceSelectors))
+	out.Priority = in.Priority
+	out.ClusterSelector = *(*[]ClusterSelectorRequirement)(unsafe.Pointer(&in.ClusterSelector))
+	out.ReplicaPreferences = (*ReplicaAllocationPreferences)(unsafe.Pointer(in.ReplicaPreferences))
+	out.ClusterSet = in.ClusterSet
+	return nil
+}
+
+// Convert_federation_PropagationPolicySpec_To_v1beta1_PropagationPolicySpec is an autogenerated conversion function.
+func Convert_federation_PropagationPolicySpec_To_v1beta1_PropagationPolicySpec(in *federation.PropagationPolicySpec, out *PropagationPolicySpec, s conversion.Scope) error {
+	return autoConvert_federation_PropagationPolicySpec_To_v1beta1_PropagationPolicySpec(in, out, s)
+}
+
+func autoConvert_v1beta1_RebalanceStrategy_To_federation_RebalanceStrategy(in *RebalanceStrategy, out *federation.RebalanceStrategy, s conversion.Scope) error {
+	out.MaxMovedReplicas = (*int64)(unsafe.Pointer(in.MaxMovedReplicas))
+	out.Interval = in.Interval
+	out.MaxSurge = (*int64)(unsafe.Pointer(in.MaxSurge))
+	out.MaxUnavailable = in.MaxUnavailable
+	return nil
+}
+
+// Convert_v1beta1_RebalanceStrategy_To_federation_RebalanceStrategy is an autogenerated conversion function.
+func Convert_v1beta1_RebalanceStrategy_To_federation_RebalanceStrategy(in *RebalanceStrategy, out *federation.RebalanceStrategy, s conversion.Scope) error {
+	return autoConvert_v1beta1_RebalanceStrategy_To_federation_RebalanceStrategy(in, out, s)
+}
+
+func autoConvert_federation_RebalanceStrategy_To_v1beta1_RebalanceStrategy(in *federation.RebalanceStrategy, out *RebalanceStrategy, s conversion.Scope) error {
+	out.MaxMovedReplicas = (*int64)(unsafe.Pointer(in.MaxMovedReplicas))
+	out.Interval = in.Interval
+	out.MaxSurge = (*int64)(unsafe.Pointer(in.MaxSurge))
+	out.MaxUnavailable = in.MaxUnavailable
+	return nil
+}
+
+// Convert_federation_RebalanceStrategy_To_v1beta1_RebalanceStrategy is an autogenerated conversion function.
+func Convert_federation_RebalanceStrategy_To_v1beta1_RebalanceStrategy(in *federation.RebalanceStrategy, out *RebalanceStrategy, s conversion.Scope) error {
+	return autoConvert_federation_RebalanceStrategy_To_v1beta1_RebalanceStrategy(in, out, s)
+}
+
+func autoConvert_v1beta1_ReplicaAllocationPreferences_To_federation_ReplicaAllocationPreferences(in *ReplicaAllocationPreferences, out *federation.ReplicaAllocationPreferences, s conversion.Scope) error {
+	out.Rebalance = in.Rebalance
+	out.Clusters = *(*map[string]federation.ClusterPreferences)(unsafe.Pointer(&in.Clusters))
+	out.ClusterSet = in.ClusterSet
+	out.TopologySpreadConstraints = *(*[]federation.TopologySpreadConstraint)(unsafe.Pointer(&in.TopologySpreadConstraints))
+	out.MaxDrainBackReplicas = (*int64)(unsafe.Pointer(in.MaxDrainBackReplicas))
+	out.RebalanceStrategy = (*federation.RebalanceStrategy)(unsafe.Pointer(in.RebalanceStrategy))
+	return nil
+}
+
+// Convert_v1beta1_ReplicaAllocationPreferences_To_federation_ReplicaAllocationPreferences is an autogenerated conversion function.
+func Convert_v1beta1_ReplicaAllocationPreferences_To_federation_ReplicaAllocationPreferences(in *ReplicaAllocationPreferences, out *federation.ReplicaAllocationPreferences, s conversion.Scope) error {
+	return autoConvert_v1beta1_ReplicaAllocationPreferences_To_federation_ReplicaAllocationPreferences(in, out, s)
+}
+
+func autoConvert_federation_ReplicaAllocationPreferences_To_v1beta1_ReplicaAllocationPreferences(in *federation.ReplicaAllocationPreferences, out *ReplicaAllocationPreferences, s conversion.Scope) error {
+	out.Rebalance = in.Rebalance
+	out.Clusters = *(*map[string]ClusterPreferences)(unsafe.Pointer(&in.Clusters))
+	out.ClusterSet = in.ClusterSet
+	out.TopologySpreadConstraints = *(*[]TopologySpreadConstraint)(unsafe.Pointer(&in.TopologySpreadConstraints))
+	out.MaxDrainBackReplicas = (*int64)(unsafe.Pointer(in.MaxDrainBackReplicas))
+	out.RebalanceStrategy = (*RebalanceStrategy)(unsafe.Pointer(in.RebalanceStrategy))
+	return nil
+}
+
+// Convert_federation_ReplicaAllocationPreferences_To_v1beta1_ReplicaAllocationPreferences is an autogenerated conversion function.
+func Convert_federation_ReplicaAllocationPreferences_To_v1beta1_ReplicaAllocationPreferences(in *federation.ReplicaAllocationPreferences, out *ReplicaAllocationPreferences, s conversion.Scope) error {
+	return autoConvert_federation_ReplicaAllocationPreferences_To_v1beta1_ReplicaAllocationPreferences(in, out, s)
+}
+
+func autoConvert_v1beta1_ResourceSelector_To_federation_ResourceSelector(in *ResourceSelector, out *federation.ResourceSelector, s conversion.Scope) error {
+	out.Kind = in.Kind
+	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
+	out.LabelSelector = (*meta_v1.LabelSelector)(unsafe.Pointer(in.LabelSelector))
+	return nil
+}
+
+// Convert_v1beta1_ResourceSelector_To_federation_ResourceSelector is an autogenerated conversion function.
+func Convert_v1beta1_ResourceSelector_To_federation_ResourceSelector(in *ResourceSelector, out *federation.ResourceSelector, s conversion.Scope) error {
+	return autoConvert_v1beta1_ResourceSelector_To_federation_ResourceSelector(in, out, s)
+}
+
+func autoConvert_federation_ResourceSelector_To_v1beta1_ResourceSelector(in *federation.ResourceSelector, out *ResourceSelector, s conversion.Scope) error {
+	out.Kind = in.Kind
+	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
+	out.LabelSelector = (*meta_v1.LabelSelector)(unsafe.Pointer(in.LabelSelector))
+	return nil
+}
+
+// Convert_federation_ResourceSelector_To_v1beta1_ResourceSelector is an autogenerated conversion function.
+func Convert_federation_ResourceSelector_To_v1beta1_ResourceSelector(in *federation.ResourceSelector, out *ResourceSelector, s conversion.Scope) error {
+	return autoConvert_federation_ResourceSelector_To_v1beta1_ResourceSelector(in, out, s)
+}
+
 func autoConvert_v1beta1_ServerAddressByClientCIDR_To_federation_ServerAddressByClientCIDR(in *ServerAddressByClientCIDR, out *federation.ServerAddressByClientCIDR, s conversion.Scope) error {
 	out.ClientCIDR = in.ClientCIDR
 	out.ServerAddress = in.ServerAddress
@@ -204,3 +621,55 @@ func autoConvert_federation_ServerAddressByClientCIDR_To_v1beta1_ServerAddressBy
 func Convert_federation_ServerAddressByClientCIDR_To_v1beta1_ServerAddressByClientCIDR(in *federation.ServerAddressByClientCIDR, out *ServerAddressByClientCIDR, s conversion.Scope) error {
 	return autoConvert_federation_ServerAddressByClientCIDR_To_v1beta1_ServerAddressByClientCIDR(in, out, s)
 }
+
+func autoConvert_v1beta1_ServerAddressStatus_To_federation_ServerAddressStatus(in *ServerAddressStatus, out *federation.ServerAddressStatus, s conversion.Scope) error {
+	out.ServerAddress = in.ServerAddress
+	out.Healthy = in.Healthy
+	out.Message = in.Message
+	out.LastProbeTime = in.LastProbeTime
+	return nil
+}
+
+// Convert_v1beta1_ServerAddressStatus_To_federation_ServerAddressStatus is an autogenerated conversion function.
+func Convert_v1beta1_ServerAddressStatus_To_federation_ServerAddressStatus(in *ServerAddressStatus, out *federation.ServerAddressStatus, s conversion.Scope) error {
+	return autoConvert_v1beta1_ServerAddressStatus_To_federation_ServerAddressStatus(in, out, s)
+}
+
+func autoConvert_federation_ServerAddressStatus_To_v1beta1_ServerAddressStatus(in *federation.ServerAddressStatus, out *ServerAddressStatus, s conversion.Scope) error {
+	out.ServerAddress = in.ServerAddress
+	out.Healthy = in.Healthy
+	out.Message = in.Message
+	out.LastProbeTime = in.LastProbeTime
+	return nil
+}
+
+// Convert_federation_ServerAddressStatus_To_v1beta1_ServerAddressStatus is an autogenerated conversion function.
+func Convert_federation_ServerAddressStatus_To_v1beta1_ServerAddressStatus(in *federation.ServerAddressStatus, out *ServerAddressStatus, s conversion.Scope) error {
+	return autoConvert_federation_ServerAddressStatus_To_v1beta1_ServerAddressStatus(in, out, s)
+}
+
+func autoConvert_v1beta1_TopologySpreadConstraint_To_federation_TopologySpreadConstraint(in *TopologySpreadConstraint, out *federation.TopologySpreadConstraint, s conversion.Scope) error {
+	out.TopologyKey = federation.TopologyKey(in.TopologyKey)
+	out.MaxClustersPerDomain = (*int64)(unsafe.Pointer(in.MaxClustersPerDomain))
+	out.MaxSkew = (*int64)(unsafe.Pointer(in.MaxSkew))
+	out.MinDomains = (*int64)(unsafe.Pointer(in.MinDomains))
+	return nil
+}
+
+// Convert_v1beta1_TopologySpreadConstraint_To_federation_TopologySpreadConstraint is an autogenerated conversion function.
+func Convert_v1beta1_TopologySpreadConstraint_To_federation_TopologySpreadConstraint(in *TopologySpreadConstraint, out *federation.TopologySpreadConstraint, s conversion.Scope) error {
+	return autoConvert_v1beta1_TopologySpreadConstraint_To_federation_TopologySpreadConstraint(in, out, s)
+}
+
+func autoConvert_federation_TopologySpreadConstraint_To_v1beta1_TopologySpreadConstraint(in *federation.TopologySpreadConstraint, out *TopologySpreadConstraint, s conversion.Scope) error {
+	out.TopologyKey = TopologyKey(in.TopologyKey)
+	out.MaxClustersPerDomain = (*int64)(unsafe.Pointer(in.MaxClustersPerDomain))
+	out.MaxSkew = (*int64)(unsafe.Pointer(in.MaxSkew))
+	out.MinDomains = (*int64)(unsafe.Pointer(in.MinDomains))
+	return nil
+}
+
+// Convert_federation_TopologySpreadConstraint_To_v1beta1_TopologySpreadConstraint is an autogenerated conversion function.
+func Convert_federation_TopologySpreadConstraint_To_v1beta1_TopologySpreadConstraint(in *federation.TopologySpreadConstraint, out *TopologySpreadConstraint, s conversion.Scope) error {
+	return autoConvert_federation_TopologySpreadConstraint_To_v1beta1_TopologySpreadConstraint(in, out, s)
+}
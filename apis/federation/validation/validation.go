@@ -20,16 +20,47 @@ import (
 	"fmt"
 	"net"
 
+	apimachineryvalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/federation/apis/federation"
 	"k8s.io/kubernetes/pkg/apis/core/validation"
 )
 
+// clusterSelectorOperators maps the operators accepted in cluster selector
+// requirements to label selector operators.
+var clusterSelectorOperators = map[string]selection.Operator{
+	"!":            selection.DoesNotExist,
+	"DoesNotExist": selection.DoesNotExist,
+	"=":            selection.Equals,
+	"==":           selection.DoubleEquals,
+	"in":           selection.In,
+	"In":           selection.In,
+	"!=":           selection.NotEquals,
+	"notin":        selection.NotIn,
+	"NotIn":        selection.NotIn,
+	"exists":       selection.Exists,
+	"Exists":       selection.Exists,
+	"gt":           selection.GreaterThan,
+	"Gt":           selection.GreaterThan,
+	">":            selection.GreaterThan,
+	"lt":           selection.LessThan,
+	"Lt":           selection.LessThan,
+	"<":            selection.LessThan,
+}
+
+var supportedTopologyKeys = sets.NewString(string(federation.TopologyKeyRegion), string(federation.TopologyKeyZone))
+
 func ValidateClusterSpec(spec *federation.ClusterSpec, fieldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
-	// address is required.
+	// address is required, unless the agent of the cluster connects to the federation.
 	if len(spec.ServerAddressByClientCIDRs) == 0 {
-		allErrs = append(allErrs, field.Required(fieldPath.Child("serverAddressByClientCIDRs"), ""))
+		if spec.ConnectionMode != federation.ClusterConnectionPull {
+			allErrs = append(allErrs, field.Required(fieldPath.Child("serverAddressByClientCIDRs"), ""))
+		}
 	} else {
 		for i, address := range spec.ServerAddressByClientCIDRs {
 			idxPath := fieldPath.Child("serverAddressByClientCIDRs").Index(i)
@@ -40,6 +71,18 @@ func ValidateClusterSpec(spec *federation.ClusterSpec, fieldPath *field.Path) fi
 			}
 		}
 	}
+	switch spec.Maintenance {
+	case "", federation.ClusterCordoned, federation.ClusterDrained:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fieldPath.Child("maintenance"), spec.Maintenance,
+			[]string{string(federation.ClusterCordoned), string(federation.ClusterDrained)}))
+	}
+	switch spec.ConnectionMode {
+	case "", federation.ClusterConnectionPush, federation.ClusterConnectionPull:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fieldPath.Child("connectionMode"), spec.ConnectionMode,
+			[]string{string(federation.ClusterConnectionPush), string(federation.ClusterConnectionPull)}))
+	}
 	return allErrs
 }
 
@@ -62,3 +105,195 @@ func ValidateClusterStatusUpdate(cluster, oldCluster *federation.Cluster) field.
 	allErrs := validation.ValidateObjectMetaUpdate(&cluster.ObjectMeta, &oldCluster.ObjectMeta, field.NewPath("metadata"))
 	return allErrs
 }
+
+// ValidateClusterSelector validates the requirements of a cluster selector.
+func ValidateClusterSelector(requirements []federation.ClusterSelectorRequirement, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, requirement := range requirements {
+		idxPath := fldPath.Index(i)
+		op, found := clusterSelectorOperators[requirement.Operator]
+		if !found {
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("operator"), requirement.Operator, sets.StringKeySet(clusterSelectorOperators).List()))
+			continue
+		}
+		if _, err := labels.NewRequirement(requirement.Key, op, requirement.Values); err != nil {
+			allErrs = append(allErrs, field.Invalid(idxPath, requirement, err.Error()))
+		}
+	}
+	return allErrs
+}
+
+// ValidateReplicaAllocationPreferences validates the placement preferences of the
+// replicas of a federated workload.
+func ValidateReplicaAllocationPreferences(prefs *federation.ReplicaAllocationPreferences, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for name, cluster := range prefs.Clusters {
+		clusterPath := fldPath.Child("clusters").Key(name)
+		allErrs = append(allErrs, validateNonnegative(cluster.MinReplicas, clusterPath.Child("minReplicas"))...)
+		if cluster.MaxReplicas != nil {
+			allErrs = append(allErrs, validateNonnegative(*cluster.MaxReplicas, clusterPath.Child("maxReplicas"))...)
+			if *cluster.MaxReplicas < cluster.MinReplicas {
+				allErrs = append(allErrs, field.Invalid(clusterPath.Child("maxReplicas"), *cluster.MaxReplicas, "must be greater than or equal to minReplicas"))
+			}
+		}
+		allErrs = append(allErrs, validateNonnegative(cluster.Weight, clusterPath.Child("weight"))...)
+	}
+
+	topologyKeys := sets.NewString()
+	for i, constraint := range prefs.TopologySpreadConstraints {
+		idxPath := fldPath.Child("topologySpreadConstraints").Index(i)
+		key := string(constraint.TopologyKey)
+		switch {
+		case !supportedTopologyKeys.Has(key):
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("topologyKey"), key, supportedTopologyKeys.List()))
+		case topologyKeys.Has(key):
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("topologyKey"), key))
+		}
+		topologyKeys.Insert(key)
+		allErrs = append(allErrs, validateOptionalNonnegative(constraint.MaxClustersPerDomain, idxPath.Child("maxClustersPerDomain"))...)
+		allErrs = append(allErrs, validateOptionalNonnegative(constraint.MaxSkew, idxPath.Child("maxSkew"))...)
+		allErrs = append(allErrs, validateOptionalNonnegative(constraint.MinDomains, idxPath.Child("minDomains"))...)
+	}
+
+	if prefs.ClusterSet != "" {
+		allErrs = append(allErrs, ValidateClusterSetReference(prefs.ClusterSet, fldPath.Child("clusterSet"))...)
+	}
+
+	allErrs = append(allErrs, validateOptionalNonnegative(prefs.MaxDrainBackReplicas, fldPath.Child("maxDrainBackReplicas"))...)
+	if strategy := prefs.RebalanceStrategy; strategy != nil {
+		strategyPath := fldPath.Child("rebalanceStrategy")
+		allErrs = append(allErrs, validateOptionalNonnegative(strategy.MaxMovedReplicas, strategyPath.Child("maxMovedReplicas"))...)
+		allErrs = append(allErrs, validateOptionalNonnegative(strategy.MaxSurge, strategyPath.Child("maxSurge"))...)
+		allErrs = append(allErrs, validateNonnegative(strategy.MaxUnavailable, strategyPath.Child("maxUnavailable"))...)
+		if strategy.Interval.Duration < 0 {
+			allErrs = append(allErrs, field.Invalid(strategyPath.Child("interval"), strategy.Interval.Duration.String(), "must be greater than or equal to 0"))
+		}
+	}
+	return allErrs
+}
+
+// ValidateHpaPreferences validates the preferences of a federated horizontal
+// pod autoscaler.
+func ValidateHpaPreferences(prefs *federation.HpaPreferences, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	switch prefs.Mode {
+	case "", federation.HpaScalingModeLocal, federation.HpaScalingModeGlobal:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("mode"), prefs.Mode,
+			[]string{string(federation.HpaScalingModeLocal), string(federation.HpaScalingModeGlobal)}))
+	}
+	if prefs.DampingPercent != nil && (*prefs.DampingPercent < 0 || *prefs.DampingPercent > 100) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("dampingPercent"), *prefs.DampingPercent, "must be between 0 and 100, inclusive"))
+	}
+	allErrs = append(allErrs, validateOptionalNonnegative(prefs.TolerancePercent, fldPath.Child("tolerancePercent"))...)
+	allErrs = append(allErrs, validateOptionalNonnegative(prefs.MaxMovedReplicas, fldPath.Child("maxMovedReplicas"))...)
+	if prefs.Interval.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("interval"), prefs.Interval.Duration.String(), "must be greater than or equal to 0"))
+	}
+	return allErrs
+}
+
+func validateNonnegative(value int64, fldPath *field.Path) field.ErrorList {
+	if value < 0 {
+		return field.ErrorList{field.Invalid(fldPath, value, "must be greater than or equal to 0")}
+	}
+	return nil
+}
+
+func validateOptionalNonnegative(value *int64, fldPath *field.Path) field.ErrorList {
+	if value == nil {
+		return nil
+	}
+	return validateNonnegative(*value, fldPath)
+}
+
+func ValidatePropagationPolicySpec(spec *federation.PropagationPolicySpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(spec.ResourceSelectors) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("resourceSelectors"), ""))
+	}
+	for i, selector := range spec.ResourceSelectors {
+		idxPath := fldPath.Child("resourceSelectors").Index(i)
+		if len(selector.Kind) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath.Child("kind"), ""))
+		}
+		for j, namespace := range selector.Namespaces {
+			for _, msg := range validation.ValidateNamespaceName(namespace, false) {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("namespaces").Index(j), namespace, msg))
+			}
+		}
+		if selector.LabelSelector != nil {
+			allErrs = append(allErrs, metav1validation.ValidateLabelSelector(selector.LabelSelector, idxPath.Child("labelSelector"))...)
+		}
+	}
+	allErrs = append(allErrs, ValidateClusterSelector(spec.ClusterSelector, fldPath.Child("clusterSelector"))...)
+	if spec.ReplicaPreferences != nil {
+		allErrs = append(allErrs, ValidateReplicaAllocationPreferences(spec.ReplicaPreferences, fldPath.Child("replicaPreferences"))...)
+	}
+	if spec.ClusterSet != "" {
+		allErrs = append(allErrs, ValidateClusterSetReference(spec.ClusterSet, fldPath.Child("clusterSet"))...)
+	}
+	return allErrs
+}
+
+func ValidatePropagationPolicy(policy *federation.PropagationPolicy) field.ErrorList {
+	allErrs := validation.ValidateObjectMeta(&policy.ObjectMeta, false, apimachineryvalidation.NameIsDNSSubdomain, field.NewPath("metadata"))
+	allErrs = append(allErrs, ValidatePropagationPolicySpec(&policy.Spec, field.NewPath("spec"))...)
+	return allErrs
+}
+
+func ValidatePropagationPolicyUpdate(policy, oldPolicy *federation.PropagationPolicy) field.ErrorList {
+	allErrs := validation.ValidateObjectMetaUpdate(&policy.ObjectMeta, &oldPolicy.ObjectMeta, field.NewPath("metadata"))
+	allErrs = append(allErrs, ValidatePropagationPolicySpec(&policy.Spec, field.NewPath("spec"))...)
+	return allErrs
+}
+
+// ValidateClusterSetReference validates the name of a cluster set that the
+// placement of federated objects refers to.
+func ValidateClusterSetReference(name string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for _, msg := range apimachineryvalidation.NameIsDNSSubdomain(name, false) {
+		allErrs = append(allErrs, field.Invalid(fldPath, name, msg))
+	}
+	return allErrs
+}
+
+// validateClusterNames validates a list of cluster names without duplicates.
+func validateClusterNames(names []string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	seen := sets.NewString()
+	for i, name := range names {
+		for _, msg := range validation.ValidateClusterName(name, false) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), name, msg))
+		}
+		if seen.Has(name) {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i), name))
+		}
+		seen.Insert(name)
+	}
+	return allErrs
+}
+
+func ValidateClusterSetSpec(spec *federation.ClusterSetSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := validateClusterNames(spec.Clusters, fldPath.Child("clusters"))
+	allErrs = append(allErrs, ValidateClusterSelector(spec.ClusterSelector, fldPath.Child("clusterSelector"))...)
+	return allErrs
+}
+
+func ValidateClusterSet(set *federation.ClusterSet) field.ErrorList {
+	allErrs := validation.ValidateObjectMeta(&set.ObjectMeta, false, apimachineryvalidation.NameIsDNSSubdomain, field.NewPath("metadata"))
+	allErrs = append(allErrs, ValidateClusterSetSpec(&set.Spec, field.NewPath("spec"))...)
+	return allErrs
+}
+
+func ValidateClusterSetUpdate(set, oldSet *federation.ClusterSet) field.ErrorList {
+	allErrs := validation.ValidateObjectMetaUpdate(&set.ObjectMeta, &oldSet.ObjectMeta, field.NewPath("metadata"))
+	allErrs = append(allErrs, ValidateClusterSetSpec(&set.Spec, field.NewPath("spec"))...)
+	return allErrs
+}
+
+func ValidateClusterSetStatusUpdate(set, oldSet *federation.ClusterSet) field.ErrorList {
+	allErrs := validation.ValidateObjectMetaUpdate(&set.ObjectMeta, &oldSet.ObjectMeta, field.NewPath("metadata"))
+	allErrs = append(allErrs, validateClusterNames(set.Status.Clusters, field.NewPath("status", "clusters"))...)
+	return allErrs
+}
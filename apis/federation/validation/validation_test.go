@@ -150,6 +150,24 @@ func TestValidateCluster(t *testing.T) {
 				},
 			},
 		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-s"},
+			Spec: federation.ClusterSpec{
+				ServerAddressByClientCIDRs: []federation.ServerAddressByClientCIDR{
+					{
+						ClientCIDR:    "0.0.0.0/0",
+						ServerAddress: "localhost:8888",
+					},
+				},
+				Maintenance: federation.ClusterDrained,
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-s"},
+			Spec: federation.ClusterSpec{
+				ConnectionMode: federation.ClusterConnectionPull,
+			},
+		},
 	}
 	for _, successCase := range successCases {
 		errs := ValidateCluster(&successCase)
@@ -186,6 +204,36 @@ func TestValidateCluster(t *testing.T) {
 				},
 			},
 		},
+		"unknown maintenance mode": {
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-f"},
+			Spec: federation.ClusterSpec{
+				ServerAddressByClientCIDRs: []federation.ServerAddressByClientCIDR{
+					{
+						ClientCIDR:    "0.0.0.0/0",
+						ServerAddress: "localhost:8888",
+					},
+				},
+				Maintenance: "Upgrading",
+			},
+		},
+		"missing addresses of cluster in push mode": {
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-f"},
+			Spec: federation.ClusterSpec{
+				ConnectionMode: federation.ClusterConnectionPush,
+			},
+		},
+		"unknown connection mode": {
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-f"},
+			Spec: federation.ClusterSpec{
+				ServerAddressByClientCIDRs: []federation.ServerAddressByClientCIDR{
+					{
+						ClientCIDR:    "0.0.0.0/0",
+						ServerAddress: "localhost:8888",
+					},
+				},
+				ConnectionMode: "Tunnel",
+			},
+		},
 	}
 	for testName, errorCase := range errorCases {
 		errs := ValidateCluster(&errorCase)
@@ -332,3 +380,166 @@ func TestValidateClusterStatusUpdate(t *testing.T) {
 		}
 	}
 }
+
+func TestValidatePropagationPolicy(t *testing.T) {
+	validSpec := func() federation.PropagationPolicySpec {
+		return federation.PropagationPolicySpec{
+			ResourceSelectors: []federation.ResourceSelector{
+				{
+					Kind:          "ReplicaSet",
+					Namespaces:    []string{"default"},
+					LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "frontend"}},
+				},
+			},
+			ClusterSelector: []federation.ClusterSelectorRequirement{
+				{Key: "environment", Operator: "in", Values: []string{"prod"}},
+			},
+			ReplicaPreferences: &federation.ReplicaAllocationPreferences{
+				Clusters: map[string]federation.ClusterPreferences{
+					"*": {Weight: 1},
+				},
+				TopologySpreadConstraints: []federation.TopologySpreadConstraint{
+					{TopologyKey: federation.TopologyKeyRegion},
+				},
+				ClusterSet: "canary",
+			},
+			ClusterSet: "prod-eu",
+		}
+	}
+	policy := func(mutate func(spec *federation.PropagationPolicySpec)) *federation.PropagationPolicy {
+		p := &federation.PropagationPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "frontend"},
+			Spec:       validSpec(),
+		}
+		mutate(&p.Spec)
+		return p
+	}
+
+	if errs := ValidatePropagationPolicy(policy(func(*federation.PropagationPolicySpec) {})); len(errs) != 0 {
+		t.Errorf("expect success: %v", errs)
+	}
+
+	errorCases := map[string]*federation.PropagationPolicy{
+		"no resource selectors": policy(func(spec *federation.PropagationPolicySpec) {
+			spec.ResourceSelectors = nil
+		}),
+		"missing kind": policy(func(spec *federation.PropagationPolicySpec) {
+			spec.ResourceSelectors[0].Kind = ""
+		}),
+		"invalid namespace": policy(func(spec *federation.PropagationPolicySpec) {
+			spec.ResourceSelectors[0].Namespaces = []string{"Not_A_Namespace"}
+		}),
+		"unknown cluster selector operator": policy(func(spec *federation.PropagationPolicySpec) {
+			spec.ClusterSelector[0].Operator = "like"
+		}),
+		"cluster selector without values": policy(func(spec *federation.PropagationPolicySpec) {
+			spec.ClusterSelector[0].Values = nil
+		}),
+		"negative weight": policy(func(spec *federation.PropagationPolicySpec) {
+			spec.ReplicaPreferences.Clusters["*"] = federation.ClusterPreferences{Weight: -1}
+		}),
+		"max below min": policy(func(spec *federation.PropagationPolicySpec) {
+			max := int64(1)
+			spec.ReplicaPreferences.Clusters["*"] = federation.ClusterPreferences{MinReplicas: 2, MaxReplicas: &max}
+		}),
+		"unknown topology key": policy(func(spec *federation.PropagationPolicySpec) {
+			spec.ReplicaPreferences.TopologySpreadConstraints[0].TopologyKey = "rack"
+		}),
+		"duplicate topology key": policy(func(spec *federation.PropagationPolicySpec) {
+			spec.ReplicaPreferences.TopologySpreadConstraints = append(spec.ReplicaPreferences.TopologySpreadConstraints,
+				federation.TopologySpreadConstraint{TopologyKey: federation.TopologyKeyRegion})
+		}),
+		"invalid cluster set": policy(func(spec *federation.PropagationPolicySpec) {
+			spec.ClusterSet = "Prod_EU"
+		}),
+		"invalid preferences cluster set": policy(func(spec *federation.PropagationPolicySpec) {
+			spec.ReplicaPreferences.ClusterSet = "Canary!"
+		}),
+	}
+	for testName, errorCase := range errorCases {
+		if errs := ValidatePropagationPolicy(errorCase); len(errs) == 0 {
+			t.Errorf("expected failure: %s", testName)
+		}
+	}
+}
+
+func TestValidateClusterSet(t *testing.T) {
+	clusterSet := func(mutate func(spec *federation.ClusterSetSpec)) *federation.ClusterSet {
+		set := &federation.ClusterSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "prod-eu"},
+			Spec: federation.ClusterSetSpec{
+				Clusters: []string{"eu-west", "eu-north"},
+				ClusterSelector: []federation.ClusterSelectorRequirement{
+					{Key: "environment", Operator: "in", Values: []string{"prod"}},
+				},
+			},
+		}
+		mutate(&set.Spec)
+		return set
+	}
+
+	successCases := map[string]*federation.ClusterSet{
+		"members and selector": clusterSet(func(*federation.ClusterSetSpec) {}),
+		"empty":                clusterSet(func(spec *federation.ClusterSetSpec) { *spec = federation.ClusterSetSpec{} }),
+	}
+	for testName, successCase := range successCases {
+		if errs := ValidateClusterSet(successCase); len(errs) != 0 {
+			t.Errorf("expect success: %s: %v", testName, errs)
+		}
+	}
+
+	errorCases := map[string]*federation.ClusterSet{
+		"invalid cluster name": clusterSet(func(spec *federation.ClusterSetSpec) {
+			spec.Clusters = []string{"EU_West"}
+		}),
+		"duplicate cluster name": clusterSet(func(spec *federation.ClusterSetSpec) {
+			spec.Clusters = []string{"eu-west", "eu-west"}
+		}),
+		"unknown cluster selector operator": clusterSet(func(spec *federation.ClusterSetSpec) {
+			spec.ClusterSelector[0].Operator = "like"
+		}),
+	}
+	for testName, errorCase := range errorCases {
+		if errs := ValidateClusterSet(errorCase); len(errs) == 0 {
+			t.Errorf("expected failure: %s", testName)
+		}
+	}
+
+	old := clusterSet(func(*federation.ClusterSetSpec) {})
+	old.ResourceVersion = "1"
+	updated := old.DeepCopy()
+	updated.Status.Clusters = []string{"eu-north", "eu-west"}
+	if errs := ValidateClusterSetStatusUpdate(updated, old); len(errs) != 0 {
+		t.Errorf("expect success: %v", errs)
+	}
+	updated.Status.Clusters = []string{"eu-west", "eu-west"}
+	if errs := ValidateClusterSetStatusUpdate(updated, old); len(errs) == 0 {
+		t.Errorf("expected failure for duplicate status members")
+	}
+}
+
+func TestValidateHpaPreferences(t *testing.T) {
+	newInt64 := func(value int64) *int64 { return &value }
+	successCases := []federation.HpaPreferences{
+		{},
+		{Mode: federation.HpaScalingModeLocal},
+		{Mode: federation.HpaScalingModeGlobal, DampingPercent: newInt64(100), TolerancePercent: newInt64(0), MaxMovedReplicas: newInt64(5)},
+	}
+	for _, successCase := range successCases {
+		if errs := ValidateHpaPreferences(&successCase, field.NewPath("prefs")); len(errs) != 0 {
+			t.Errorf("expect success: %v", errs)
+		}
+	}
+
+	errorCases := map[string]federation.HpaPreferences{
+		"unknown mode":               {Mode: "Adaptive"},
+		"damping above 100":          {DampingPercent: newInt64(101)},
+		"negative tolerance":         {TolerancePercent: newInt64(-1)},
+		"negative max moved replica": {MaxMovedReplicas: newInt64(-1)},
+	}
+	for testName, errorCase := range errorCases {
+		if errs := ValidateHpaPreferences(&errorCase, field.NewPath("prefs")); len(errs) == 0 {
+			t.Errorf("expected failure: %s", testName)
+		}
+	}
+}
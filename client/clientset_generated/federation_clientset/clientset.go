@@ -22,6 +22,7 @@ import (
 	rest "k8s.io/client-go/rest"
 	flowcontrol "k8s.io/client-go/util/flowcontrol"
 	autoscalingv1 "k8s.io/federation/client/clientset_generated/federation_clientset/typed/autoscaling/v1"
+	autoscalingv2beta1 "k8s.io/federation/client/clientset_generated/federation_clientset/typed/autoscaling/v2beta1"
 	batchv1 "k8s.io/federation/client/clientset_generated/federation_clientset/typed/batch/v1"
 	corev1 "k8s.io/federation/client/clientset_generated/federation_clientset/typed/core/v1"
 	extensionsv1beta1 "k8s.io/federation/client/clientset_generated/federation_clientset/typed/extensions/v1beta1"
@@ -33,6 +34,7 @@ type Interface interface {
 	AutoscalingV1() autoscalingv1.AutoscalingV1Interface
 	// Deprecated: please explicitly pick a version if possible.
 	Autoscaling() autoscalingv1.AutoscalingV1Interface
+	AutoscalingV2beta1() autoscalingv2beta1.AutoscalingV2beta1Interface
 	BatchV1() batchv1.BatchV1Interface
 	// Deprecated: please explicitly pick a version if possible.
 	Batch() batchv1.BatchV1Interface
@@ -51,11 +53,12 @@ type Interface interface {
 // version included in a Clientset.
 type Clientset struct {
 	*discovery.DiscoveryClient
-	autoscalingV1     *autoscalingv1.AutoscalingV1Client
-	batchV1           *batchv1.BatchV1Client
-	coreV1            *corev1.CoreV1Client
-	extensionsV1beta1 *extensionsv1beta1.ExtensionsV1beta1Client
-	federationV1beta1 *federationv1beta1.FederationV1beta1Client
+	autoscalingV1      *autoscalingv1.AutoscalingV1Client
+	autoscalingV2beta1 *autoscalingv2beta1.AutoscalingV2beta1Client
+	batchV1            *batchv1.BatchV1Client
+	coreV1             *corev1.CoreV1Client
+	extensionsV1beta1  *extensionsv1beta1.ExtensionsV1beta1Client
+	federationV1beta1  *federationv1beta1.FederationV1beta1Client
 }
 
 // AutoscalingV1 retrieves the AutoscalingV1Client
@@ -69,6 +72,11 @@ func (c *Clientset) Autoscaling() autoscalingv1.AutoscalingV1Interface {
 	return c.autoscalingV1
 }
 
+// AutoscalingV2beta1 retrieves the AutoscalingV2beta1Client
+func (c *Clientset) AutoscalingV2beta1() autoscalingv2beta1.AutoscalingV2beta1Interface {
+	return c.autoscalingV2beta1
+}
+
 // BatchV1 retrieves the BatchV1Client
 func (c *Clientset) BatchV1() batchv1.BatchV1Interface {
 	return c.batchV1
@@ -133,6 +141,10 @@ func NewForConfig(c *rest.Config) (*Clientset, error) {
 	if err != nil {
 		return nil, err
 	}
+	cs.autoscalingV2beta1, err = autoscalingv2beta1.NewForConfig(&configShallowCopy)
+	if err != nil {
+		return nil, err
+	}
 	cs.batchV1, err = batchv1.NewForConfig(&configShallowCopy)
 	if err != nil {
 		return nil, err
@@ -163,6 +175,7 @@ func NewForConfig(c *rest.Config) (*Clientset, error) {
 func NewForConfigOrDie(c *rest.Config) *Clientset {
 	var cs Clientset
 	cs.autoscalingV1 = autoscalingv1.NewForConfigOrDie(c)
+	cs.autoscalingV2beta1 = autoscalingv2beta1.NewForConfigOrDie(c)
 	cs.batchV1 = batchv1.NewForConfigOrDie(c)
 	cs.coreV1 = corev1.NewForConfigOrDie(c)
 	cs.extensionsV1beta1 = extensionsv1beta1.NewForConfigOrDie(c)
@@ -176,6 +189,7 @@ func NewForConfigOrDie(c *rest.Config) *Clientset {
 func New(c rest.Interface) *Clientset {
 	var cs Clientset
 	cs.autoscalingV1 = autoscalingv1.New(c)
+	cs.autoscalingV2beta1 = autoscalingv2beta1.New(c)
 	cs.batchV1 = batchv1.New(c)
 	cs.coreV1 = corev1.New(c)
 	cs.extensionsV1beta1 = extensionsv1beta1.New(c)
@@ -25,6 +25,8 @@ import (
 	clientset "k8s.io/federation/client/clientset_generated/federation_clientset"
 	autoscalingv1 "k8s.io/federation/client/clientset_generated/federation_clientset/typed/autoscaling/v1"
 	fakeautoscalingv1 "k8s.io/federation/client/clientset_generated/federation_clientset/typed/autoscaling/v1/fake"
+	autoscalingv2beta1 "k8s.io/federation/client/clientset_generated/federation_clientset/typed/autoscaling/v2beta1"
+	fakeautoscalingv2beta1 "k8s.io/federation/client/clientset_generated/federation_clientset/typed/autoscaling/v2beta1/fake"
 	batchv1 "k8s.io/federation/client/clientset_generated/federation_clientset/typed/batch/v1"
 	fakebatchv1 "k8s.io/federation/client/clientset_generated/federation_clientset/typed/batch/v1/fake"
 	corev1 "k8s.io/federation/client/clientset_generated/federation_clientset/typed/core/v1"
@@ -86,6 +88,11 @@ func (c *Clientset) Autoscaling() autoscalingv1.AutoscalingV1Interface {
 	return &fakeautoscalingv1.FakeAutoscalingV1{Fake: &c.Fake}
 }
 
+// AutoscalingV2beta1 retrieves the AutoscalingV2beta1Client
+func (c *Clientset) AutoscalingV2beta1() autoscalingv2beta1.AutoscalingV2beta1Interface {
+	return &fakeautoscalingv2beta1.FakeAutoscalingV2beta1{Fake: &c.Fake}
+}
+
 // BatchV1 retrieves the BatchV1Client
 func (c *Clientset) BatchV1() batchv1.BatchV1Interface {
 	return &fakebatchv1.FakeBatchV1{Fake: &c.Fake}
@@ -18,6 +18,7 @@ package fake
 
 import (
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2beta1 "k8s.io/api/autoscaling/v2beta1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
@@ -53,6 +54,7 @@ func init() {
 // correctly.
 func AddToScheme(scheme *runtime.Scheme) {
 	autoscalingv1.AddToScheme(scheme)
+	autoscalingv2beta1.AddToScheme(scheme)
 	batchv1.AddToScheme(scheme)
 	corev1.AddToScheme(scheme)
 	extensionsv1beta1.AddToScheme(scheme)
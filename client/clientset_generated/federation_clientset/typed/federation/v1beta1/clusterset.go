@@ -0,0 +1,161 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+	v1beta1 "k8s.io/federation/apis/federation/v1beta1"
+	scheme "k8s.io/federation/client/clientset_generated/federation_clientset/scheme"
+)
+
+// ClusterSetsGetter has a method to return a ClusterSetInterface.
+// A group's client should implement this interface.
+type ClusterSetsGetter interface {
+	ClusterSets() ClusterSetInterface
+}
+
+// ClusterSetInterface has methods to work with ClusterSet resources.
+type ClusterSetInterface interface {
+	Create(*v1beta1.ClusterSet) (*v1beta1.ClusterSet, error)
+	Update(*v1beta1.ClusterSet) (*v1beta1.ClusterSet, error)
+	UpdateStatus(*v1beta1.ClusterSet) (*v1beta1.ClusterSet, error)
+	Delete(name string, options *v1.DeleteOptions) error
+	DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error
+	Get(name string, options v1.GetOptions) (*v1beta1.ClusterSet, error)
+	List(opts v1.ListOptions) (*v1beta1.ClusterSetList, error)
+	Watch(opts v1.ListOptions) (watch.Interface, error)
+	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1beta1.ClusterSet, err error)
+	ClusterSetExpansion
+}
+
+// clusterSets implements ClusterSetInterface
+type clusterSets struct {
+	client rest.Interface
+}
+
+// newClusterSets returns a ClusterSets
+func newClusterSets(c *FederationV1beta1Client) *clusterSets {
+	return &clusterSets{
+		client: c.RESTClient(),
+	}
+}
+
+// Get takes name of the clusterSet, and returns the corresponding clusterSet object, and an error if there is any.
+func (c *clusterSets) Get(name string, options v1.GetOptions) (result *v1beta1.ClusterSet, err error) {
+	result = &v1beta1.ClusterSet{}
+	err = c.client.Get().
+		Resource("clustersets").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of ClusterSets that match those selectors.
+func (c *clusterSets) List(opts v1.ListOptions) (result *v1beta1.ClusterSetList, err error) {
+	result = &v1beta1.ClusterSetList{}
+	err = c.client.Get().
+		Resource("clustersets").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested clusterSets.
+func (c *clusterSets) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	opts.Watch = true
+	return c.client.Get().
+		Resource("clustersets").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Watch()
+}
+
+// Create takes the representation of a clusterSet and creates it.  Returns the server's representation of the clusterSet, and an error, if there is any.
+func (c *clusterSets) Create(clusterSet *v1beta1.ClusterSet) (result *v1beta1.ClusterSet, err error) {
+	result = &v1beta1.ClusterSet{}
+	err = c.client.Post().
+		Resource("clustersets").
+		Body(clusterSet).
+		Do().
+		Into(result)
+	return
+}
+
+// Update takes the representation of a clusterSet and updates it. Returns the server's representation of the clusterSet, and an error, if there is any.
+func (c *clusterSets) Update(clusterSet *v1beta1.ClusterSet) (result *v1beta1.ClusterSet, err error) {
+	result = &v1beta1.ClusterSet{}
+	err = c.client.Put().
+		Resource("clustersets").
+		Name(clusterSet.Name).
+		Body(clusterSet).
+		Do().
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+
+func (c *clusterSets) UpdateStatus(clusterSet *v1beta1.ClusterSet) (result *v1beta1.ClusterSet, err error) {
+	result = &v1beta1.ClusterSet{}
+	err = c.client.Put().
+		Resource("clustersets").
+		Name(clusterSet.Name).
+		SubResource("status").
+		Body(clusterSet).
+		Do().
+		Into(result)
+	return
+}
+
+// Delete takes name of the clusterSet and deletes it. Returns an error if one occurs.
+func (c *clusterSets) Delete(name string, options *v1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("clustersets").
+		Name(name).
+		Body(options).
+		Do().
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *clusterSets) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	return c.client.Delete().
+		Resource("clustersets").
+		VersionedParams(&listOptions, scheme.ParameterCodec).
+		Body(options).
+		Do().
+		Error()
+}
+
+// Patch applies the patch and returns the patched clusterSet.
+func (c *clusterSets) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1beta1.ClusterSet, err error) {
+	result = &v1beta1.ClusterSet{}
+	err = c.client.Patch(pt).
+		Resource("clustersets").
+		SubResource(subresources...).
+		Name(name).
+		Body(data).
+		Do().
+		Into(result)
+	return
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+	v1beta1 "k8s.io/federation/apis/federation/v1beta1"
+)
+
+// FakeClusterSets implements ClusterSetInterface
+type FakeClusterSets struct {
+	Fake *FakeFederationV1beta1
+}
+
+var clusterSetsResource = schema.GroupVersionResource{Group: "federation", Version: "v1beta1", Resource: "clustersets"}
+
+var clusterSetsKind = schema.GroupVersionKind{Group: "federation", Version: "v1beta1", Kind: "ClusterSet"}
+
+// Get takes name of the clusterSet, and returns the corresponding clusterSet object, and an error if there is any.
+func (c *FakeClusterSets) Get(name string, options v1.GetOptions) (result *v1beta1.ClusterSet, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(clusterSetsResource, name), &v1beta1.ClusterSet{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.ClusterSet), err
+}
+
+// List takes label and field selectors, and returns the list of ClusterSets that match those selectors.
+func (c *FakeClusterSets) List(opts v1.ListOptions) (result *v1beta1.ClusterSetList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(clusterSetsResource, clusterSetsKind, opts), &v1beta1.ClusterSetList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1beta1.ClusterSetList{}
+	for _, item := range obj.(*v1beta1.ClusterSetList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested clusterSets.
+func (c *FakeClusterSets) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(clusterSetsResource, opts))
+}
+
+// Create takes the representation of a clusterSet and creates it.  Returns the server's representation of the clusterSet, and an error, if there is any.
+func (c *FakeClusterSets) Create(clusterSet *v1beta1.ClusterSet) (result *v1beta1.ClusterSet, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(clusterSetsResource, clusterSet), &v1beta1.ClusterSet{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.ClusterSet), err
+}
+
+// Update takes the representation of a clusterSet and updates it. Returns the server's representation of the clusterSet, and an error, if there is any.
+func (c *FakeClusterSets) Update(clusterSet *v1beta1.ClusterSet) (result *v1beta1.ClusterSet, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(clusterSetsResource, clusterSet), &v1beta1.ClusterSet{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.ClusterSet), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeClusterSets) UpdateStatus(clusterSet *v1beta1.ClusterSet) (*v1beta1.ClusterSet, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateSubresourceAction(clusterSetsResource, "status", clusterSet), &v1beta1.ClusterSet{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.ClusterSet), err
+}
+
+// Delete takes name of the clusterSet and deletes it. Returns an error if one occurs.
+func (c *FakeClusterSets) Delete(name string, options *v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteAction(clusterSetsResource, name), &v1beta1.ClusterSet{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeClusterSets) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(clusterSetsResource, listOptions)
+
+	_, err := c.Fake.Invokes(action, &v1beta1.ClusterSetList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched clusterSet.
+func (c *FakeClusterSets) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1beta1.ClusterSet, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(clusterSetsResource, name, data, subresources...), &v1beta1.ClusterSet{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.ClusterSet), err
+}
@@ -30,6 +30,14 @@ func (c *FakeFederationV1beta1) Clusters() v1beta1.ClusterInterface {
 	return &FakeClusters{c}
 }
 
+func (c *FakeFederationV1beta1) ClusterSets() v1beta1.ClusterSetInterface {
+	return &FakeClusterSets{c}
+}
+
+func (c *FakeFederationV1beta1) PropagationPolicies() v1beta1.PropagationPolicyInterface {
+	return &FakePropagationPolicies{c}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeFederationV1beta1) RESTClient() rest.Interface {
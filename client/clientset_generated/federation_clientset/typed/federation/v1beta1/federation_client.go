@@ -26,6 +26,8 @@ import (
 type FederationV1beta1Interface interface {
 	RESTClient() rest.Interface
 	ClustersGetter
+	ClusterSetsGetter
+	PropagationPoliciesGetter
 }
 
 // FederationV1beta1Client is used to interact with features provided by the federation group.
@@ -37,6 +39,14 @@ func (c *FederationV1beta1Client) Clusters() ClusterInterface {
 	return newClusters(c)
 }
 
+func (c *FederationV1beta1Client) ClusterSets() ClusterSetInterface {
+	return newClusterSets(c)
+}
+
+func (c *FederationV1beta1Client) PropagationPolicies() PropagationPolicyInterface {
+	return newPropagationPolicies(c)
+}
+
 // NewForConfig creates a new FederationV1beta1Client for the given config.
 func NewForConfig(c *rest.Config) (*FederationV1beta1Client, error) {
 	config := *c
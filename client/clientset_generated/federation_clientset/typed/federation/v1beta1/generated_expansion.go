@@ -17,3 +17,7 @@ limitations under the License.
 package v1beta1
 
 type ClusterExpansion interface{}
+
+type ClusterSetExpansion interface{}
+
+type PropagationPolicyExpansion interface{}
@@ -0,0 +1,38 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"k8s.io/federation/cmd/federation-agent/app"
+	"k8s.io/federation/cmd/federation-agent/app/options"
+)
+
+// NewFederationAgent creates a new hyperkube Server object that includes the
+// description and flags.
+func NewFederationAgent() *Server {
+	s := options.NewAgentOptions()
+
+	hks := Server{
+		SimpleUsage: "federation-agent",
+		Long:        "Agent of a member cluster the federation can not reach. Applies the federated objects selected for the cluster and reports its status",
+		Run: func(_ *Server, args []string, stopCh <-chan struct{}) error {
+			return app.Run(s)
+		},
+	}
+	s.AddFlags(hks.Flags())
+	return &hks
+}
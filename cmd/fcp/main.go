@@ -34,6 +34,7 @@ func main() {
 
 	fcp.AddServer(NewFederationAPIServer())
 	fcp.AddServer(NewFederationCMServer())
+	fcp.AddServer(NewFederationAgent())
 
 	fcp.RunToExit(os.Args)
 }
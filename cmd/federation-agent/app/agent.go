@@ -0,0 +1,57 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package app implements a server that runs the agent of a member cluster in
+// pull mode.
+package app
+
+import (
+	"fmt"
+
+	"github.com/golang/glog"
+	"k8s.io/apimachinery/pkg/util/wait"
+	restclient "k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/federation/cmd/federation-agent/app/options"
+	"k8s.io/federation/pkg/federation-controller/agent"
+	"k8s.io/kubernetes/pkg/version"
+)
+
+// Run runs the agent. This should never exit.
+func Run(s *options.AgentOptions) error {
+	glog.Infof("%+v", version.Get())
+	if s.ClusterName == "" {
+		return fmt.Errorf("--cluster-name is required")
+	}
+
+	federationConfig, err := clientcmd.BuildConfigFromFlags(s.Master, s.Kubeconfig)
+	if err != nil {
+		return fmt.Errorf("failed to build the config of the federation API server: %v", err)
+	}
+	var clusterConfig *restclient.Config
+	if s.ClusterKubeconfig == "" {
+		clusterConfig, err = restclient.InClusterConfig()
+	} else {
+		clusterConfig, err = clientcmd.BuildConfigFromFlags("", s.ClusterKubeconfig)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to build the config of cluster %s: %v", s.ClusterName, err)
+	}
+
+	glog.Infof("Starting the agent of cluster %s", s.ClusterName)
+	agent.NewAgent(s.ClusterName, federationConfig, clusterConfig, s.HeartbeatPeriod.Duration).Run(s.ConcurrentSyncs, wait.NeverStop)
+	return fmt.Errorf("the agent of cluster %s stopped", s.ClusterName)
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package options provides the flags used for the agent of a member cluster.
+package options
+
+import (
+	"time"
+
+	"github.com/spf13/pflag"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AgentOptions configures the agent of a member cluster in pull mode.
+type AgentOptions struct {
+	// ClusterName is the name the cluster joined the federation with.
+	ClusterName string
+	// Master is the address of the federation API server.
+	Master string
+	// Kubeconfig is the path to the kubeconfig of the federation API server.
+	Kubeconfig string
+	// ClusterKubeconfig is the path to the kubeconfig of the cluster the agent
+	// runs in. The in-cluster config is used if not set.
+	ClusterKubeconfig string
+	// HeartbeatPeriod is the period for reporting the status of the cluster.
+	HeartbeatPeriod metav1.Duration
+	// ConcurrentSyncs is the number of federated objects applied concurrently.
+	ConcurrentSyncs int
+}
+
+// NewAgentOptions creates new AgentOptions with a default config.
+func NewAgentOptions() *AgentOptions {
+	return &AgentOptions{
+		HeartbeatPeriod: metav1.Duration{Duration: 30 * time.Second},
+		ConcurrentSyncs: 5,
+	}
+}
+
+// AddFlags adds flags for the agent to the specified FlagSet
+func (s *AgentOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&s.ClusterName, "cluster-name", s.ClusterName, "The name the cluster joined the federation with. The cluster must be in pull mode.")
+	fs.StringVar(&s.Master, "master", s.Master, "The address of the federation API server (overrides any value in kubeconfig)")
+	fs.StringVar(&s.Kubeconfig, "kubeconfig", s.Kubeconfig, "Path to kubeconfig file with authorization and location information of the federation API server.")
+	fs.StringVar(&s.ClusterKubeconfig, "cluster-kubeconfig", s.ClusterKubeconfig, "Path to kubeconfig file with authorization and location information of the cluster the agent runs in. The in-cluster config is used if not set.")
+	fs.DurationVar(&s.HeartbeatPeriod.Duration, "heartbeat-period", s.HeartbeatPeriod.Duration, "The period for reporting the status of the cluster to the federation. It must be shorter than the --cluster-agent-timeout of the federation controller manager.")
+	fs.IntVar(&s.ConcurrentSyncs, "concurrent-syncs", s.ConcurrentSyncs, "The number of federated objects applied to the cluster concurrently.")
+}
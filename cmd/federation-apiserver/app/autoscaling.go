@@ -19,6 +19,7 @@ package app
 import (
 	"github.com/golang/glog"
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2beta1 "k8s.io/api/autoscaling/v2beta1"
 	"k8s.io/apiserver/pkg/registry/generic"
 	"k8s.io/apiserver/pkg/registry/rest"
 	genericapiserver "k8s.io/apiserver/pkg/server"
@@ -46,15 +47,20 @@ func installAutoscalingAPIs(g *genericapiserver.GenericAPIServer, optsGetter gen
 		return
 	}
 	autoscalingGroupMeta := legacyscheme.Registry.GroupOrDie(autoscaling.GroupName)
+	versionedResources := map[string]map[string]rest.Storage{
+		"v1": resources,
+	}
+	// v2beta1 serves the same objects, with support for multiple metrics.
+	if apiResourceConfigSource.VersionEnabled(autoscalingv2beta1.SchemeGroupVersion) {
+		versionedResources["v2beta1"] = resources
+	}
 	apiGroupInfo := genericapiserver.APIGroupInfo{
-		GroupMeta: *autoscalingGroupMeta,
-		VersionedResourcesStorageMap: map[string]map[string]rest.Storage{
-			"v1": resources,
-		},
-		OptionsExternalVersion: &legacyscheme.Registry.GroupOrDie(api.GroupName).GroupVersion,
-		Scheme:                 legacyscheme.Scheme,
-		ParameterCodec:         legacyscheme.ParameterCodec,
-		NegotiatedSerializer:   legacyscheme.Codecs,
+		GroupMeta:                    *autoscalingGroupMeta,
+		VersionedResourcesStorageMap: versionedResources,
+		OptionsExternalVersion:       &legacyscheme.Registry.GroupOrDie(api.GroupName).GroupVersion,
+		Scheme:                       legacyscheme.Scheme,
+		ParameterCodec:               legacyscheme.ParameterCodec,
+		NegotiatedSerializer:         legacyscheme.Codecs,
 	}
 	if err := g.InstallAPIGroup(&apiGroupInfo); err != nil {
 		glog.Fatalf("Error in registering group versions: %v", err)
@@ -26,22 +26,41 @@ import (
 	"k8s.io/federation/apis/federation"
 	_ "k8s.io/federation/apis/federation/install"
 	fedv1beta1 "k8s.io/federation/apis/federation/v1beta1"
+	"k8s.io/federation/cmd/federation-apiserver/app/options"
 	clusterstorage "k8s.io/federation/registry/cluster/etcd"
+	clusterrest "k8s.io/federation/registry/cluster/rest"
+	clustersetstorage "k8s.io/federation/registry/clusterset/etcd"
+	propagationpolicystorage "k8s.io/federation/registry/propagationpolicy/etcd"
 	"k8s.io/kubernetes/pkg/api/legacyscheme"
 	api "k8s.io/kubernetes/pkg/apis/core"
 )
 
-func installFederationAPIs(g *genericapiserver.GenericAPIServer, optsGetter generic.RESTOptionsGetter, apiResourceConfigSource storage.APIResourceConfigSource) {
+func installFederationAPIs(s *options.ServerRunOptions, g *genericapiserver.GenericAPIServer, optsGetter generic.RESTOptionsGetter, apiResourceConfigSource storage.APIResourceConfigSource) {
 	groupName := federation.GroupName
 	clustersStorageFn := func() map[string]rest.Storage {
 		clusterStorage, clusterStatusStorage := clusterstorage.NewREST(optsGetter)
 		return map[string]rest.Storage{
 			"clusters":        clusterStorage,
 			"clusters/status": clusterStatusStorage,
+			"clusters/proxy":  clusterrest.NewProxyREST(clusterStorage, s.ClusterProxyImpersonate),
+		}
+	}
+	propagationPoliciesStorageFn := func() map[string]rest.Storage {
+		return map[string]rest.Storage{
+			"propagationpolicies": propagationpolicystorage.NewREST(optsGetter),
+		}
+	}
+	clusterSetsStorageFn := func() map[string]rest.Storage {
+		clusterSetStorage, clusterSetStatusStorage := clustersetstorage.NewREST(optsGetter)
+		return map[string]rest.Storage{
+			"clustersets":        clusterSetStorage,
+			"clustersets/status": clusterSetStatusStorage,
 		}
 	}
 	resourcesStorageMap := map[string]getResourcesStorageFunc{
-		"clusters": clustersStorageFn,
+		"clusters":            clustersStorageFn,
+		"propagationpolicies": propagationPoliciesStorageFn,
+		"clustersets":         clusterSetsStorageFn,
 	}
 	shouldInstallGroup, resources := enabledResources(fedv1beta1.SchemeGroupVersion, resourcesStorageMap, apiResourceConfigSource)
 	if !shouldInstallGroup {
@@ -46,6 +46,12 @@ type ServerRunOptions struct {
 	APIEnablement           *genericoptions.APIEnablementOptions
 
 	EventTTL time.Duration
+	// ClusterProxyImpersonate makes the proxy subresource of clusters
+	// impersonate the calling user in the clusters.
+	ClusterProxyImpersonate bool
+	// ClusterExecCommands are the commands that exec credential plugins of
+	// the kubeconfigs in cluster secrets may run.
+	ClusterExecCommands []string
 }
 
 // NewServerRunOptions creates a new ServerRunOptions object with default values.
@@ -87,4 +93,13 @@ func (s *ServerRunOptions) AddFlags(fs *pflag.FlagSet) {
 
 	fs.DurationVar(&s.EventTTL, "event-ttl", s.EventTTL,
 		"Amount of time to retain events.")
+
+	fs.BoolVar(&s.ClusterProxyImpersonate, "cluster-proxy-impersonate", s.ClusterProxyImpersonate,
+		"If true, requests proxied to member clusters impersonate the federation user that made them. "+
+			"Otherwise they are made as the user of the credentials stored for the cluster.")
+
+	fs.StringSliceVar(&s.ClusterExecCommands, "cluster-exec-commands", s.ClusterExecCommands,
+		"Commands that the exec credential plugins of the kubeconfigs in cluster secrets may run. "+
+			"Anyone who can write the secrets chooses the arguments, so only list commands that are safe to run with any of them. "+
+			"Exec credential plugins are not run by default.")
 }
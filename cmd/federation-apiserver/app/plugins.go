@@ -29,24 +29,27 @@ import (
 	mutatingwebhook "k8s.io/apiserver/pkg/admission/plugin/webhook/mutating"
 	validatingwebhook "k8s.io/apiserver/pkg/admission/plugin/webhook/validating"
 	genericoptions "k8s.io/apiserver/pkg/server/options"
+	"k8s.io/federation/plugin/pkg/admission/federationannotations"
 	"k8s.io/federation/plugin/pkg/admission/schedulingpolicy"
 	"k8s.io/kubernetes/plugin/pkg/admission/gc"
 )
 
 // AllOrderedPlugins is the list of all the plugins in order.
 var AllOrderedPlugins = []string{
-	lifecycle.PluginName,         // NamespaceLifecycle
-	gc.PluginName,                // OwnerReferencesPermissionEnforcement
-	mutatingwebhook.PluginName,   // MutatingAdmissionWebhook
-	initialization.PluginName,    // Initializers
-	validatingwebhook.PluginName, // ValidatingAdmissionWebhook
-	schedulingpolicy.PluginName,  // SchedulingPolicy
+	lifecycle.PluginName,             // NamespaceLifecycle
+	gc.PluginName,                    // OwnerReferencesPermissionEnforcement
+	mutatingwebhook.PluginName,       // MutatingAdmissionWebhook
+	initialization.PluginName,        // Initializers
+	validatingwebhook.PluginName,     // ValidatingAdmissionWebhook
+	schedulingpolicy.PluginName,      // SchedulingPolicy
+	federationannotations.PluginName, // FederationAnnotations
 }
 
 // RegisterAllAdmissionPlugins registers all admission plugins
 func RegisterAllAdmissionPlugins(admission *genericoptions.AdmissionOptions) {
 	gc.Register(admission.Plugins)
 	schedulingpolicy.Register(admission.Plugins)
+	federationannotations.Register(admission.Plugins)
 
 	admission.RecommendedPluginOrder = AllOrderedPlugins
 	admission.DefaultOffPlugins.Insert(lifecycle.PluginName, schedulingpolicy.PluginName)
@@ -43,8 +43,11 @@ import (
 	clientgoinformers "k8s.io/client-go/informers"
 	clientgoclientset "k8s.io/client-go/kubernetes"
 	federationv1beta1 "k8s.io/federation/apis/federation/v1beta1"
+	federationclientset "k8s.io/federation/client/clientset_generated/federation_clientset"
 	"k8s.io/federation/cmd/federation-apiserver/app/options"
+	fedutil "k8s.io/federation/pkg/federation-controller/util"
 	"k8s.io/federation/pkg/generated/openapi"
+	federationadmission "k8s.io/federation/plugin/pkg/admission/initializer"
 	openapicommon "k8s.io/kube-openapi/pkg/common"
 	"k8s.io/kubernetes/pkg/api/legacyscheme"
 	"k8s.io/kubernetes/pkg/client/clientset_generated/internalclientset"
@@ -104,6 +107,7 @@ func NonBlockingRun(s *options.ServerRunOptions, stopCh <-chan struct{}) error {
 	}
 
 	s.Authentication.ApplyAuthorization(s.Authorization)
+	fedutil.AllowedExecCommands = sets.NewString(s.ClusterExecCommands...)
 
 	// validate options
 	if errs := s.Validate(); len(errs) != 0 {
@@ -198,6 +202,10 @@ func NonBlockingRun(s *options.ServerRunOptions, stopCh <-chan struct{}) error {
 		return fmt.Errorf("failed to create real external clientset: %v", err)
 	}
 	versionedInformers := clientgoinformers.NewSharedInformerFactory(clientgoExternalClient, 10*time.Minute)
+	federationClient, err := federationclientset.NewForConfig(kubeClientConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create federation clientset: %v", err)
+	}
 
 	authorizationConfig := s.Authorization.ToAuthorizationConfig(sharedInformers, versionedInformers)
 	apiAuthorizer, _, err := authorizationConfig.New()
@@ -208,6 +216,7 @@ func NonBlockingRun(s *options.ServerRunOptions, stopCh <-chan struct{}) error {
 	restMapper := legacyscheme.Registry.RESTMapper()
 	quotaConfiguration := quotainstall.NewQuotaConfigurationForAdmission()
 	pluginInitializer := kubeapiserveradmission.NewPluginInitializer(client, sharedInformers, nil, restMapper, quotaConfiguration)
+	federationPluginInitializer := federationadmission.New(federationClient)
 
 	kubeVersion := version.Get()
 	genericConfig.Version = &kubeVersion
@@ -228,6 +237,7 @@ func NonBlockingRun(s *options.ServerRunOptions, stopCh <-chan struct{}) error {
 		kubeClientConfig,
 		legacyscheme.Scheme,
 		pluginInitializer,
+		federationPluginInitializer,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to initialize plugins: %v", err)
@@ -257,7 +267,7 @@ func NonBlockingRun(s *options.ServerRunOptions, stopCh <-chan struct{}) error {
 	routes.Logs{}.Install(m.Handler.GoRestfulContainer)
 
 	apiResourceConfigSource := storageFactory.APIResourceConfigSource
-	installFederationAPIs(m, genericConfig.RESTOptionsGetter, apiResourceConfigSource)
+	installFederationAPIs(s, m, genericConfig.RESTOptionsGetter, apiResourceConfigSource)
 	installCoreAPIs(s, m, genericConfig.RESTOptionsGetter, apiResourceConfigSource)
 	installExtensionsAPIs(m, genericConfig.RESTOptionsGetter, apiResourceConfigSource)
 	installBatchAPIs(m, genericConfig.RESTOptionsGetter, apiResourceConfigSource)
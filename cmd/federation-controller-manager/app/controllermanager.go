@@ -31,6 +31,7 @@ import (
 	"k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/apiserver/pkg/server/healthz"
 	utilflag "k8s.io/apiserver/pkg/util/flag"
@@ -45,12 +46,15 @@ import (
 	"k8s.io/federation/cmd/federation-controller-manager/app/options"
 	"k8s.io/federation/pkg/federatedtypes"
 	clustercontroller "k8s.io/federation/pkg/federation-controller/cluster"
+	clustersetcontroller "k8s.io/federation/pkg/federation-controller/clusterset"
 	ingresscontroller "k8s.io/federation/pkg/federation-controller/ingress"
 	jobcontroller "k8s.io/federation/pkg/federation-controller/job"
 	servicecontroller "k8s.io/federation/pkg/federation-controller/service"
 	servicednscontroller "k8s.io/federation/pkg/federation-controller/service/dns"
 	synccontroller "k8s.io/federation/pkg/federation-controller/sync"
+	fedutil "k8s.io/federation/pkg/federation-controller/util"
 	"k8s.io/federation/pkg/federation-controller/util/eventsink"
+	"k8s.io/federation/pkg/federation-controller/util/scheduler"
 	"k8s.io/kubernetes/pkg/api/legacyscheme"
 	"k8s.io/kubernetes/pkg/util/configz"
 	"k8s.io/kubernetes/pkg/version"
@@ -97,6 +101,8 @@ func Run(s *options.CMServer) error {
 		glog.Errorf("unable to register configz: %s", err)
 	}
 
+	fedutil.AllowedExecCommands = sets.NewString(s.ClusterExecCommands...)
+
 	restClientCfg, err := clientcmd.BuildConfigFromFlags(s.Master, s.Kubeconfig)
 	if err != nil || restClientCfg == nil {
 		glog.V(2).Infof("Couldn't build the rest client config from flags: %v", err)
@@ -198,8 +204,6 @@ func StartControllers(s *options.CMServer, restClientCfg *restclient.Config, sto
 		glog.Fatalf("Could not find resources from API Server: %v", err)
 	}
 
-	clustercontroller.StartClusterController(restClientCfg, stopChan, s.ClusterMonitorPeriod.Duration)
-
 	if controllerEnabled(s.Controllers, serverResources, servicecontroller.ControllerName, servicecontroller.RequiredResources, true) {
 		if controllerEnabled(s.Controllers, serverResources, servicednscontroller.ControllerName, servicecontroller.RequiredResources, true) {
 			serviceDNScontrollerClientset := federationclientset.NewForConfigOrDie(restclient.AddUserAgent(restClientCfg, servicednscontroller.UserAgentName))
@@ -217,18 +221,44 @@ func StartControllers(s *options.CMServer, restClientCfg *restclient.Config, sto
 		go serviceController.Run(s.ConcurrentServiceSyncs, stopChan)
 	}
 
+	schedulerFramework, err := scheduler.NewFrameworkFromFile(s.SchedulerConfigFile)
+	if err != nil {
+		glog.Fatalf("Failed to load scheduler configuration: %v", err)
+	}
+
 	adapterSpecificArgs := make(map[string]interface{})
 	adapterSpecificArgs[federatedtypes.HpaKind] = &s.HpaScaleForbiddenWindow
+	adapterSpecificArgs[federatedtypes.ReplicaSetKind] = schedulerFramework
+	adapterSpecificArgs[federatedtypes.DeploymentKind] = schedulerFramework
+	// The cluster controller reports the clusters lacking the resources of
+	// the federated types, which their sync controllers skip.
+	requiredResources := make(map[string][]schema.GroupVersionResource)
 	for kind, federatedType := range federatedtypes.FederatedTypes() {
 		if controllerEnabled(s.Controllers, serverResources, federatedType.ControllerName, federatedType.RequiredResources, true) {
+			requiredResources[kind] = federatedType.RequiredResources
 			synccontroller.StartFederationSyncController(kind, federatedType.AdapterFactory, restClientCfg, stopChan, minimizeLatency, adapterSpecificArgs)
 		}
 	}
+	probeOptions := clustercontroller.ProbeOptions{
+		Thresholds:   clustercontroller.ProbeThresholds{Success: s.ClusterSuccessThreshold, Failure: s.ClusterFailureThreshold},
+		Workers:      s.ConcurrentClusterProbes,
+		Timeout:      s.ClusterProbeTimeout.Duration,
+		AgentTimeout: s.ClusterAgentTimeout.Duration,
+	}
+	clustercontroller.StartClusterController(restClientCfg, stopChan, s.ClusterMonitorPeriod.Duration, probeOptions, requiredResources)
+
+	if controllerEnabled(s.Controllers, serverResources, clustersetcontroller.ControllerName, clustersetcontroller.RequiredResources, true) {
+		glog.V(3).Infof("Loading client config for cluster set controller %q", clustersetcontroller.UserAgentName)
+		csClientset := federationclientset.NewForConfigOrDie(restclient.AddUserAgent(restClientCfg, clustersetcontroller.UserAgentName))
+		clusterSetController := clustersetcontroller.NewClusterSetController(csClientset)
+		glog.V(3).Infof("Running cluster set controller")
+		go clusterSetController.Run(1, stopChan)
+	}
 
 	if controllerEnabled(s.Controllers, serverResources, jobcontroller.ControllerName, jobcontroller.RequiredResources, true) {
 		glog.V(3).Infof("Loading client config for job controller %q", jobcontroller.UserAgentName)
 		jobClientset := federationclientset.NewForConfigOrDie(restclient.AddUserAgent(restClientCfg, jobcontroller.UserAgentName))
-		jobController := jobcontroller.NewJobController(jobClientset)
+		jobController := jobcontroller.NewJobController(jobClientset, schedulerFramework)
 		glog.V(3).Infof("Running job controller")
 		go jobController.Run(s.ConcurrentJobSyncs, wait.NeverStop)
 	}
@@ -27,6 +27,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilflag "k8s.io/apiserver/pkg/util/flag"
 	"k8s.io/federation/pkg/dnsprovider"
+	"k8s.io/federation/pkg/federation-controller/util/scheduler"
 	"k8s.io/kubernetes/pkg/apis/componentconfig"
 	"k8s.io/kubernetes/pkg/client/leaderelectionconfig"
 )
@@ -62,6 +63,21 @@ type ControllerManagerConfiguration struct {
 	ConcurrentJobSyncs int `json:"concurrentJobSyncs"`
 	// clusterMonitorPeriod is the period for syncing ClusterStatus in cluster controller.
 	ClusterMonitorPeriod metav1.Duration `json:"clusterMonitorPeriod"`
+	// clusterSuccessThreshold is the number of consecutive successful health
+	// checks that make an unready cluster ready.
+	ClusterSuccessThreshold int `json:"clusterSuccessThreshold"`
+	// clusterFailureThreshold is the number of consecutive failed health
+	// checks that make a ready cluster unready. A ready cluster failing fewer
+	// health checks is reported as degraded.
+	ClusterFailureThreshold int `json:"clusterFailureThreshold"`
+	// concurrentClusterProbes is the number of clusters whose health is
+	// checked concurrently.
+	ConcurrentClusterProbes int `json:"concurrentClusterProbes"`
+	// clusterProbeTimeout bounds the health check of a single cluster.
+	ClusterProbeTimeout metav1.Duration `json:"clusterProbeTimeout"`
+	// clusterAgentTimeout is the time after which a cluster in pull mode
+	// whose agent stopped reporting its status is reported offline.
+	ClusterAgentTimeout metav1.Duration `json:"clusterAgentTimeout"`
 	// APIServerQPS is the QPS to use while talking with federation apiserver.
 	APIServerQPS float32 `json:"federatedAPIQPS"`
 	// APIServerBurst is the burst to use while talking with federation apiserver.
@@ -85,6 +101,12 @@ type ControllerManagerConfiguration struct {
 	HpaScaleForbiddenWindow metav1.Duration `json:"HpaScaleForbiddenWindow"`
 	// pre-configured namespace name that would be created only in federation control plane
 	FederationOnlyNamespace string `json:"federationOnlyNamespaceName"`
+	// SchedulerConfigFile is the path to the file defining the scheduler profiles
+	// used to place replicas of federated replicasets, deployments and jobs.
+	SchedulerConfigFile string `json:"schedulerConfigFile"`
+	// ClusterExecCommands are the commands that exec credential plugins of
+	// the kubeconfigs in cluster secrets may run.
+	ClusterExecCommands []string `json:"clusterExecCommands"`
 }
 
 // CMServer is the main context object for the controller manager.
@@ -109,6 +131,11 @@ func NewCMServer() *CMServer {
 			ConcurrentServiceSyncs:    10,
 			ConcurrentReplicaSetSyncs: 10,
 			ClusterMonitorPeriod:      metav1.Duration{Duration: 40 * time.Second},
+			ClusterSuccessThreshold:   1,
+			ClusterFailureThreshold:   3,
+			ConcurrentClusterProbes:   10,
+			ClusterProbeTimeout:       metav1.Duration{Duration: 10 * time.Second},
+			ClusterAgentTimeout:       metav1.Duration{Duration: 2 * time.Minute},
 			ConcurrentJobSyncs:        10,
 			APIServerQPS:              20.0,
 			APIServerBurst:            30,
@@ -133,6 +160,11 @@ func (s *CMServer) AddFlags(fs *pflag.FlagSet) {
 	fs.IntVar(&s.ConcurrentReplicaSetSyncs, "concurrent-replicaset-syncs", s.ConcurrentReplicaSetSyncs, "The number of ReplicaSets syncing operations that will be done concurrently. Larger number = faster endpoint updating, but more CPU (and network) load")
 	fs.IntVar(&s.ConcurrentJobSyncs, "concurrent-job-syncs", s.ConcurrentJobSyncs, "The number of Jobs syncing operations that will be done concurrently. Larger number = faster endpoint updating, but more CPU (and network) load")
 	fs.DurationVar(&s.ClusterMonitorPeriod.Duration, "cluster-monitor-period", s.ClusterMonitorPeriod.Duration, "The period for syncing ClusterStatus in ClusterController.")
+	fs.IntVar(&s.ClusterSuccessThreshold, "cluster-success-threshold", s.ClusterSuccessThreshold, "The number of consecutive successful health checks after which an unready cluster becomes ready.")
+	fs.IntVar(&s.ClusterFailureThreshold, "cluster-failure-threshold", s.ClusterFailureThreshold, "The number of consecutive failed health checks after which a ready cluster becomes unready. Until then the cluster is reported as degraded and no new objects are placed in it.")
+	fs.IntVar(&s.ConcurrentClusterProbes, "concurrent-cluster-probes", s.ConcurrentClusterProbes, "The number of clusters whose health is checked concurrently. A cluster that does not answer only delays the health checks of the clusters waiting for a free worker.")
+	fs.DurationVar(&s.ClusterProbeTimeout.Duration, "cluster-probe-timeout", s.ClusterProbeTimeout.Duration, "The time after which the health check of a cluster is abandoned.")
+	fs.DurationVar(&s.ClusterAgentTimeout.Duration, "cluster-agent-timeout", s.ClusterAgentTimeout.Duration, "The time after which a cluster in pull mode whose agent stopped reporting its status is reported offline.")
 	fs.BoolVar(&s.EnableProfiling, "profiling", true, "Enable profiling via web interface host:port/debug/pprof/")
 	fs.BoolVar(&s.EnableContentionProfiling, "contention-profiling", false, "Enable lock contention profiling, if profiling is enabled")
 	fs.StringVar(&s.Master, "master", s.Master, "The address of the federation API server (overrides any value in kubeconfig)")
@@ -148,5 +180,7 @@ func (s *CMServer) AddFlags(fs *pflag.FlagSet) {
 		"to enable/disable specific controllers. Key should be the resource name (like services) and value should be true or false. "+
 		"For example: services=false,ingresses=false")
 	fs.StringVar(&s.FederationOnlyNamespace, "federation-only-namespace", s.FederationOnlyNamespace, "Name of the namespace that would be created only in federation control plane.")
+	fs.StringVar(&s.SchedulerConfigFile, "scheduler-config", s.SchedulerConfigFile, "Path to the file defining the scheduler profiles that federated replicasets, deployments and jobs can select with the "+scheduler.SchedulerProfileAnnotation+" annotation.")
+	fs.StringSliceVar(&s.ClusterExecCommands, "cluster-exec-commands", s.ClusterExecCommands, "Commands that the exec credential plugins of the kubeconfigs in cluster secrets may run. Anyone who can write the secrets chooses the arguments, so only list commands that are safe to run with any of them. Exec credential plugins are not run by default.")
 	leaderelectionconfig.BindFlags(&s.LeaderElection, fs)
 }
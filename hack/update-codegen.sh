@@ -36,4 +36,4 @@ clientgen=$(kube::util::find-binary "client-gen")
 
 # This can be called with one flag, --verify-only, so it works for both the
 # update- and verify- scripts.
-${clientgen} --clientset-name=federation_clientset --clientset-path=k8s.io/federation/client/clientset_generated --input-base="k8s.io/federation/vendor/k8s.io/api" --input="../../../apis/federation/v1beta1","core/v1","extensions/v1beta1","batch/v1","autoscaling/v1" --included-types-overrides="core/v1/Service,core/v1/Namespace,extensions/v1beta1/ReplicaSet,core/v1/Secret,extensions/v1beta1/Ingress,extensions/v1beta1/Deployment,extensions/v1beta1/DaemonSet,core/v1/ConfigMap,core/v1/Event,batch/v1/Job,autoscaling/v1/HorizontalPodAutoscaler" --go-header-file="${KUBE_ROOT}/hack/boilerplate/boilerplate.go.txt" "$@"
+${clientgen} --clientset-name=federation_clientset --clientset-path=k8s.io/federation/client/clientset_generated --input-base="k8s.io/federation/vendor/k8s.io/api" --input="../../../apis/federation/v1beta1","core/v1","extensions/v1beta1","batch/v1","autoscaling/v1","autoscaling/v2beta1" --included-types-overrides="core/v1/Service,core/v1/Namespace,extensions/v1beta1/ReplicaSet,core/v1/Secret,extensions/v1beta1/Ingress,extensions/v1beta1/Deployment,extensions/v1beta1/DaemonSet,core/v1/ConfigMap,core/v1/Event,batch/v1/Job,autoscaling/v1/HorizontalPodAutoscaler,autoscaling/v2beta1/HorizontalPodAutoscaler" --go-header-file="${KUBE_ROOT}/hack/boilerplate/boilerplate.go.txt" "$@"
@@ -64,6 +64,8 @@ func NewDeploymentAdapter(client federationclientset.Interface, config *restclie
 			}
 			return nil
 		},
+		framework: schedulerFrameworkFromArgs(DeploymentKind, adapterSpecificArgs),
+		rebalance: newRebalanceTracker(),
 	}
 
 	return &DeploymentAdapter{&schedulingAdapter, client}
@@ -21,7 +21,10 @@ import (
 	"time"
 
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2beta1 "k8s.io/api/autoscaling/v2beta1"
+	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/api/extensions/v1beta1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	pkgruntime "k8s.io/apimachinery/pkg/runtime"
@@ -30,6 +33,7 @@ import (
 	"k8s.io/apimachinery/pkg/watch"
 	kubeclientset "k8s.io/client-go/kubernetes"
 	restclient "k8s.io/client-go/rest"
+	fedapi "k8s.io/federation/apis/federation"
 	federationapi "k8s.io/federation/apis/federation/v1beta1"
 	federationclientset "k8s.io/federation/client/clientset_generated/federation_clientset"
 	fedutil "k8s.io/federation/pkg/federation-controller/util"
@@ -56,9 +60,22 @@ func init() {
 	RegisterFederatedType(HpaKind, HpaControllerName, []schema.GroupVersionResource{autoscalingv1.SchemeGroupVersion.WithResource(HpaControllerName)}, NewHpaAdapter)
 }
 
+// HpaAdapter federates horizontal pod autoscalers scaling on resource, pods
+// and object metrics.
+//
+// External metric sources are out of scope until the vendored Kubernetes API
+// is updated to 1.10, the first release defining them. Until then the
+// federation API server rejects hpas with an External metric as an
+// unsupported metric source type, rather than dropping the metric.
+//
+// The hpas are read and written through autoscaling/v1, see hpaconversion.go.
+// The metrics survive the conversions, only the cpu utilization metric moves
+// to the end of the list, which does not change how the hpa scales.
 type HpaAdapter struct {
 	client               federationclientset.Interface
 	scaleForbiddenWindow time.Duration
+	// Max replicas moved between clusters by hpas in global mode.
+	moves *rebalanceTracker
 }
 
 func NewHpaAdapter(client federationclientset.Interface, config *restclient.Config, adapterSpecificArgs map[string]interface{}) FederatedTypeAdapter {
@@ -72,6 +89,7 @@ func NewHpaAdapter(client federationclientset.Interface, config *restclient.Conf
 	return &HpaAdapter{
 		client:               client,
 		scaleForbiddenWindow: scaleForbiddenWindow,
+		moves:                newRebalanceTracker(),
 	}
 }
 
@@ -80,17 +98,17 @@ func (a *HpaAdapter) Kind() string {
 }
 
 func (a *HpaAdapter) ObjectType() pkgruntime.Object {
-	return &autoscalingv1.HorizontalPodAutoscaler{}
+	return &autoscalingv2beta1.HorizontalPodAutoscaler{}
 }
 
 func (a *HpaAdapter) IsExpectedType(obj interface{}) bool {
-	_, ok := obj.(*autoscalingv1.HorizontalPodAutoscaler)
+	_, ok := obj.(*autoscalingv2beta1.HorizontalPodAutoscaler)
 	return ok
 }
 
 func (a *HpaAdapter) Copy(obj pkgruntime.Object) pkgruntime.Object {
-	hpa := obj.(*autoscalingv1.HorizontalPodAutoscaler)
-	return &autoscalingv1.HorizontalPodAutoscaler{
+	hpa := obj.(*autoscalingv2beta1.HorizontalPodAutoscaler)
+	return &autoscalingv2beta1.HorizontalPodAutoscaler{
 		ObjectMeta: fedutil.DeepCopyRelevantObjectMeta(hpa.ObjectMeta),
 		Spec:       *hpa.Spec.DeepCopy(),
 	}
@@ -101,17 +119,20 @@ func (a *HpaAdapter) Equivalent(obj1, obj2 pkgruntime.Object) bool {
 }
 
 func (a *HpaAdapter) QualifiedName(obj pkgruntime.Object) QualifiedName {
-	hpa := obj.(*autoscalingv1.HorizontalPodAutoscaler)
+	hpa := obj.(*autoscalingv2beta1.HorizontalPodAutoscaler)
 	return QualifiedName{Namespace: hpa.Namespace, Name: hpa.Name}
 }
 
 func (a *HpaAdapter) ObjectMeta(obj pkgruntime.Object) *metav1.ObjectMeta {
-	return &obj.(*autoscalingv1.HorizontalPodAutoscaler).ObjectMeta
+	return &obj.(*autoscalingv2beta1.HorizontalPodAutoscaler).ObjectMeta
 }
 
 func (a *HpaAdapter) FedCreate(obj pkgruntime.Object) (pkgruntime.Object, error) {
-	hpa := obj.(*autoscalingv1.HorizontalPodAutoscaler)
-	return a.client.AutoscalingV1().HorizontalPodAutoscalers(hpa.Namespace).Create(hpa)
+	hpa, err := hpaToV1(obj.(*autoscalingv2beta1.HorizontalPodAutoscaler))
+	if err != nil {
+		return nil, err
+	}
+	return hpaResultFromV1(a.client.AutoscalingV1().HorizontalPodAutoscalers(hpa.Namespace).Create(hpa))
 }
 
 func (a *HpaAdapter) FedDelete(qualifiedName QualifiedName, options *metav1.DeleteOptions) error {
@@ -119,25 +140,31 @@ func (a *HpaAdapter) FedDelete(qualifiedName QualifiedName, options *metav1.Dele
 }
 
 func (a *HpaAdapter) FedGet(qualifiedName QualifiedName) (pkgruntime.Object, error) {
-	return a.client.AutoscalingV1().HorizontalPodAutoscalers(qualifiedName.Namespace).Get(qualifiedName.Name, metav1.GetOptions{})
+	return hpaResultFromV1(a.client.AutoscalingV1().HorizontalPodAutoscalers(qualifiedName.Namespace).Get(qualifiedName.Name, metav1.GetOptions{}))
 }
 
 func (a *HpaAdapter) FedList(namespace string, options metav1.ListOptions) (pkgruntime.Object, error) {
-	return a.client.AutoscalingV1().HorizontalPodAutoscalers(namespace).List(options)
+	return hpaListFromV1(a.client.AutoscalingV1().HorizontalPodAutoscalers(namespace).List(options))
 }
 
 func (a *HpaAdapter) FedUpdate(obj pkgruntime.Object) (pkgruntime.Object, error) {
-	hpa := obj.(*autoscalingv1.HorizontalPodAutoscaler)
-	return a.client.AutoscalingV1().HorizontalPodAutoscalers(hpa.Namespace).Update(hpa)
+	hpa, err := hpaToV1(obj.(*autoscalingv2beta1.HorizontalPodAutoscaler))
+	if err != nil {
+		return nil, err
+	}
+	return hpaResultFromV1(a.client.AutoscalingV1().HorizontalPodAutoscalers(hpa.Namespace).Update(hpa))
 }
 
 func (a *HpaAdapter) FedWatch(namespace string, options metav1.ListOptions) (watch.Interface, error) {
-	return a.client.AutoscalingV1().HorizontalPodAutoscalers(namespace).Watch(options)
+	return hpaWatchFromV1(a.client.AutoscalingV1().HorizontalPodAutoscalers(namespace).Watch(options))
 }
 
 func (a *HpaAdapter) ClusterCreate(client kubeclientset.Interface, obj pkgruntime.Object) (pkgruntime.Object, error) {
-	hpa := obj.(*autoscalingv1.HorizontalPodAutoscaler)
-	return client.AutoscalingV1().HorizontalPodAutoscalers(hpa.Namespace).Create(hpa)
+	hpa, err := hpaToV1(obj.(*autoscalingv2beta1.HorizontalPodAutoscaler))
+	if err != nil {
+		return nil, err
+	}
+	return hpaResultFromV1(client.AutoscalingV1().HorizontalPodAutoscalers(hpa.Namespace).Create(hpa))
 }
 
 func (a *HpaAdapter) ClusterDelete(client kubeclientset.Interface, qualifiedName QualifiedName, options *metav1.DeleteOptions) error {
@@ -145,38 +172,47 @@ func (a *HpaAdapter) ClusterDelete(client kubeclientset.Interface, qualifiedName
 }
 
 func (a *HpaAdapter) ClusterGet(client kubeclientset.Interface, qualifiedName QualifiedName) (pkgruntime.Object, error) {
-	return client.AutoscalingV1().HorizontalPodAutoscalers(qualifiedName.Namespace).Get(qualifiedName.Name, metav1.GetOptions{})
+	return hpaResultFromV1(client.AutoscalingV1().HorizontalPodAutoscalers(qualifiedName.Namespace).Get(qualifiedName.Name, metav1.GetOptions{}))
 }
 
 func (a *HpaAdapter) ClusterList(client kubeclientset.Interface, namespace string, options metav1.ListOptions) (pkgruntime.Object, error) {
-	return client.AutoscalingV1().HorizontalPodAutoscalers(namespace).List(options)
+	return hpaListFromV1(client.AutoscalingV1().HorizontalPodAutoscalers(namespace).List(options))
 }
 
 func (a *HpaAdapter) ClusterUpdate(client kubeclientset.Interface, obj pkgruntime.Object) (pkgruntime.Object, error) {
-	hpa := obj.(*autoscalingv1.HorizontalPodAutoscaler)
-	return client.AutoscalingV1().HorizontalPodAutoscalers(hpa.Namespace).Update(hpa)
+	hpa, err := hpaToV1(obj.(*autoscalingv2beta1.HorizontalPodAutoscaler))
+	if err != nil {
+		return nil, err
+	}
+	return hpaResultFromV1(client.AutoscalingV1().HorizontalPodAutoscalers(hpa.Namespace).Update(hpa))
 }
 
 func (a *HpaAdapter) ClusterWatch(client kubeclientset.Interface, namespace string, options metav1.ListOptions) (watch.Interface, error) {
-	return client.AutoscalingV1().HorizontalPodAutoscalers(namespace).Watch(options)
+	return hpaWatchFromV1(client.AutoscalingV1().HorizontalPodAutoscalers(namespace).Watch(options))
 }
 
 func (a *HpaAdapter) NewTestObject(namespace string) pkgruntime.Object {
 	var min int32 = 4
 	var targetCPU int32 = 70
-	return &autoscalingv1.HorizontalPodAutoscaler{
+	return &autoscalingv2beta1.HorizontalPodAutoscaler{
 		ObjectMeta: metav1.ObjectMeta{
 			GenerateName: "test-hpa-",
 			Namespace:    namespace,
 		},
-		Spec: autoscalingv1.HorizontalPodAutoscalerSpec{
-			ScaleTargetRef: autoscalingv1.CrossVersionObjectReference{
+		Spec: autoscalingv2beta1.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2beta1.CrossVersionObjectReference{
 				Kind: "ReplicaSet",
 				Name: "myrs",
 			},
-			MinReplicas:                    &min,
-			MaxReplicas:                    int32(10),
-			TargetCPUUtilizationPercentage: &targetCPU,
+			MinReplicas: &min,
+			MaxReplicas: int32(10),
+			Metrics: []autoscalingv2beta1.MetricSpec{{
+				Type: autoscalingv2beta1.ResourceMetricSourceType,
+				Resource: &autoscalingv2beta1.ResourceMetricSource{
+					Name:                     apiv1.ResourceCPU,
+					TargetAverageUtilization: &targetCPU,
+				},
+			}},
 		},
 	}
 }
@@ -186,8 +222,8 @@ func (a *HpaAdapter) IsSchedulingAdapter() bool {
 }
 
 func (a *HpaAdapter) EquivalentIgnoringSchedule(obj1, obj2 pkgruntime.Object) bool {
-	hpa1 := obj1.(*autoscalingv1.HorizontalPodAutoscaler)
-	hpa2 := a.Copy(obj2).(*autoscalingv1.HorizontalPodAutoscaler)
+	hpa1 := obj1.(*autoscalingv2beta1.HorizontalPodAutoscaler)
+	hpa2 := a.Copy(obj2).(*autoscalingv2beta1.HorizontalPodAutoscaler)
 	if hpa1.Spec.MinReplicas == nil {
 		hpa2.Spec.MinReplicas = nil
 	} else if hpa2.Spec.MinReplicas == nil {
@@ -207,13 +243,11 @@ type replicaNums struct {
 
 type hpaFederatedStatus struct {
 	lastScaleTime *metav1.Time
-	// Indicates how many clusters have hpa/replicas.
-	// Used to average the cpu utilization which is
-	// reflected to the federation user.
-	count                             int32
-	aggregateCPUUtilizationPercentage *int32
-	currentReplicas                   int32
-	desiredReplicas                   int32
+	// Current metrics of the local hpas, aggregated
+	// to be reflected to the federation user.
+	metrics         *hpaMetrics
+	currentReplicas int32
+	desiredReplicas int32
 }
 
 type hpaSchedulingInfo struct {
@@ -237,15 +271,17 @@ func (a *HpaAdapter) GetSchedule(obj pkgruntime.Object, key string, clusters []*
 		return nil, err
 	}
 
-	// Initialise averaged cpu utilisation for this reconcile.
-	var ccup int32 = 0
+	// Initialise aggregated metrics for this reconcile.
 	fedStatus := hpaFederatedStatus{
-		aggregateCPUUtilizationPercentage: &ccup,
-		count:           int32(0),
+		metrics:         newHpaMetrics(),
 		desiredReplicas: int32(0),
 		currentReplicas: int32(0),
 	}
-	fedHpa := obj.(*autoscalingv1.HorizontalPodAutoscaler)
+	fedHpa := obj.(*autoscalingv2beta1.HorizontalPodAutoscaler)
+	prefs, err := getHpaPreferences(fedHpa)
+	if err != nil {
+		return nil, err
+	}
 	// We assign the last known scale time here, which we update with
 	// the latest time from among all clusters in ScheduleObject()
 	if fedHpa.Status.LastScaleTime != nil {
@@ -254,7 +290,7 @@ func (a *HpaAdapter) GetSchedule(obj pkgruntime.Object, key string, clusters []*
 	}
 
 	return &hpaSchedulingInfo{
-		scheduleState: a.getHpaScheduleState(obj, currentClusterObjs),
+		scheduleState: a.getHpaScheduleState(obj, prefs, currentClusterObjs),
 		fedStatus:     fedStatus,
 	}, nil
 }
@@ -305,8 +341,12 @@ func getCurrentClusterObjs(informer fedutil.FederatedInformer, key string, clust
 //
 // The above algorithm is run to first distribute max and then distribute min to those clusters
 // which get max.
-func (a *HpaAdapter) getHpaScheduleState(fedObj pkgruntime.Object, currentObjs map[string]pkgruntime.Object) map[string]*replicaNums {
-	fedHpa := fedObj.(*autoscalingv1.HorizontalPodAutoscaler)
+//
+// In global mode only local hpas which have not scaled yet offer their replicas in
+// step 1. Instead, once the replicas are distributed, max and min are moved between
+// the clusters according to their utilization by scheduleGlobally.
+func (a *HpaAdapter) getHpaScheduleState(fedObj pkgruntime.Object, prefs *fedapi.HpaPreferences, currentObjs map[string]pkgruntime.Object) map[string]*replicaNums {
+	fedHpa := fedObj.(*autoscalingv2beta1.HorizontalPodAutoscaler)
 	requestedMin := hpaMinReplicaDefault
 	if fedHpa.Spec.MinReplicas != nil {
 		requestedMin = *fedHpa.Spec.MinReplicas
@@ -340,6 +380,10 @@ func (a *HpaAdapter) getHpaScheduleState(fedObj pkgruntime.Object, currentObjs m
 	// schedStatus currently have status of existing hpas.
 	// It will eventually have desired status for this reconcile.
 	clusterLists, currentReplicas, scheduleState := a.prepareForScheduling(currentObjs)
+	if isGlobalMode(prefs) {
+		keepPristine(clusterLists.availableMax, currentObjs)
+		keepPristine(clusterLists.availableMin, currentObjs)
+	}
 
 	remainingReplicas := replicaNums{
 		min: requestedReplicas.min - currentReplicas.min,
@@ -390,19 +434,20 @@ func (a *HpaAdapter) getHpaScheduleState(fedObj pkgruntime.Object, currentObjs m
 	// but min remains 0.
 	a.distributeMinReplicas(toDistribute.min, clusterLists, rdc, currentObjs, scheduleState)
 
-	return finaliseScheduleState(scheduleState)
+	scheduleState = finaliseScheduleState(scheduleState)
+	if isGlobalMode(prefs) {
+		key := QualifiedName{Namespace: fedHpa.Namespace, Name: fedHpa.Name}.String()
+		a.scheduleGlobally(key, prefs, currentObjs, scheduleState)
+	}
+	return scheduleState
 }
 
 func (a *HpaAdapter) ScheduleObject(cluster *federationapi.Cluster, clusterObj pkgruntime.Object, federationObjCopy pkgruntime.Object, schedulingInfo interface{}) (pkgruntime.Object, ScheduleAction, error) {
 	// Update federated status info
 	typedInfo := schedulingInfo.(*hpaSchedulingInfo)
 	if clusterObj != nil {
-		clusterHpa := clusterObj.(*autoscalingv1.HorizontalPodAutoscaler)
-		if clusterHpa.Status.CurrentCPUUtilizationPercentage != nil {
-			*typedInfo.fedStatus.aggregateCPUUtilizationPercentage +=
-				(*clusterHpa.Status.CurrentCPUUtilizationPercentage * clusterHpa.Status.CurrentReplicas)
-			typedInfo.fedStatus.count += clusterHpa.Status.CurrentReplicas
-		}
+		clusterHpa := clusterObj.(*autoscalingv2beta1.HorizontalPodAutoscaler)
+		typedInfo.fedStatus.metrics.add(clusterHpa.Status.CurrentMetrics, clusterHpa.Status.CurrentReplicas)
 		if clusterHpa.Status.LastScaleTime != nil {
 			t := metav1.NewTime(clusterHpa.Status.LastScaleTime.Time)
 			if typedInfo.fedStatus.lastScaleTime != nil &&
@@ -417,7 +462,7 @@ func (a *HpaAdapter) ScheduleObject(cluster *federationapi.Cluster, clusterObj p
 
 	// Update the cluster obj and the needed action on the cluster
 	clusterHpaState := typedInfo.scheduleState[cluster.Name]
-	desiredHpa := federationObjCopy.(*autoscalingv1.HorizontalPodAutoscaler)
+	desiredHpa := federationObjCopy.(*autoscalingv2beta1.HorizontalPodAutoscaler)
 	if clusterHpaState != nil {
 		desiredHpa.Spec.MaxReplicas = clusterHpaState.max
 		if desiredHpa.Spec.MinReplicas == nil {
@@ -440,11 +485,14 @@ func (a *HpaAdapter) ScheduleObject(cluster *federationapi.Cluster, clusterObj p
 }
 
 func (a *HpaAdapter) UpdateFederatedStatus(obj pkgruntime.Object, schedulingInfo interface{}) error {
-	fedHpa := obj.(*autoscalingv1.HorizontalPodAutoscaler)
+	fedHpa := obj.(*autoscalingv2beta1.HorizontalPodAutoscaler)
 	needUpdate, newFedHpaStatus := updateStatus(fedHpa, schedulingInfo.(*hpaSchedulingInfo).fedStatus)
 	if needUpdate {
 		fedHpa.Status = newFedHpaStatus
-		_, err := a.client.AutoscalingV1().HorizontalPodAutoscalers(fedHpa.Namespace).UpdateStatus(fedHpa)
+		hpa, err := hpaToV1(fedHpa)
+		if err == nil {
+			_, err = a.client.AutoscalingV1().HorizontalPodAutoscalers(hpa.Namespace).UpdateStatus(hpa)
+		}
 		if err != nil {
 			return fmt.Errorf("Error updating hpa: %s status in federation: %v", fedHpa.Name, err)
 		}
@@ -456,22 +504,20 @@ func (a *HpaAdapter) UpdateFederatedStatus(obj pkgruntime.Object, schedulingInfo
 	return nil
 }
 
-func updateStatus(fedHpa *autoscalingv1.HorizontalPodAutoscaler, newStatus hpaFederatedStatus) (bool, autoscalingv1.HorizontalPodAutoscalerStatus) {
-	averageCPUUtilizationPercentage := int32(0)
-	// Average out the available current utilisation
-	if *newStatus.aggregateCPUUtilizationPercentage != 0 && newStatus.count != 0 {
-		averageCPUUtilizationPercentage = *newStatus.aggregateCPUUtilizationPercentage / newStatus.count
-	}
+func updateStatus(fedHpa *autoscalingv2beta1.HorizontalPodAutoscaler, newStatus hpaFederatedStatus) (bool, autoscalingv2beta1.HorizontalPodAutoscalerStatus) {
 	gen := fedHpa.Generation
-	newFedHpaStatus := autoscalingv1.HorizontalPodAutoscalerStatus{ObservedGeneration: &gen}
+	newFedHpaStatus := autoscalingv2beta1.HorizontalPodAutoscalerStatus{
+		ObservedGeneration: &gen,
+		LastScaleTime:      fedHpa.Status.LastScaleTime,
+		CurrentReplicas:    newStatus.currentReplicas,
+		DesiredReplicas:    newStatus.desiredReplicas,
+		CurrentMetrics:     newStatus.metrics.currentMetrics(),
+		Conditions:         fedHpa.Status.Conditions,
+	}
 	needUpdate := false
-	if (fedHpa.Status.CurrentCPUUtilizationPercentage == nil &&
-		averageCPUUtilizationPercentage != 0) ||
-		(fedHpa.Status.CurrentCPUUtilizationPercentage != nil &&
-			averageCPUUtilizationPercentage !=
-				*fedHpa.Status.CurrentCPUUtilizationPercentage) {
+	if (len(fedHpa.Status.CurrentMetrics) != 0 || len(newFedHpaStatus.CurrentMetrics) != 0) &&
+		!apiequality.Semantic.DeepEqual(fedHpa.Status.CurrentMetrics, newFedHpaStatus.CurrentMetrics) {
 		needUpdate = true
-		newFedHpaStatus.CurrentCPUUtilizationPercentage = &averageCPUUtilizationPercentage
 	}
 	if (fedHpa.Status.LastScaleTime == nil && newStatus.lastScaleTime != nil) ||
 		(fedHpa.Status.LastScaleTime != nil && newStatus.lastScaleTime == nil) ||
@@ -482,11 +528,9 @@ func updateStatus(fedHpa *autoscalingv1.HorizontalPodAutoscaler, newStatus hpaFe
 	}
 	if fedHpa.Status.DesiredReplicas != newStatus.desiredReplicas {
 		needUpdate = true
-		newFedHpaStatus.CurrentReplicas = newStatus.currentReplicas
 	}
 	if fedHpa.Status.CurrentReplicas != newStatus.currentReplicas {
 		needUpdate = true
-		newFedHpaStatus.DesiredReplicas = newStatus.desiredReplicas
 	}
 	return needUpdate, newFedHpaStatus
 }
@@ -523,12 +567,12 @@ func (a *HpaAdapter) prepareForScheduling(currentObjs map[string]pkgruntime.Obje
 
 		replicas := replicaNums{min: 0, max: 0}
 		scheduleState[cluster] = &replicas
-		if obj.(*autoscalingv1.HorizontalPodAutoscaler).Spec.MinReplicas != nil {
-			existingTotal.min += *obj.(*autoscalingv1.HorizontalPodAutoscaler).Spec.MinReplicas
-			replicas.min = *obj.(*autoscalingv1.HorizontalPodAutoscaler).Spec.MinReplicas
+		if obj.(*autoscalingv2beta1.HorizontalPodAutoscaler).Spec.MinReplicas != nil {
+			existingTotal.min += *obj.(*autoscalingv2beta1.HorizontalPodAutoscaler).Spec.MinReplicas
+			replicas.min = *obj.(*autoscalingv2beta1.HorizontalPodAutoscaler).Spec.MinReplicas
 		}
-		existingTotal.max += obj.(*autoscalingv1.HorizontalPodAutoscaler).Spec.MaxReplicas
-		replicas.max = obj.(*autoscalingv1.HorizontalPodAutoscaler).Spec.MaxReplicas
+		existingTotal.max += obj.(*autoscalingv2beta1.HorizontalPodAutoscaler).Spec.MaxReplicas
+		replicas.max = obj.(*autoscalingv2beta1.HorizontalPodAutoscaler).Spec.MaxReplicas
 	}
 
 	return lists, existingTotal, scheduleState
@@ -853,7 +897,7 @@ func finaliseScheduleState(scheduled map[string]*replicaNums) map[string]*replic
 // and can be used authoritatively; or have another field on the local object
 // which is mandatorily set on creation and can be used authoritatively.
 // Should we abuse annotations again for this, or this can be a proper requirement?
-func isPristine(hpa *autoscalingv1.HorizontalPodAutoscaler) bool {
+func isPristine(hpa *autoscalingv2beta1.HorizontalPodAutoscaler) bool {
 	if hpa.Status.LastScaleTime == nil &&
 		hpa.Status.DesiredReplicas == 0 {
 		return true
@@ -863,7 +907,7 @@ func isPristine(hpa *autoscalingv1.HorizontalPodAutoscaler) bool {
 
 // isScaleable tells if it already has been a reasonable amount of
 // time since this hpa scaled. Its used to avoid fast thrashing.
-func (a *HpaAdapter) isScaleable(hpa *autoscalingv1.HorizontalPodAutoscaler) bool {
+func (a *HpaAdapter) isScaleable(hpa *autoscalingv2beta1.HorizontalPodAutoscaler) bool {
 	if hpa.Status.LastScaleTime == nil {
 		return false
 	}
@@ -875,7 +919,7 @@ func (a *HpaAdapter) isScaleable(hpa *autoscalingv1.HorizontalPodAutoscaler) boo
 }
 
 func (a *HpaAdapter) maxReplicasReducible(obj pkgruntime.Object) bool {
-	hpa := obj.(*autoscalingv1.HorizontalPodAutoscaler)
+	hpa := obj.(*autoscalingv2beta1.HorizontalPodAutoscaler)
 	if (hpa.Spec.MinReplicas != nil) &&
 		(((hpa.Spec.MaxReplicas - 1) - *hpa.Spec.MinReplicas) < 0) {
 		return false
@@ -901,7 +945,7 @@ func (a *HpaAdapter) maxReplicasReducible(obj pkgruntime.Object) bool {
 // but will not be able to scale down further and offer max to some other cluster
 // which needs replicas.
 func (a *HpaAdapter) minReplicasReducible(obj pkgruntime.Object) bool {
-	hpa := obj.(*autoscalingv1.HorizontalPodAutoscaler)
+	hpa := obj.(*autoscalingv2beta1.HorizontalPodAutoscaler)
 	if isPristine(hpa) && (hpa.Spec.MinReplicas != nil) &&
 		(*hpa.Spec.MinReplicas > 1) &&
 		(*hpa.Spec.MinReplicas <= hpa.Spec.MaxReplicas) {
@@ -920,7 +964,7 @@ func (a *HpaAdapter) minReplicasReducible(obj pkgruntime.Object) bool {
 }
 
 func (a *HpaAdapter) maxReplicasNeeded(obj pkgruntime.Object) bool {
-	hpa := obj.(*autoscalingv1.HorizontalPodAutoscaler)
+	hpa := obj.(*autoscalingv2beta1.HorizontalPodAutoscaler)
 	if !a.isScaleable(hpa) {
 		return false
 	}
@@ -933,7 +977,7 @@ func (a *HpaAdapter) maxReplicasNeeded(obj pkgruntime.Object) bool {
 }
 
 func (a *HpaAdapter) minReplicasIncreasable(obj pkgruntime.Object) bool {
-	hpa := obj.(*autoscalingv1.HorizontalPodAutoscaler)
+	hpa := obj.(*autoscalingv2beta1.HorizontalPodAutoscaler)
 	if !a.isScaleable(hpa) ||
 		((hpa.Spec.MinReplicas != nil) &&
 			(*hpa.Spec.MinReplicas) >= hpa.Spec.MaxReplicas) {
@@ -951,7 +995,7 @@ func (a *HpaAdapter) minReplicasIncreasable(obj pkgruntime.Object) bool {
 // so that the corresponding controller can act on that.
 // This is used because if an hpa is active on a federated object it is supposed
 // to control the replicas and presence/absence of target object from federated clusters.
-func (a *HpaAdapter) updateClusterListOnTargetObject(fedHpa *autoscalingv1.HorizontalPodAutoscaler, scheduleStatus map[string]*replicaNums) error {
+func (a *HpaAdapter) updateClusterListOnTargetObject(fedHpa *autoscalingv2beta1.HorizontalPodAutoscaler, scheduleStatus map[string]*replicaNums) error {
 	if len(fedHpa.Spec.ScaleTargetRef.Kind) <= 0 || len(fedHpa.Spec.ScaleTargetRef.Name) <= 0 {
 		// nothing to do
 		glog.Infof("Fed HPA: cluster list update on target object skipped for target obj: %s, kind: %s", fedHpa.Spec.ScaleTargetRef.Name, fedHpa.Spec.ScaleTargetRef.Kind)
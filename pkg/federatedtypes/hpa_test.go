@@ -19,10 +19,12 @@ package federatedtypes
 import (
 	"testing"
 
-	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2beta1 "k8s.io/api/autoscaling/v2beta1"
 	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	pkgruntime "k8s.io/apimachinery/pkg/runtime"
+	fedapi "k8s.io/federation/apis/federation"
 	. "k8s.io/federation/pkg/federation-controller/util/test"
 
 	"github.com/stretchr/testify/assert"
@@ -36,7 +38,7 @@ type replicas struct {
 func TestGetHpaScheduleState(t *testing.T) {
 	defaultFedHpa := newHpaWithReplicas(NewInt32(1), NewInt32(70), 10)
 	testCases := map[string]struct {
-		fedHpa           *autoscalingv1.HorizontalPodAutoscaler
+		fedHpa           *autoscalingv2beta1.HorizontalPodAutoscaler
 		localHpas        map[string]pkgruntime.Object
 		expectedReplicas map[string]*replicas
 	}{
@@ -198,7 +200,7 @@ func TestGetHpaScheduleState(t *testing.T) {
 			if testCase.fedHpa == nil {
 				testCase.fedHpa = defaultFedHpa
 			}
-			scheduledState := adapter.getHpaScheduleState(testCase.fedHpa, testCase.localHpas)
+			scheduledState := adapter.getHpaScheduleState(testCase.fedHpa, nil, testCase.localHpas)
 			checkClusterConditions(t, testCase.fedHpa, scheduledState)
 			if testCase.expectedReplicas != nil {
 				for cluster, replicas := range testCase.expectedReplicas {
@@ -211,10 +213,72 @@ func TestGetHpaScheduleState(t *testing.T) {
 	}
 }
 
-func updateHpaStatus(hpa *autoscalingv1.HorizontalPodAutoscaler, currentUtilisation *int32, current, desired int32, scaleable bool) *autoscalingv1.HorizontalPodAutoscaler {
+func TestUpdateStatus(t *testing.T) {
+	fedHpa := newHpaWithReplicas(NewInt32(1), NewInt32(70), 10)
+	fedHpa.Generation = 2
+
+	queueLength := func(value string) autoscalingv2beta1.MetricStatus {
+		return autoscalingv2beta1.MetricStatus{
+			Type: autoscalingv2beta1.ObjectMetricSourceType,
+			Object: &autoscalingv2beta1.ObjectMetricStatus{
+				Target:       autoscalingv2beta1.CrossVersionObjectReference{Kind: "Service", Name: "queue"},
+				MetricName:   "queue_length",
+				CurrentValue: resource.MustParse(value),
+			},
+		}
+	}
+	requestsPerPod := func(value string) autoscalingv2beta1.MetricStatus {
+		return autoscalingv2beta1.MetricStatus{
+			Type: autoscalingv2beta1.PodsMetricSourceType,
+			Pods: &autoscalingv2beta1.PodsMetricStatus{
+				MetricName:          "requests",
+				CurrentAverageValue: resource.MustParse(value),
+			},
+		}
+	}
+	hpa1 := updateHpaStatus(newHpaWithReplicas(NewInt32(1), NewInt32(70), 5), NewInt32(40), 1, 1, true)
+	hpa1.Status.CurrentMetrics = append(hpa1.Status.CurrentMetrics, queueLength("10"), requestsPerPod("100"))
+	hpa2 := updateHpaStatus(newHpaWithReplicas(NewInt32(1), NewInt32(70), 5), NewInt32(80), 3, 4, true)
+	hpa2.Status.CurrentMetrics = append(hpa2.Status.CurrentMetrics, queueLength("20"), requestsPerPod("300"))
+	// Replicas that are not running yet do not dilute the averages.
+	hpa3 := updateHpaStatus(newHpaWithReplicas(NewInt32(1), NewInt32(70), 5), NewInt32(0), 0, 1, true)
+
+	fedStatus := hpaFederatedStatus{metrics: newHpaMetrics()}
+	for _, hpa := range []*autoscalingv2beta1.HorizontalPodAutoscaler{hpa1, hpa2, hpa3} {
+		fedStatus.metrics.add(hpa.Status.CurrentMetrics, hpa.Status.CurrentReplicas)
+		fedStatus.currentReplicas += hpa.Status.CurrentReplicas
+		fedStatus.desiredReplicas += hpa.Status.DesiredReplicas
+	}
+
+	needUpdate, status := updateStatus(fedHpa, fedStatus)
+	assert.True(t, needUpdate)
+	assert.Equal(t, int64(2), *status.ObservedGeneration)
+	assert.Equal(t, int32(4), status.CurrentReplicas)
+	assert.Equal(t, int32(6), status.DesiredReplicas)
+	if assert.Len(t, status.CurrentMetrics, 3) {
+		assert.Equal(t, int64(30), status.CurrentMetrics[0].Object.CurrentValue.Value())
+		assert.Equal(t, int64(250), status.CurrentMetrics[1].Pods.CurrentAverageValue.Value())
+		assert.Equal(t, int32(70), *status.CurrentMetrics[2].Resource.CurrentAverageUtilization)
+	}
+
+	fedHpa.Status = status
+	needUpdate, _ = updateStatus(fedHpa, fedStatus)
+	assert.False(t, needUpdate)
+}
+
+func updateHpaStatus(hpa *autoscalingv2beta1.HorizontalPodAutoscaler, currentUtilisation *int32, current, desired int32, scaleable bool) *autoscalingv2beta1.HorizontalPodAutoscaler {
 	hpa.Status.CurrentReplicas = current
 	hpa.Status.DesiredReplicas = desired
-	hpa.Status.CurrentCPUUtilizationPercentage = currentUtilisation
+	hpa.Status.CurrentMetrics = nil
+	if currentUtilisation != nil {
+		hpa.Status.CurrentMetrics = []autoscalingv2beta1.MetricStatus{{
+			Type: autoscalingv2beta1.ResourceMetricSourceType,
+			Resource: &autoscalingv2beta1.ResourceMetricStatus{
+				Name:                      apiv1.ResourceCPU,
+				CurrentAverageUtilization: currentUtilisation,
+			},
+		}}
+	}
 	now := metav1.Now()
 	scaledTime := now
 	if scaleable {
@@ -225,7 +289,7 @@ func updateHpaStatus(hpa *autoscalingv1.HorizontalPodAutoscaler, currentUtilisat
 	return hpa
 }
 
-func checkClusterConditions(t *testing.T, fedHpa *autoscalingv1.HorizontalPodAutoscaler, scheduled map[string]*replicaNums) {
+func checkClusterConditions(t *testing.T, fedHpa *autoscalingv2beta1.HorizontalPodAutoscaler, scheduled map[string]*replicaNums) {
 	minTotal := int32(0)
 	maxTotal := int32(0)
 	for _, replicas := range scheduled {
@@ -244,21 +308,137 @@ func checkClusterConditions(t *testing.T, fedHpa *autoscalingv1.HorizontalPodAut
 	})
 }
 
-func newHpaWithReplicas(min, targetUtilisation *int32, max int32) *autoscalingv1.HorizontalPodAutoscaler {
-	return &autoscalingv1.HorizontalPodAutoscaler{
+func newHpaWithReplicas(min, targetUtilisation *int32, max int32) *autoscalingv2beta1.HorizontalPodAutoscaler {
+	return &autoscalingv2beta1.HorizontalPodAutoscaler{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "myhpa",
 			Namespace: apiv1.NamespaceDefault,
 			SelfLink:  "/api/mylink",
 		},
-		Spec: autoscalingv1.HorizontalPodAutoscalerSpec{
-			ScaleTargetRef: autoscalingv1.CrossVersionObjectReference{
+		Spec: autoscalingv2beta1.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2beta1.CrossVersionObjectReference{
 				Kind: "HorizontalPodAutoscaler",
 				Name: "target-",
 			},
-			MinReplicas:                    min,
-			MaxReplicas:                    max,
-			TargetCPUUtilizationPercentage: targetUtilisation,
+			MinReplicas: min,
+			MaxReplicas: max,
+			Metrics: []autoscalingv2beta1.MetricSpec{{
+				Type: autoscalingv2beta1.ResourceMetricSourceType,
+				Resource: &autoscalingv2beta1.ResourceMetricSource{
+					Name:                     apiv1.ResourceCPU,
+					TargetAverageUtilization: targetUtilisation,
+				},
+			}},
+		},
+	}
+}
+
+func TestScheduleGlobally(t *testing.T) {
+	// Both local hpas target 50% cpu utilization. c1 runs 8 replicas at 100%,
+	// c2 runs 4 replicas at 25%, the federation as a whole is at 150% of
+	// the target. By demand, c1 should get 17 of the 20 max replicas.
+	newLocalHpas := func() map[string]pkgruntime.Object {
+		return map[string]pkgruntime.Object{
+			"c1": updateHpaStatus(newHpaWithReplicas(NewInt32(2), NewInt32(50), 10), NewInt32(100), 8, 8, false),
+			"c2": updateHpaStatus(newHpaWithReplicas(NewInt32(2), NewInt32(50), 10), NewInt32(25), 4, 4, false),
+			"c3": nil,
+		}
+	}
+	newScheduled := func(min int32) map[string]*replicaNums {
+		return map[string]*replicaNums{
+			"c1": {min: min, max: 10},
+			"c2": {min: min, max: 10},
+			"c3": nil,
+		}
+	}
+
+	testCases := map[string]struct {
+		prefs    *fedapi.HpaPreferences
+		min      int32
+		passes   int
+		expected map[string]*replicaNums
+	}{
+		"Half the way to the shares is moved in a pass by default": {
+			prefs:  &fedapi.HpaPreferences{Mode: fedapi.HpaScalingModeGlobal},
+			passes: 1,
+			expected: map[string]*replicaNums{
+				"c1": {min: 2, max: 13},
+				"c2": {min: 2, max: 7},
+			},
+		},
+		"Repeated passes converge to the shares": {
+			prefs:  &fedapi.HpaPreferences{Mode: fedapi.HpaScalingModeGlobal},
+			passes: 10,
+			expected: map[string]*replicaNums{
+				"c1": {min: 2, max: 17},
+				"c2": {min: 2, max: 3},
+			},
+		},
+		"Min follows max": {
+			prefs:  &fedapi.HpaPreferences{Mode: fedapi.HpaScalingModeGlobal, DampingPercent: NewInt64(100)},
+			min:    4,
+			passes: 1,
+			expected: map[string]*replicaNums{
+				"c1": {min: 5, max: 17},
+				"c2": {min: 3, max: 3},
+			},
+		},
+		"Moves are capped per interval": {
+			prefs:  &fedapi.HpaPreferences{Mode: fedapi.HpaScalingModeGlobal, MaxMovedReplicas: NewInt64(2)},
+			passes: 3,
+			expected: map[string]*replicaNums{
+				"c1": {min: 2, max: 12},
+				"c2": {min: 2, max: 8},
+			},
+		},
+		"Clusters within tolerance keep their bounds": {
+			prefs:  &fedapi.HpaPreferences{Mode: fedapi.HpaScalingModeGlobal, TolerancePercent: NewInt64(100)},
+			passes: 1,
+			expected: map[string]*replicaNums{
+				"c1": {min: 2, max: 10},
+				"c2": {min: 2, max: 10},
+			},
 		},
 	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			adapter := &HpaAdapter{moves: newRebalanceTracker()}
+			localHpas := newLocalHpas()
+			min := testCase.min
+			if min == 0 {
+				min = 2
+			}
+			scheduled := newScheduled(min)
+			for i := 0; i < testCase.passes; i++ {
+				adapter.scheduleGlobally("default/myhpa", testCase.prefs, localHpas, scheduled)
+				for cluster, replicas := range scheduled {
+					if replicas != nil {
+						localHpas[cluster].(*autoscalingv2beta1.HorizontalPodAutoscaler).Spec.MaxReplicas = replicas.max
+					}
+				}
+			}
+			for cluster, replicas := range testCase.expected {
+				assert.Equal(t, *replicas, *scheduled[cluster], "cluster %s", cluster)
+			}
+			assert.Nil(t, scheduled["c3"])
+		})
+	}
+}
+
+func TestGetHpaPreferences(t *testing.T) {
+	hpa := newHpaWithReplicas(NewInt32(1), NewInt32(70), 10)
+	prefs, err := getHpaPreferences(hpa)
+	assert.NoError(t, err)
+	assert.Nil(t, prefs)
+
+	hpa.Annotations = map[string]string{FedHpaPreferencesAnnotation: `{"mode": "Global", "dampingPercent": 30}`}
+	prefs, err = getHpaPreferences(hpa)
+	assert.NoError(t, err)
+	assert.True(t, isGlobalMode(prefs))
+	assert.Equal(t, int64(30), *prefs.DampingPercent)
+
+	hpa.Annotations[FedHpaPreferencesAnnotation] = `{"mode": "Everywhere"}`
+	_, err = getHpaPreferences(hpa)
+	assert.Error(t, err)
 }
@@ -0,0 +1,109 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package federatedtypes
+
+import (
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2beta1 "k8s.io/api/autoscaling/v2beta1"
+	pkgruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/kubernetes/pkg/api/legacyscheme"
+	"k8s.io/kubernetes/pkg/apis/autoscaling"
+	_ "k8s.io/kubernetes/pkg/apis/autoscaling/install"
+
+	"github.com/golang/glog"
+)
+
+// The hpa adapter works on autoscaling/v2beta1 objects, but reads and writes
+// them through autoscaling/v1, which the federation API server and all the
+// member clusters serve. autoscaling/v1 keeps the metrics other than cpu
+// utilization in annotations, so no metric is lost in the conversions. The cpu
+// utilization metric is always converted back last.
+
+// hpaToV1 converts a v2beta1 hpa to the v1 hpa sent to an API server.
+func hpaToV1(hpa *autoscalingv2beta1.HorizontalPodAutoscaler) (*autoscalingv1.HorizontalPodAutoscaler, error) {
+	internal := &autoscaling.HorizontalPodAutoscaler{}
+	if err := legacyscheme.Scheme.Convert(hpa.DeepCopy(), internal, nil); err != nil {
+		return nil, err
+	}
+	out := &autoscalingv1.HorizontalPodAutoscaler{}
+	if err := legacyscheme.Scheme.Convert(internal, out, nil); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// hpaFromV1 converts a v1 hpa returned by an API server to a v2beta1 hpa.
+func hpaFromV1(hpa *autoscalingv1.HorizontalPodAutoscaler) (*autoscalingv2beta1.HorizontalPodAutoscaler, error) {
+	internal := &autoscaling.HorizontalPodAutoscaler{}
+	if err := legacyscheme.Scheme.Convert(hpa.DeepCopy(), internal, nil); err != nil {
+		return nil, err
+	}
+	out := &autoscalingv2beta1.HorizontalPodAutoscaler{}
+	if err := legacyscheme.Scheme.Convert(internal, out, nil); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// hpaResultFromV1 converts the result of a request for a v1 hpa.
+func hpaResultFromV1(hpa *autoscalingv1.HorizontalPodAutoscaler, err error) (pkgruntime.Object, error) {
+	if err != nil {
+		return nil, err
+	}
+	return hpaFromV1(hpa)
+}
+
+// hpaListFromV1 converts the result of a request for a list of v1 hpas.
+func hpaListFromV1(list *autoscalingv1.HorizontalPodAutoscalerList, err error) (pkgruntime.Object, error) {
+	if err != nil {
+		return nil, err
+	}
+	out := &autoscalingv2beta1.HorizontalPodAutoscalerList{
+		ListMeta: list.ListMeta,
+		Items:    make([]autoscalingv2beta1.HorizontalPodAutoscaler, 0, len(list.Items)),
+	}
+	for i := range list.Items {
+		hpa, err := hpaFromV1(&list.Items[i])
+		if err != nil {
+			return nil, err
+		}
+		out.Items = append(out.Items, *hpa)
+	}
+	return out, nil
+}
+
+// hpaWatchFromV1 converts the hpas of the events of a watch on v1 hpas.
+// Events with hpas that can not be converted are dropped.
+func hpaWatchFromV1(w watch.Interface, err error) (watch.Interface, error) {
+	if err != nil {
+		return nil, err
+	}
+	return watch.Filter(w, func(event watch.Event) (watch.Event, bool) {
+		hpa, ok := event.Object.(*autoscalingv1.HorizontalPodAutoscaler)
+		if !ok {
+			return event, true
+		}
+		converted, err := hpaFromV1(hpa)
+		if err != nil {
+			glog.Errorf("Failed to convert hpa %s/%s: %v", hpa.Namespace, hpa.Name, err)
+			return event, false
+		}
+		event.Object = converted
+		return event, true
+	}), nil
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package federatedtypes
+
+import (
+	"testing"
+
+	autoscalingv2beta1 "k8s.io/api/autoscaling/v2beta1"
+	apiv1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/diff"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/kubernetes/pkg/api/legacyscheme"
+	"k8s.io/kubernetes/pkg/apis/autoscaling"
+	autoscalingvalidation "k8s.io/kubernetes/pkg/apis/autoscaling/validation"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newMultiMetricHpa() *autoscalingv2beta1.HorizontalPodAutoscaler {
+	min := int32(2)
+	cpu := int32(70)
+	return &autoscalingv2beta1.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{Name: "myhpa", Namespace: "ns", Annotations: map[string]string{"a": "b"}},
+		Spec: autoscalingv2beta1.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2beta1.CrossVersionObjectReference{Kind: "ReplicaSet", Name: "myrs"},
+			MinReplicas:    &min,
+			MaxReplicas:    10,
+			// autoscaling/v1 lists the cpu utilization after the other metrics.
+			Metrics: []autoscalingv2beta1.MetricSpec{
+				{
+					Type: autoscalingv2beta1.PodsMetricSourceType,
+					Pods: &autoscalingv2beta1.PodsMetricSource{MetricName: "queue-length", TargetAverageValue: resource.MustParse("10")},
+				},
+				{
+					Type:     autoscalingv2beta1.ResourceMetricSourceType,
+					Resource: &autoscalingv2beta1.ResourceMetricSource{Name: apiv1.ResourceCPU, TargetAverageUtilization: &cpu},
+				},
+			},
+		},
+		Status: autoscalingv2beta1.HorizontalPodAutoscalerStatus{
+			CurrentReplicas: 3,
+			DesiredReplicas: 4,
+			CurrentMetrics: []autoscalingv2beta1.MetricStatus{
+				{
+					Type: autoscalingv2beta1.PodsMetricSourceType,
+					Pods: &autoscalingv2beta1.PodsMetricStatus{MetricName: "queue-length", CurrentAverageValue: resource.MustParse("12")},
+				},
+			},
+		},
+	}
+}
+
+func TestHpaV1RoundTrip(t *testing.T) {
+	hpa := newMultiMetricHpa()
+
+	v1, err := hpaToV1(hpa)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert.Equal(t, int32(70), *v1.Spec.TargetCPUUtilizationPercentage)
+	assert.Equal(t, "b", v1.Annotations["a"])
+
+	out, err := hpaFromV1(v1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !apiequality.Semantic.DeepEqual(hpa, out) {
+		t.Errorf("hpa changed in a round trip through autoscaling/v1: %s", diff.ObjectReflectDiff(hpa, out))
+	}
+	assert.Equal(t, newMultiMetricHpa(), hpa, "the converted hpa must not be modified")
+}
+
+func TestHpaWatchFromV1(t *testing.T) {
+	v1, err := hpaToV1(newMultiMetricHpa())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	fakeWatch := watch.NewFakeWithChanSize(1, false)
+	w, err := hpaWatchFromV1(fakeWatch, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	fakeWatch.Add(v1)
+	event := <-w.ResultChan()
+	hpa, ok := event.Object.(*autoscalingv2beta1.HorizontalPodAutoscaler)
+	if !ok {
+		t.Fatalf("expected a v2beta1 hpa, got %T", event.Object)
+	}
+	assert.Equal(t, 2, len(hpa.Spec.Metrics))
+	w.Stop()
+}
+
+func TestHpaExternalMetricsRejected(t *testing.T) {
+	hpa := newMultiMetricHpa()
+	hpa.Spec.Metrics = append(hpa.Spec.Metrics, autoscalingv2beta1.MetricSpec{Type: "External"})
+	internal := &autoscaling.HorizontalPodAutoscaler{}
+	if err := legacyscheme.Scheme.Convert(hpa, internal, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	errs := autoscalingvalidation.ValidateHorizontalPodAutoscaler(internal)
+	if len(errs) == 0 || errs[0].Type != field.ErrorTypeNotSupported || errs[0].Field != "spec.metrics[2].type" {
+		t.Errorf("expected the external metric to be rejected as not supported, got %v", errs)
+	}
+}
@@ -0,0 +1,306 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package federatedtypes
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+
+	autoscalingv2beta1 "k8s.io/api/autoscaling/v2beta1"
+	pkgruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	fedapi "k8s.io/federation/apis/federation"
+
+	"github.com/golang/glog"
+)
+
+const (
+	// FedHpaPreferencesAnnotation holds the json-serialized
+	// HpaPreferences of a federated hpa.
+	FedHpaPreferencesAnnotation = "federation.kubernetes.io/hpa-preferences"
+
+	defaultHpaDampingPercent   = int64(50)
+	defaultHpaTolerancePercent = int64(10)
+)
+
+// getHpaPreferences reads the preferences from the annotation of the federated
+// hpa. Returns nil if the annotation is not set.
+func getHpaPreferences(hpa *autoscalingv2beta1.HorizontalPodAutoscaler) (*fedapi.HpaPreferences, error) {
+	prefString, found := hpa.Annotations[FedHpaPreferencesAnnotation]
+	if !found {
+		return nil, nil
+	}
+	var prefs fedapi.HpaPreferences
+	if err := json.Unmarshal([]byte(prefString), &prefs); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %v", FedHpaPreferencesAnnotation, err)
+	}
+	switch prefs.Mode {
+	case "", fedapi.HpaScalingModeLocal, fedapi.HpaScalingModeGlobal:
+	default:
+		return nil, fmt.Errorf("invalid %s annotation: unknown mode %q", FedHpaPreferencesAnnotation, prefs.Mode)
+	}
+	return &prefs, nil
+}
+
+func isGlobalMode(prefs *fedapi.HpaPreferences) bool {
+	return prefs != nil && prefs.Mode == fedapi.HpaScalingModeGlobal
+}
+
+// keepPristine removes the clusters whose local hpas have already scaled from
+// the given list. In global mode the bounds of such hpas are moved by
+// scheduleGlobally rather than traded by local signals, while newly created
+// hpas may still hand their bounds to clusters joining the federation.
+func keepPristine(clusters sets.String, currentObjs map[string]pkgruntime.Object) {
+	for _, cluster := range clusters.List() {
+		if !isPristine(currentObjs[cluster].(*autoscalingv2beta1.HorizontalPodAutoscaler)) {
+			clusters.Delete(cluster)
+		}
+	}
+}
+
+// hpaUtilization returns the ratio between the current value and the target of
+// the most utilized metric of the hpa, the one its local controller scales on.
+func hpaUtilization(hpa *autoscalingv2beta1.HorizontalPodAutoscaler) (float64, bool) {
+	current := make(map[string]*autoscalingv2beta1.MetricStatus, len(hpa.Status.CurrentMetrics))
+	for i := range hpa.Status.CurrentMetrics {
+		if key, ok := metricKey(&hpa.Status.CurrentMetrics[i]); ok {
+			current[key] = &hpa.Status.CurrentMetrics[i]
+		}
+	}
+
+	utilization, found := 0.0, false
+	for i := range hpa.Spec.Metrics {
+		spec := &hpa.Spec.Metrics[i]
+		var ratio float64
+		var ok bool
+		switch {
+		case spec.Type == autoscalingv2beta1.ObjectMetricSourceType && spec.Object != nil:
+			status := current[objectMetricKey(spec.Object.Target, spec.Object.MetricName)]
+			if status != nil {
+				ratio, ok = milliRatio(status.Object.CurrentValue.MilliValue(), spec.Object.TargetValue.MilliValue())
+			}
+		case spec.Type == autoscalingv2beta1.PodsMetricSourceType && spec.Pods != nil:
+			status := current[podsMetricKey(spec.Pods.MetricName)]
+			if status != nil {
+				ratio, ok = milliRatio(status.Pods.CurrentAverageValue.MilliValue(), spec.Pods.TargetAverageValue.MilliValue())
+			}
+		case spec.Type == autoscalingv2beta1.ResourceMetricSourceType && spec.Resource != nil:
+			status := current[resourceMetricKey(spec.Resource.Name)]
+			switch {
+			case status == nil:
+			case spec.Resource.TargetAverageUtilization != nil && status.Resource.CurrentAverageUtilization != nil:
+				ratio, ok = milliRatio(int64(*status.Resource.CurrentAverageUtilization), int64(*spec.Resource.TargetAverageUtilization))
+			case spec.Resource.TargetAverageValue != nil:
+				ratio, ok = milliRatio(status.Resource.CurrentAverageValue.MilliValue(), spec.Resource.TargetAverageValue.MilliValue())
+			}
+		}
+		if ok && (!found || ratio > utilization) {
+			utilization, found = ratio, true
+		}
+	}
+	return utilization, found
+}
+
+func milliRatio(current, target int64) (float64, bool) {
+	if target <= 0 {
+		return 0, false
+	}
+	return float64(current) / float64(target), true
+}
+
+// clusterLoad is the demand of a cluster, in replicas needed to bring its
+// local hpa to target.
+type clusterLoad struct {
+	cluster     string
+	utilization float64
+	demand      float64
+}
+
+// scheduleGlobally moves the max replicas of the local hpas toward clusters that
+// are more utilized than the federation as a whole and away from clusters that
+// are less utilized, so that busy clusters get headroom before their local hpas
+// saturate. Each cluster moves DampingPercent of the way to its share of the
+// max replicas, proportional to its demand, in a single pass. Min replicas
+// follow the max replicas. Totals are kept.
+func (a *HpaAdapter) scheduleGlobally(key string, prefs *fedapi.HpaPreferences, currentObjs map[string]pkgruntime.Object, scheduled map[string]*replicaNums) {
+	var loads []*clusterLoad
+	totalReplicas, totalDemand := 0.0, 0.0
+	for cluster, obj := range currentObjs {
+		if obj == nil || scheduled[cluster] == nil {
+			continue
+		}
+		hpa := obj.(*autoscalingv2beta1.HorizontalPodAutoscaler)
+		utilization, ok := hpaUtilization(hpa)
+		if !ok || hpa.Status.CurrentReplicas <= 0 {
+			continue
+		}
+		replicas := float64(hpa.Status.CurrentReplicas)
+		loads = append(loads, &clusterLoad{cluster: cluster, utilization: utilization, demand: replicas * utilization})
+		totalReplicas += replicas
+		totalDemand += replicas * utilization
+	}
+	if len(loads) < 2 || totalDemand <= 0 {
+		return
+	}
+	// Hottest clusters first.
+	sort.Slice(loads, func(i, j int) bool {
+		if loads[i].utilization != loads[j].utilization {
+			return loads[i].utilization > loads[j].utilization
+		}
+		return loads[i].cluster < loads[j].cluster
+	})
+
+	damping := defaultHpaDampingPercent
+	if prefs.DampingPercent != nil {
+		damping = *prefs.DampingPercent
+	}
+	tolerance := defaultHpaTolerancePercent
+	if prefs.TolerancePercent != nil {
+		tolerance = *prefs.TolerancePercent
+	}
+	utilization := totalDemand / totalReplicas
+	glog.V(4).Infof("Fed HPA %s: federation-wide utilization %.2f", key, utilization)
+
+	totalMax := int32(0)
+	for _, load := range loads {
+		totalMax += scheduled[load.cluster].max
+	}
+	shares := proportionalShares(totalMax, loads)
+
+	// Replicas each cluster should gain (positive) or give up (negative).
+	steps := make(map[string]int32, len(loads))
+	gain, loss := int32(0), int32(0)
+	for _, load := range loads {
+		if math.Abs(load.utilization-utilization) <= utilization*float64(tolerance)/100 {
+			continue
+		}
+		replicas := scheduled[load.cluster]
+		step := int32(float64(shares[load.cluster]-replicas.max) * float64(damping) / 100)
+		if step == 0 && shares[load.cluster] != replicas.max {
+			// Always make progress toward the share.
+			step = 1
+			if shares[load.cluster] < replicas.max {
+				step = -1
+			}
+		}
+		// Every cluster keeps at least 1 replica.
+		if replicas.max+step < 1 {
+			step = 1 - replicas.max
+		}
+		steps[load.cluster] = step
+		if step > 0 {
+			gain += step
+		} else {
+			loss -= step
+		}
+	}
+
+	move := func(available int64) int64 {
+		moved := gain
+		if loss < moved {
+			moved = loss
+		}
+		if available >= 0 && int64(moved) > available {
+			moved = int32(available)
+		}
+		// Give to the hottest clusters and take from the coldest first.
+		toGain, toLose := moved, moved
+		for i := range loads {
+			if step := steps[loads[i].cluster]; step > 0 && toGain > 0 {
+				step = minInt32(step, toGain)
+				scheduled[loads[i].cluster].max += step
+				toGain -= step
+			}
+			cold := loads[len(loads)-1-i].cluster
+			if step := steps[cold]; step < 0 && toLose > 0 {
+				step = minInt32(-step, toLose)
+				scheduled[cold].max -= step
+				toLose -= step
+			}
+		}
+		return int64(moved)
+	}
+	if prefs.MaxMovedReplicas == nil {
+		move(-1)
+	} else {
+		a.moves.spend(key, *prefs.MaxMovedReplicas, prefs.Interval.Duration, move)
+	}
+
+	// Min replicas that do not fit under the new max go to the hottest
+	// clusters that have room for them.
+	excessMin := int32(0)
+	for _, load := range loads {
+		replicas := scheduled[load.cluster]
+		if replicas.min > replicas.max {
+			excessMin += replicas.min - replicas.max
+			replicas.min = replicas.max
+		}
+	}
+	for _, load := range loads {
+		if excessMin == 0 {
+			break
+		}
+		replicas := scheduled[load.cluster]
+		room := minInt32(replicas.max-replicas.min, excessMin)
+		replicas.min += room
+		excessMin -= room
+	}
+}
+
+// proportionalShares splits total among the clusters in proportion to their
+// demand, every cluster getting at least 1. Remainders go to the clusters with
+// the largest fractional shares.
+func proportionalShares(total int32, loads []*clusterLoad) map[string]int32 {
+	shares := make(map[string]int32, len(loads))
+	remaining := total - int32(len(loads))
+	if remaining < 0 {
+		remaining = 0
+	}
+	totalDemand := 0.0
+	for _, load := range loads {
+		totalDemand += load.demand
+	}
+
+	type fraction struct {
+		cluster string
+		value   float64
+	}
+	fractions := make([]fraction, 0, len(loads))
+	assigned := int32(0)
+	for _, load := range loads {
+		exact := float64(remaining) * load.demand / totalDemand
+		whole := int32(exact)
+		shares[load.cluster] = 1 + whole
+		assigned += whole
+		fractions = append(fractions, fraction{cluster: load.cluster, value: exact - float64(whole)})
+	}
+	sort.SliceStable(fractions, func(i, j int) bool { return fractions[i].value > fractions[j].value })
+	for i := 0; assigned < remaining; i++ {
+		shares[fractions[i%len(fractions)].cluster]++
+		assigned++
+	}
+	return shares
+}
+
+func minInt32(a, b int32) int32 {
+	if a < b {
+		return a
+	}
+	return b
+}
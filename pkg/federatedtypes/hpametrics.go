@@ -0,0 +1,153 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package federatedtypes
+
+import (
+	"fmt"
+	"sort"
+
+	autoscalingv2beta1 "k8s.io/api/autoscaling/v2beta1"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// hpaMetrics aggregates the current metric values reported by the local hpas
+// into the values reported in the status of the federated hpa.
+type hpaMetrics struct {
+	metrics map[string]*metricAggregate
+}
+
+type metricAggregate struct {
+	// The first status seen for the metric, its values are replaced on output.
+	status autoscalingv2beta1.MetricStatus
+	// Sum of the values in milli units. Values averaged over pods are
+	// weighted by the current replicas of the cluster.
+	value int64
+	// Sum of the utilization percentages weighted by current replicas.
+	utilization    int64
+	hasUtilization bool
+	// Current replicas of the clusters that reported a value averaged over pods.
+	replicas int64
+}
+
+func newHpaMetrics() *hpaMetrics {
+	return &hpaMetrics{metrics: make(map[string]*metricAggregate)}
+}
+
+// metricKey identifies a metric across clusters. Metrics without a source
+// matching their type can not be aggregated.
+func metricKey(metric *autoscalingv2beta1.MetricStatus) (string, bool) {
+	switch {
+	case metric.Type == autoscalingv2beta1.ObjectMetricSourceType && metric.Object != nil:
+		return objectMetricKey(metric.Object.Target, metric.Object.MetricName), true
+	case metric.Type == autoscalingv2beta1.PodsMetricSourceType && metric.Pods != nil:
+		return podsMetricKey(metric.Pods.MetricName), true
+	case metric.Type == autoscalingv2beta1.ResourceMetricSourceType && metric.Resource != nil:
+		return resourceMetricKey(metric.Resource.Name), true
+	}
+	return "", false
+}
+
+func objectMetricKey(target autoscalingv2beta1.CrossVersionObjectReference, metricName string) string {
+	return fmt.Sprintf("%s/%s/%s/%s/%s", autoscalingv2beta1.ObjectMetricSourceType, target.APIVersion, target.Kind, target.Name, metricName)
+}
+
+func podsMetricKey(metricName string) string {
+	return fmt.Sprintf("%s/%s", autoscalingv2beta1.PodsMetricSourceType, metricName)
+}
+
+func resourceMetricKey(name apiv1.ResourceName) string {
+	return fmt.Sprintf("%s/%s", autoscalingv2beta1.ResourceMetricSourceType, name)
+}
+
+// add accounts for the current metrics of a local hpa running the given
+// number of replicas.
+func (m *hpaMetrics) add(metrics []autoscalingv2beta1.MetricStatus, replicas int32) {
+	for i := range metrics {
+		metric := &metrics[i]
+		key, ok := metricKey(metric)
+		if !ok {
+			continue
+		}
+		aggregate := m.metrics[key]
+		if aggregate == nil {
+			aggregate = &metricAggregate{status: *metric.DeepCopy()}
+			m.metrics[key] = aggregate
+		}
+
+		switch metric.Type {
+		case autoscalingv2beta1.ObjectMetricSourceType:
+			// Every cluster describes its own copy of the target object,
+			// e.g. the ingress in front of the local replicas, so the
+			// federated value is the total.
+			aggregate.value += metric.Object.CurrentValue.MilliValue()
+		case autoscalingv2beta1.PodsMetricSourceType:
+			if replicas <= 0 {
+				continue
+			}
+			aggregate.value += metric.Pods.CurrentAverageValue.MilliValue() * int64(replicas)
+			aggregate.replicas += int64(replicas)
+		case autoscalingv2beta1.ResourceMetricSourceType:
+			if replicas <= 0 {
+				continue
+			}
+			aggregate.value += metric.Resource.CurrentAverageValue.MilliValue() * int64(replicas)
+			if metric.Resource.CurrentAverageUtilization != nil {
+				aggregate.utilization += int64(*metric.Resource.CurrentAverageUtilization) * int64(replicas)
+				aggregate.hasUtilization = true
+			}
+			aggregate.replicas += int64(replicas)
+		}
+	}
+}
+
+// currentMetrics returns the aggregated metrics, sorted by type and name.
+// Averages are taken over the pods of all clusters.
+func (m *hpaMetrics) currentMetrics() []autoscalingv2beta1.MetricStatus {
+	keys := make([]string, 0, len(m.metrics))
+	for key := range m.metrics {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var statuses []autoscalingv2beta1.MetricStatus
+	for _, key := range keys {
+		aggregate := m.metrics[key]
+		status := *aggregate.status.DeepCopy()
+		switch status.Type {
+		case autoscalingv2beta1.ObjectMetricSourceType:
+			status.Object.CurrentValue = *resource.NewMilliQuantity(aggregate.value, status.Object.CurrentValue.Format)
+		case autoscalingv2beta1.PodsMetricSourceType:
+			if aggregate.replicas == 0 {
+				continue
+			}
+			status.Pods.CurrentAverageValue = *resource.NewMilliQuantity(aggregate.value/aggregate.replicas, status.Pods.CurrentAverageValue.Format)
+		case autoscalingv2beta1.ResourceMetricSourceType:
+			if aggregate.replicas == 0 {
+				continue
+			}
+			status.Resource.CurrentAverageValue = *resource.NewMilliQuantity(aggregate.value/aggregate.replicas, status.Resource.CurrentAverageValue.Format)
+			status.Resource.CurrentAverageUtilization = nil
+			if aggregate.hasUtilization {
+				utilization := int32(aggregate.utilization / aggregate.replicas)
+				status.Resource.CurrentAverageUtilization = &utilization
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
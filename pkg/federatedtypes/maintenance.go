@@ -0,0 +1,125 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package federatedtypes
+
+import (
+	"sort"
+
+	federationapi "k8s.io/federation/apis/federation/v1beta1"
+	fedutil "k8s.io/federation/pkg/federation-controller/util"
+
+	"github.com/golang/glog"
+)
+
+// clusterMaintenance holds the clusters in maintenance among the clusters a
+// workload is scheduled to.
+type clusterMaintenance struct {
+	// cordoned holds the clusters that are cordoned but not drained.
+	cordoned map[string]bool
+	drained  map[string]bool
+}
+
+func newClusterMaintenance(clusters []*federationapi.Cluster) *clusterMaintenance {
+	m := &clusterMaintenance{
+		cordoned: make(map[string]bool),
+		drained:  make(map[string]bool),
+	}
+	for _, cluster := range clusters {
+		if fedutil.IsClusterDrained(cluster) {
+			m.drained[cluster.Name] = true
+		} else if fedutil.IsClusterCordoned(cluster) {
+			m.cordoned[cluster.Name] = true
+		}
+	}
+	return m
+}
+
+func (m *clusterMaintenance) active() bool {
+	return len(m.cordoned) > 0 || len(m.drained) > 0
+}
+
+// limitCapacity keeps the planner from giving cordoned clusters more replicas
+// than they are currently asked to run.
+func (m *clusterMaintenance) limitCapacity(estimatedCapacity map[string]int64, specReplicas map[string]int64) {
+	for clusterName := range m.cordoned {
+		if capacity, found := estimatedCapacity[clusterName]; !found || capacity > specReplicas[clusterName] {
+			estimatedCapacity[clusterName] = specReplicas[clusterName]
+		}
+	}
+}
+
+// feasible removes the drained clusters from the clusters the replicas are
+// planned for.
+func (m *clusterMaintenance) feasible(clusterNames []string) []string {
+	feasible := make([]string, 0, len(clusterNames))
+	for _, clusterName := range clusterNames {
+		if !m.drained[clusterName] {
+			feasible = append(feasible, clusterName)
+		}
+	}
+	return feasible
+}
+
+// apply adjusts the planned replicas to the maintenance of the clusters and
+// returns whether drained clusters still run replicas. Replicas overflowing
+// into cordoned clusters are dropped. Drained clusters give up their replicas
+// only as fast as replicas become ready in the other clusters, so that the
+// workload keeps running the replicas it asks for while it is drained.
+func (m *clusterMaintenance) apply(key string, replicas int64, state map[string]*ReplicaScheduleState, specReplicas map[string]int64, readyReplicas map[string]int64) bool {
+	for clusterName := range m.cordoned {
+		if clusterState, found := state[clusterName]; found && clusterState.replicas > specReplicas[clusterName] {
+			clusterState.replicas = specReplicas[clusterName]
+		}
+	}
+
+	readyElsewhere := int64(0)
+	for clusterName, ready := range readyReplicas {
+		if !m.drained[clusterName] {
+			readyElsewhere += ready
+		}
+	}
+	missing := replicas - readyElsewhere
+
+	drained := make([]string, 0, len(m.drained))
+	for clusterName := range m.drained {
+		drained = append(drained, clusterName)
+	}
+	sort.Strings(drained)
+
+	pending := false
+	for _, clusterName := range drained {
+		clusterState, found := state[clusterName]
+		if !found {
+			continue
+		}
+		keep := specReplicas[clusterName]
+		if missing < keep {
+			keep = missing
+		}
+		if keep < 0 {
+			keep = 0
+		}
+		missing -= keep
+		clusterState.replicas = keep
+		clusterState.isSelected = keep > 0
+		if keep > 0 {
+			glog.V(4).Infof("Cluster %q is drained, keeping %d replicas of %q until they are ready elsewhere", clusterName, keep, key)
+			pending = true
+		}
+	}
+	return pending
+}
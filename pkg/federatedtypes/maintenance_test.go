@@ -0,0 +1,108 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package federatedtypes
+
+import (
+	"testing"
+
+	extensionsv1 "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	federationapi "k8s.io/federation/apis/federation/v1beta1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleWithClusterMaintenance(t *testing.T) {
+	newCluster := func(name string, maintenance federationapi.ClusterMaintenance) *federationapi.Cluster {
+		return &federationapi.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       federationapi.ClusterSpec{Maintenance: maintenance},
+		}
+	}
+	active := newCluster("active", "")
+	cordoned := newCluster("cordoned", federationapi.ClusterCordoned)
+	drained := newCluster("drained", federationapi.ClusterDrained)
+
+	tests := []struct {
+		note            string
+		replicas        int32
+		clusters        []*federationapi.Cluster
+		snapshots       map[string]ClusterReplicaSnapshot
+		expected        map[string]int64
+		expectedPending bool
+	}{
+		{
+			note:     "drained cluster keeps its replicas until they are ready elsewhere",
+			replicas: 6,
+			clusters: []*federationapi.Cluster{active, drained},
+			snapshots: map[string]ClusterReplicaSnapshot{
+				"drained": {Replicas: 6, ReadyReplicas: 6},
+			},
+			expected:        map[string]int64{"active": 6, "drained": 6},
+			expectedPending: true,
+		},
+		{
+			note:     "drained cluster gives up the replicas ready elsewhere",
+			replicas: 6,
+			clusters: []*federationapi.Cluster{active, drained},
+			snapshots: map[string]ClusterReplicaSnapshot{
+				"active":  {Replicas: 6, ReadyReplicas: 4},
+				"drained": {Replicas: 6, ReadyReplicas: 6},
+			},
+			expected:        map[string]int64{"active": 6, "drained": 2},
+			expectedPending: true,
+		},
+		{
+			note:     "drained cluster is emptied once all replicas are ready elsewhere",
+			replicas: 6,
+			clusters: []*federationapi.Cluster{active, drained},
+			snapshots: map[string]ClusterReplicaSnapshot{
+				"active":  {Replicas: 6, ReadyReplicas: 6},
+				"drained": {Replicas: 6, ReadyReplicas: 6},
+			},
+			expected: map[string]int64{"active": 6, "drained": 0},
+		},
+		{
+			note:     "cordoned cluster keeps its replicas without gaining any",
+			replicas: 10,
+			clusters: []*federationapi.Cluster{active, cordoned},
+			snapshots: map[string]ClusterReplicaSnapshot{
+				"cordoned": {Replicas: 2, ReadyReplicas: 2},
+			},
+			expected: map[string]int64{"active": 8, "cordoned": 2},
+		},
+		{
+			note:     "cordoned cluster gets no replicas of new workloads",
+			replicas: 4,
+			clusters: []*federationapi.Cluster{active, cordoned},
+			expected: map[string]int64{"active": 4, "cordoned": 0},
+		},
+	}
+
+	for _, tc := range tests {
+		rs := &extensionsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "rs", Namespace: "default"},
+			Spec:       extensionsv1.ReplicaSetSpec{Replicas: &tc.replicas},
+		}
+		simulator := NewReplicaSetAdapter(nil, nil, nil).(ScheduleSimulator)
+		info, err := simulator.SimulateSchedule(rs, "default/rs", tc.clusters, tc.snapshots)
+		require.NoError(t, err, tc.note)
+		assert.Equal(t, tc.expected, info.ScheduledReplicas(), tc.note)
+		assert.Equal(t, tc.expectedPending, info.Pending, tc.note)
+	}
+}
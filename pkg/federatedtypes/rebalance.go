@@ -0,0 +1,143 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package federatedtypes
+
+import (
+	"reflect"
+	"sync"
+	"time"
+
+	fedapi "k8s.io/federation/apis/federation"
+	"k8s.io/federation/pkg/federation-controller/util/planner"
+)
+
+const defaultRebalanceInterval = time.Minute
+
+// rebalanceTracker remembers how many replicas of every workload were moved
+// between clusters in the current interval of its rebalance strategy.
+type rebalanceTracker struct {
+	lock    sync.Mutex
+	windows map[string]*rebalanceWindow
+	now     func() time.Time
+}
+
+type rebalanceWindow struct {
+	start    time.Time
+	interval time.Duration
+	moved    int64
+}
+
+func (w *rebalanceWindow) expired(now time.Time) bool {
+	return now.Sub(w.start) >= w.interval
+}
+
+func newRebalanceTracker() *rebalanceTracker {
+	return &rebalanceTracker{
+		windows: make(map[string]*rebalanceWindow),
+		now:     time.Now,
+	}
+}
+
+// step returns the replicas each cluster should run next on the way from the
+// current layout to plan, and records the replicas this moves.
+func (t *rebalanceTracker) step(key string, strategy *fedapi.RebalanceStrategy, replicas int64, plan map[string]int64,
+	specReplicas map[string]int64, readyReplicas map[string]int64) map[string]int64 {
+
+	var next map[string]int64
+	move := func(maxMoved int64) int64 {
+		var moved int64
+		next, moved = planner.RebalanceStep(strategy, replicas, plan, specReplicas, readyReplicas, maxMoved)
+		return moved
+	}
+	if strategy.MaxMovedReplicas == nil {
+		move(-1)
+	} else {
+		t.spend(key, *strategy.MaxMovedReplicas, strategy.Interval.Duration, move)
+	}
+	return next
+}
+
+// spend calls move with the number of replicas of the workload that may still
+// be moved in the current interval, and records the replicas move reports as
+// moved. The interval defaults to defaultRebalanceInterval.
+func (t *rebalanceTracker) spend(key string, limit int64, interval time.Duration, move func(available int64) int64) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	now := t.now()
+	// Expired windows are dropped so that deleted workloads are forgotten.
+	for k, window := range t.windows {
+		if window.expired(now) {
+			delete(t.windows, k)
+		}
+	}
+
+	if interval <= 0 {
+		interval = defaultRebalanceInterval
+	}
+	window := t.windows[key]
+	if window == nil {
+		window = &rebalanceWindow{start: now, interval: interval}
+		t.windows[key] = window
+	}
+	available := limit - window.moved
+	if available < 0 {
+		available = 0
+	}
+	window.moved += move(available)
+}
+
+// limitRebalance caps the scheduled replicas according to the rebalance strategy
+// and returns whether the schedule is only partially applied.
+func (a *replicaSchedulingAdapter) limitRebalance(key string, strategy *fedapi.RebalanceStrategy, replicas int64,
+	state map[string]*ReplicaScheduleState, specReplicas map[string]int64, readyReplicas map[string]int64) bool {
+
+	plan := make(map[string]int64, len(state))
+	for clusterName, clusterState := range state {
+		plan[clusterName] = clusterState.replicas
+	}
+	next := a.rebalance.step(key, strategy, replicas, plan, specReplicas, readyReplicas)
+
+	pending := false
+	for clusterName, clusterState := range state {
+		if next[clusterName] != plan[clusterName] {
+			pending = true
+		}
+		clusterState.replicas = next[clusterName]
+		if clusterState.replicas > 0 {
+			clusterState.isSelected = true
+		}
+	}
+	return pending
+}
+
+// clustersSpecReplicas returns the replicas the workload objects in the given
+// clusters are currently asked to run.
+func clustersSpecReplicas(clusterNames []string, key string, objectGetter func(clusterName string, key string) (interface{}, bool, error)) (map[string]int64, error) {
+	specReplicas := make(map[string]int64)
+	for _, clusterName := range clusterNames {
+		obj, exists, err := objectGetter(clusterName, key)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			continue
+		}
+		specReplicas[clusterName] = reflect.ValueOf(obj).Elem().FieldByName("Spec").FieldByName("Replicas").Elem().Int()
+	}
+	return specReplicas, nil
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package federatedtypes
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fedapi "k8s.io/federation/apis/federation"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRebalanceTracker(t *testing.T) {
+	now := time.Now()
+	tracker := newRebalanceTracker()
+	tracker.now = func() time.Time { return now }
+
+	maxMoved := int64(3)
+	strategy := &fedapi.RebalanceStrategy{
+		MaxMovedReplicas: &maxMoved,
+		Interval:         metav1.Duration{Duration: time.Minute},
+	}
+	plan := map[string]int64{"A": 0, "B": 10}
+
+	next := tracker.step("ns/rs", strategy, 10, plan, map[string]int64{"A": 10}, map[string]int64{"A": 10})
+	assert.EqualValues(t, map[string]int64{"A": 10, "B": 3}, next)
+
+	// The budget of the interval is used up.
+	next = tracker.step("ns/rs", strategy, 10, plan, map[string]int64{"A": 7, "B": 3}, map[string]int64{"A": 7, "B": 3})
+	assert.EqualValues(t, map[string]int64{"A": 7, "B": 3}, next)
+
+	// Other workloads have their own budget.
+	next = tracker.step("ns/other", strategy, 10, plan, map[string]int64{"A": 10}, map[string]int64{"A": 10})
+	assert.EqualValues(t, map[string]int64{"A": 10, "B": 3}, next)
+
+	now = now.Add(time.Minute)
+	next = tracker.step("ns/rs", strategy, 10, plan, map[string]int64{"A": 7, "B": 3}, map[string]int64{"A": 7, "B": 3})
+	assert.EqualValues(t, map[string]int64{"A": 7, "B": 6}, next)
+}
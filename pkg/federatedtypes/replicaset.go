@@ -64,6 +64,8 @@ func NewReplicaSetAdapter(client federationclientset.Interface, config *restclie
 			}
 			return nil
 		},
+		framework: schedulerFrameworkFromArgs(ReplicaSetKind, adapterSpecificArgs),
+		rebalance: newRebalanceTracker(),
 	}
 	return &ReplicaSetAdapter{&replicaSchedulingAdapter, client}
 }
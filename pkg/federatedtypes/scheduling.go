@@ -27,13 +27,13 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	pkgruntime "k8s.io/apimachinery/pkg/runtime"
-	fedapi "k8s.io/federation/apis/federation"
 	federationapi "k8s.io/federation/apis/federation/v1beta1"
 	fedutil "k8s.io/federation/pkg/federation-controller/util"
 	hpautil "k8s.io/federation/pkg/federation-controller/util/hpa"
 	"k8s.io/federation/pkg/federation-controller/util/planner"
 	"k8s.io/federation/pkg/federation-controller/util/podanalyzer"
 	"k8s.io/federation/pkg/federation-controller/util/replicapreferences"
+	"k8s.io/federation/pkg/federation-controller/util/scheduler"
 
 	"github.com/golang/glog"
 )
@@ -71,6 +71,20 @@ type ReplicaScheduleState struct {
 type ReplicaSchedulingInfo struct {
 	ScheduleState map[string]*ReplicaScheduleState
 	Status        ReplicaStatus
+	// Pending is true if the rebalance strategy held back part of the schedule.
+	Pending bool
+}
+
+// SchedulePending implements PendingScheduleInfo.
+func (i *ReplicaSchedulingInfo) SchedulePending() bool {
+	return i.Pending
+}
+
+// PendingScheduleInfo is implemented by scheduling information that may only
+// partially apply the desired schedule, e.g. because moving replicas between
+// clusters is rate limited. Objects with a pending schedule are rechecked.
+type PendingScheduleInfo interface {
+	SchedulePending() bool
 }
 
 // SchedulingAdapter defines operations for interacting with a
@@ -85,11 +99,37 @@ type SchedulingAdapter interface {
 	EquivalentIgnoringSchedule(obj1, obj2 pkgruntime.Object) bool
 }
 
+// PreferencesAdapter is implemented by scheduling adapters that read the
+// placement of replicas from an annotation on the federated object.
+type PreferencesAdapter interface {
+	PreferencesAnnotation() string
+}
+
 // replicaSchedulingAdapter is meant to be embedded in other type adapters that require
 // workload scheduling with actual pod replicas.
 type replicaSchedulingAdapter struct {
 	preferencesAnnotationName string
 	updateStatusFunc          func(pkgruntime.Object, interface{}) error
+	// framework filters and scores the clusters before the planner
+	// distributes replicas among them.
+	framework *scheduler.Framework
+	// rebalance rate limits moving replicas between clusters.
+	rebalance *rebalanceTracker
+}
+
+// schedulerFrameworkFromArgs returns the scheduler framework passed to the
+// adapter of the given kind, or the default framework if there is none.
+func schedulerFrameworkFromArgs(kind string, adapterSpecificArgs map[string]interface{}) *scheduler.Framework {
+	if adapterSpecificArgs != nil {
+		if framework, ok := adapterSpecificArgs[kind].(*scheduler.Framework); ok && framework != nil {
+			return framework
+		}
+	}
+	return scheduler.NewDefaultFramework()
+}
+
+func (a *replicaSchedulingAdapter) PreferencesAnnotation() string {
+	return a.preferencesAnnotationName
 }
 
 func (a *replicaSchedulingAdapter) IsSchedulingAdapter() bool {
@@ -149,11 +189,6 @@ func initializeScheduleState(fedObj pkgruntime.Object, clusterNames []string) (m
 }
 
 func (a *replicaSchedulingAdapter) GetSchedule(obj pkgruntime.Object, key string, clusters []*federationapi.Cluster, informer fedutil.FederatedInformer) (interface{}, error) {
-	var clusterNames []string
-	for _, cluster := range clusters {
-		clusterNames = append(clusterNames, cluster.Name)
-	}
-
 	// Schedule the pods across the existing clusters.
 	objectGetter := func(clusterName, key string) (interface{}, bool, error) {
 		return informer.GetTargetStore().GetByKey(clusterName, key)
@@ -175,6 +210,18 @@ func (a *replicaSchedulingAdapter) GetSchedule(obj pkgruntime.Object, key string
 		return clientset.Core().Pods(metadata.GetNamespace()).List(metav1.ListOptions{LabelSelector: selector.String()})
 	}
 
+	return a.getSchedule(obj, key, clusters, objectGetter, podsGetter)
+}
+
+func (a *replicaSchedulingAdapter) getSchedule(obj pkgruntime.Object, key string, clusters []*federationapi.Cluster,
+	objectGetter func(clusterName string, key string) (interface{}, bool, error),
+	podsGetter func(clusterName string, obj pkgruntime.Object) (*apiv1.PodList, error)) (*ReplicaSchedulingInfo, error) {
+
+	var clusterNames []string
+	for _, cluster := range clusters {
+		clusterNames = append(clusterNames, cluster.Name)
+	}
+
 	initializedState, hpaControlled, err := initializeScheduleState(obj, clusterNames)
 	if err != nil {
 		return nil, err
@@ -196,23 +243,63 @@ func (a *replicaSchedulingAdapter) GetSchedule(obj pkgruntime.Object, key string
 		return nil, err
 	}
 
+	var specReplicas map[string]int64
+	maintenance := newClusterMaintenance(clusters)
+	if maintenance.active() {
+		specReplicas, err = clustersSpecReplicas(clusterNames, key, objectGetter)
+		if err != nil {
+			return nil, err
+		}
+		maintenance.limitCapacity(estimatedCapacity, specReplicas)
+	}
+
+	profile := a.framework.ProfileFor(obj)
+	result, err := profile.Schedule(&scheduler.Context{
+		Object:            obj,
+		Key:               key,
+		Clusters:          clusters,
+		CurrentReplicas:   currentReplicasPerCluster,
+		EstimatedCapacity: estimatedCapacity,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scheduler profile %q failed: %v", profile.Name(), err)
+	}
+	for clusterName, reason := range result.Infeasible {
+		glog.V(4).Infof("Cluster %q is not feasible for %q: %s", clusterName, key, reason)
+	}
+
 	fedPref, err := replicapreferences.GetAllocationPreferences(obj, a.preferencesAnnotationName)
 	if err != nil {
 		glog.Infof("Invalid workload-type specific preference, using default. object: %v, err: %v", obj, err)
 	}
-	if fedPref == nil {
-		fedPref = &fedapi.ReplicaAllocationPreferences{
-			Clusters: map[string]fedapi.ClusterPreferences{
-				"*": {Weight: 1},
-			},
-		}
-	}
 
-	plnr := planner.NewPlanner(fedPref)
+	plnr := planner.NewTopologyAwarePlanner(result.Preferences(fedPref), planner.TopologyFromClusters(clusters))
 
+	state, err := schedule(plnr, obj, key, maintenance.feasible(result.Feasible), currentReplicasPerCluster, estimatedCapacity, initializedState)
+	if err != nil {
+		return nil, err
+	}
+
+	replicas := reflect.ValueOf(obj).Elem().FieldByName("Spec").FieldByName("Replicas").Elem().Int()
+	pending := false
+	if maintenance.active() {
+		pending = maintenance.apply(key, replicas, state, specReplicas, currentReplicasPerCluster)
+	}
+	if fedPref != nil && fedPref.RebalanceStrategy != nil && a.rebalance != nil {
+		if specReplicas == nil {
+			specReplicas, err = clustersSpecReplicas(clusterNames, key, objectGetter)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if a.limitRebalance(key, fedPref.RebalanceStrategy, replicas, state, specReplicas, currentReplicasPerCluster) {
+			pending = true
+		}
+	}
 	return &ReplicaSchedulingInfo{
-		ScheduleState: schedule(plnr, obj, key, clusterNames, currentReplicasPerCluster, estimatedCapacity, initializedState),
+		ScheduleState: state,
 		Status:        ReplicaStatus{},
+		Pending:       pending,
 	}, nil
 }
 
@@ -280,10 +367,13 @@ func simpleSchedule(scheduleState map[string]*ReplicaScheduleState, key string,
 	return scheduleState, nil
 }
 
-func schedule(planner *planner.Planner, obj pkgruntime.Object, key string, clusterNames []string, currentReplicasPerCluster map[string]int64, estimatedCapacity map[string]int64, initialState map[string]*ReplicaScheduleState) map[string]*ReplicaScheduleState {
+func schedule(planner *planner.Planner, obj pkgruntime.Object, key string, clusterNames []string, currentReplicasPerCluster map[string]int64, estimatedCapacity map[string]int64, initialState map[string]*ReplicaScheduleState) (map[string]*ReplicaScheduleState, error) {
 	// TODO: integrate real scheduler
 	replicas := reflect.ValueOf(obj).Elem().FieldByName("Spec").FieldByName("Replicas").Elem().Int()
 	scheduleResult, overflow := planner.Plan(replicas, clusterNames, currentReplicasPerCluster, estimatedCapacity, key)
+	if err := planner.CheckTopology(scheduleResult); err != nil {
+		return nil, fmt.Errorf("failed to schedule %q: %v", key, err)
+	}
 
 	// Ensure that all current clusters end up in the scheduling result.
 	// initialState, is preinitialized with all isSelected to false.
@@ -320,7 +410,7 @@ func schedule(planner *planner.Planner, obj pkgruntime.Object, key string, clust
 		}
 		glog.V(4).Infof(buf.String())
 	}
-	return result
+	return result, nil
 }
 
 // clusterReplicaState returns information about the scheduling state of the pods running in the federated clusters.
@@ -0,0 +1,109 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package federatedtypes
+
+import (
+	"reflect"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	pkgruntime "k8s.io/apimachinery/pkg/runtime"
+	federationapi "k8s.io/federation/apis/federation/v1beta1"
+	"k8s.io/federation/pkg/federation-controller/util/podanalyzer"
+)
+
+// ClusterReplicaSnapshot is the state of a workload in a member cluster that
+// scheduling is simulated against.
+type ClusterReplicaSnapshot struct {
+	// Replicas the workload object in the cluster is asked to run.
+	Replicas int64 `json:"replicas"`
+	// Replicas that are running and ready.
+	ReadyReplicas int64 `json:"readyReplicas"`
+	// Replicas that can not be scheduled in the cluster.
+	// +optional
+	UnschedulableReplicas int64 `json:"unschedulableReplicas,omitempty"`
+}
+
+// ScheduleSimulator is implemented by scheduling adapters that can compute a
+// schedule offline, from snapshots of the workload in the clusters rather than
+// from the informer caches.
+type ScheduleSimulator interface {
+	SimulateSchedule(obj pkgruntime.Object, key string, clusters []*federationapi.Cluster, snapshots map[string]ClusterReplicaSnapshot) (*ReplicaSchedulingInfo, error)
+}
+
+// SimulateSchedule implements ScheduleSimulator. Snapshots are keyed by cluster
+// name; clusters without a snapshot do not run the workload.
+func (a *replicaSchedulingAdapter) SimulateSchedule(obj pkgruntime.Object, key string, clusters []*federationapi.Cluster,
+	snapshots map[string]ClusterReplicaSnapshot) (*ReplicaSchedulingInfo, error) {
+
+	objectGetter := func(clusterName, key string) (interface{}, bool, error) {
+		snapshot, found := snapshots[clusterName]
+		if !found {
+			return nil, false, nil
+		}
+		clusterObj := obj.DeepCopyObject()
+		replicas := int32(snapshot.Replicas)
+		reflect.ValueOf(clusterObj).Elem().FieldByName("Spec").FieldByName("Replicas").Set(reflect.ValueOf(&replicas))
+		reflect.ValueOf(clusterObj).Elem().FieldByName("Status").FieldByName("ReadyReplicas").SetInt(snapshot.ReadyReplicas)
+		return clusterObj, true, nil
+	}
+	// Pods are made up to match the snapshot, as analyzed by podanalyzer.
+	podsGetter := func(clusterName string, obj pkgruntime.Object) (*apiv1.PodList, error) {
+		snapshot := snapshots[clusterName]
+		pods := &apiv1.PodList{}
+		for i := int64(0); i < snapshot.ReadyReplicas; i++ {
+			pods.Items = append(pods.Items, apiv1.Pod{
+				Status: apiv1.PodStatus{
+					Phase:      apiv1.PodRunning,
+					Conditions: []apiv1.PodCondition{{Type: apiv1.PodReady, Status: apiv1.ConditionTrue}},
+				},
+			})
+		}
+		unschedulableSince := metav1.NewTime(time.Now().Add(-2 * podanalyzer.UnschedulableThreshold))
+		for i := int64(0); i < snapshot.UnschedulableReplicas; i++ {
+			pods.Items = append(pods.Items, apiv1.Pod{
+				Status: apiv1.PodStatus{
+					Phase: apiv1.PodPending,
+					Conditions: []apiv1.PodCondition{{
+						Type:               apiv1.PodScheduled,
+						Status:             apiv1.ConditionFalse,
+						Reason:             apiv1.PodReasonUnschedulable,
+						LastTransitionTime: unschedulableSince,
+					}},
+				},
+			})
+		}
+		return pods, nil
+	}
+
+	return a.getSchedule(obj, key, clusters, objectGetter, podsGetter)
+}
+
+// ScheduledReplicas returns the number of replicas scheduled to every cluster.
+// Clusters that the workload is not scheduled to get 0 replicas.
+func (i *ReplicaSchedulingInfo) ScheduledReplicas() map[string]int64 {
+	replicas := make(map[string]int64, len(i.ScheduleState))
+	for clusterName, state := range i.ScheduleState {
+		if state.isSelected {
+			replicas[clusterName] = state.replicas
+		} else {
+			replicas[clusterName] = 0
+		}
+	}
+	return replicas
+}
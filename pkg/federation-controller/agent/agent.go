@@ -0,0 +1,477 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package agent implements the agent of a member cluster in pull mode. The
+// agent runs in the cluster and connects to the federation API server, since
+// the federation can not reach the cluster. It applies the federated objects
+// selected for the cluster and reports the status of the cluster.
+//
+// Only the federated types whose objects are copied to the clusters as they
+// are are applied. The replicas of workloads are scheduled by the federation
+// to the clusters it reaches.
+package agent
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	pkgruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	kubeclientset "k8s.io/client-go/kubernetes"
+	restclient "k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	federationapi "k8s.io/federation/apis/federation/v1beta1"
+	federationclientset "k8s.io/federation/client/clientset_generated/federation_clientset"
+	"k8s.io/federation/pkg/federatedtypes"
+	clustercontroller "k8s.io/federation/pkg/federation-controller/cluster"
+	fedutil "k8s.io/federation/pkg/federation-controller/util"
+	"k8s.io/federation/pkg/federation-controller/util/clusterselector"
+	"k8s.io/federation/pkg/federation-controller/util/clusterset"
+	"k8s.io/federation/pkg/federation-controller/util/eventsink"
+	finalizersutil "k8s.io/federation/pkg/federation-controller/util/finalizers"
+	"k8s.io/federation/pkg/federation-controller/util/propagationpolicy"
+	"k8s.io/kubernetes/pkg/api/legacyscheme"
+	"k8s.io/kubernetes/pkg/controller"
+
+	"github.com/golang/glog"
+)
+
+const (
+	// UserAgentName is the user agent used in the federation and cluster clients
+	UserAgentName = "Federation-Agent"
+
+	// ManagedAnnotation marks the objects of the cluster the agent applied.
+	// Only such objects are deleted once their federated object is deleted or
+	// no longer selects the cluster.
+	ManagedAnnotation = "federation.alpha.kubernetes.io/managed-by-agent"
+
+	// resyncPeriod is the period after which all the objects are applied
+	// again, correcting the changes made in the cluster.
+	resyncPeriod = 5 * time.Minute
+	// probeTimeout bounds the health check of the cluster.
+	probeTimeout = 10 * time.Second
+)
+
+// federatedType holds the informer on the federated objects of a type.
+type federatedType struct {
+	adapter    federatedtypes.FederatedTypeAdapter
+	store      cache.Store
+	controller cache.Controller
+}
+
+// objectKey identifies a federated object to apply.
+type objectKey struct {
+	kind          string
+	qualifiedName federatedtypes.QualifiedName
+}
+
+// Agent applies the federated objects selected for the cluster it runs in
+// and reports the status of the cluster.
+type Agent struct {
+	// clusterName is the name the cluster joined the federation with.
+	clusterName string
+
+	federationClient federationclientset.Interface
+	clusterClient    kubeclientset.Interface
+	// healthClient checks the health of the cluster.
+	healthClient *clustercontroller.ClusterClient
+
+	// heartbeatPeriod is the period for reporting the status of the cluster.
+	heartbeatPeriod time.Duration
+
+	// Store and informer controller of the cluster the agent runs in.
+	clusterStore      cache.Store
+	clusterController cache.Controller
+	// Store and informer controller of the propagation policies.
+	policyStore      cache.Store
+	policyController cache.Controller
+	// Store and informer controller of the cluster sets.
+	clusterSetStore      cache.Store
+	clusterSetController cache.Controller
+
+	// types are the federated types applied by the agent, by kind.
+	types map[string]*federatedType
+
+	// Federated objects to apply.
+	queue workqueue.RateLimitingInterface
+
+	eventRecorder record.EventRecorder
+}
+
+// NewAgent returns the agent of the named cluster, connecting to the
+// federation and to the cluster with the given configs.
+func NewAgent(clusterName string, federationConfig, clusterConfig *restclient.Config, heartbeatPeriod time.Duration) *Agent {
+	federationConfig = restclient.AddUserAgent(federationConfig, UserAgentName)
+	clusterConfig = restclient.AddUserAgent(clusterConfig, UserAgentName)
+	federationClient := federationclientset.NewForConfigOrDie(federationConfig)
+
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartRecordingToSink(eventsink.NewFederatedEventSink(federationClient))
+	recorder := broadcaster.NewRecorder(legacyscheme.Scheme, v1.EventSource{Component: "federation-agent", Host: clusterName})
+
+	a := newAgent(clusterName, federationClient, kubeclientset.NewForConfigOrDie(clusterConfig), recorder)
+	a.healthClient = clustercontroller.NewClusterClientForConfig(clusterConfig, probeTimeout)
+	a.heartbeatPeriod = heartbeatPeriod
+
+	for kind, federatedType := range federatedtypes.FederatedTypes() {
+		adapter := federatedType.AdapterFactory(federationClient, federationConfig, nil)
+		if adapter.IsSchedulingAdapter() {
+			glog.Infof("Not applying federated %s, they are only scheduled to the clusters the federation reaches", kind)
+			continue
+		}
+		a.addType(adapter)
+	}
+	return a
+}
+
+// newAgent returns an agent applying no federated type.
+func newAgent(clusterName string, federationClient federationclientset.Interface, clusterClient kubeclientset.Interface, recorder record.EventRecorder) *Agent {
+	a := &Agent{
+		clusterName:      clusterName,
+		federationClient: federationClient,
+		clusterClient:    clusterClient,
+		types:            make(map[string]*federatedType),
+		queue:            workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "federation-agent"),
+		eventRecorder:    recorder,
+	}
+
+	// Changes of the labels, connection mode or maintenance of the cluster
+	// may change which objects it holds.
+	nameSelector := fields.OneTermEqualSelector("metadata.name", clusterName).String()
+	a.clusterStore, a.clusterController = cache.NewInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (pkgruntime.Object, error) {
+				options.FieldSelector = nameSelector
+				return federationClient.Federation().Clusters().List(options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				options.FieldSelector = nameSelector
+				return federationClient.Federation().Clusters().Watch(options)
+			},
+		},
+		&federationapi.Cluster{},
+		controller.NoResyncPeriodFunc(),
+		fedutil.NewTriggerOnMetaAndSpecChanges(func(obj pkgruntime.Object) { a.enqueueAll() }),
+	)
+	a.policyStore, a.policyController = propagationpolicy.NewInformer(
+		federationClient,
+		fedutil.NewTriggerOnAllChanges(func(obj pkgruntime.Object) { a.enqueueAll() }),
+	)
+	a.clusterSetStore, a.clusterSetController = clusterset.NewInformer(
+		federationClient,
+		fedutil.NewTriggerOnMetaAndSpecChanges(func(obj pkgruntime.Object) { a.enqueueAll() }),
+	)
+	return a
+}
+
+// addType makes the agent apply the federated objects of the type of the
+// given adapter.
+func (a *Agent) addType(adapter federatedtypes.FederatedTypeAdapter) {
+	kind := adapter.Kind()
+	t := &federatedType{adapter: adapter}
+	t.store, t.controller = cache.NewInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (pkgruntime.Object, error) {
+				return adapter.FedList(metav1.NamespaceAll, options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				return adapter.FedWatch(metav1.NamespaceAll, options)
+			},
+		},
+		adapter.ObjectType(),
+		controller.NoResyncPeriodFunc(),
+		fedutil.NewTriggerOnAllChanges(func(obj pkgruntime.Object) {
+			a.queue.Add(objectKey{kind: kind, qualifiedName: adapter.QualifiedName(obj)})
+		}),
+	)
+	a.types[kind] = t
+}
+
+// Run starts reporting the status of the cluster and applying the federated
+// objects selected for it.
+func (a *Agent) Run(workers int, stopCh <-chan struct{}) {
+	defer runtime.HandleCrash()
+	defer a.queue.ShutDown()
+	defer a.healthClient.Stop()
+
+	go wait.Until(a.reportStatus, a.heartbeatPeriod, stopCh)
+
+	synced := []cache.InformerSynced{a.clusterController.HasSynced, a.policyController.HasSynced, a.clusterSetController.HasSynced}
+	go a.clusterController.Run(stopCh)
+	go a.policyController.Run(stopCh)
+	go a.clusterSetController.Run(stopCh)
+	for _, t := range a.types {
+		go t.controller.Run(stopCh)
+		synced = append(synced, t.controller.HasSynced)
+	}
+	if !cache.WaitForCacheSync(stopCh, synced...) {
+		runtime.HandleError(fmt.Errorf("timed out waiting for the caches of the agent to sync"))
+		return
+	}
+	go wait.Until(a.enqueueAll, resyncPeriod, stopCh)
+
+	for i := 0; i < workers; i++ {
+		go wait.Until(a.worker, time.Second, stopCh)
+	}
+	<-stopCh
+	glog.Infof("Shutting down the agent of cluster %s", a.clusterName)
+}
+
+// enqueueAll queues the federated objects and the objects of the cluster the
+// agent applied, whose federated objects may have been deleted while the
+// agent was not running.
+func (a *Agent) enqueueAll() {
+	for kind, t := range a.types {
+		for _, obj := range t.store.List() {
+			a.queue.Add(objectKey{kind: kind, qualifiedName: t.adapter.QualifiedName(obj.(pkgruntime.Object))})
+		}
+		list, err := t.adapter.ClusterList(a.clusterClient, metav1.NamespaceAll, metav1.ListOptions{})
+		if err != nil {
+			runtime.HandleError(fmt.Errorf("failed to list the %s of cluster %s: %v", kind, a.clusterName, err))
+			continue
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			runtime.HandleError(fmt.Errorf("failed to list the %s of cluster %s: %v", kind, a.clusterName, err))
+			continue
+		}
+		for _, item := range items {
+			if isManaged(t.adapter, item) {
+				a.queue.Add(objectKey{kind: kind, qualifiedName: t.adapter.QualifiedName(item)})
+			}
+		}
+	}
+}
+
+func (a *Agent) worker() {
+	for {
+		item, quit := a.queue.Get()
+		if quit {
+			return
+		}
+		key := item.(objectKey)
+		err := a.reconcile(key)
+		if err == nil {
+			a.queue.Forget(item)
+		} else {
+			runtime.HandleError(fmt.Errorf("failed to apply %s %q to cluster %s: %v", key.kind, key.qualifiedName, a.clusterName, err))
+			a.queue.AddRateLimited(item)
+		}
+		a.queue.Done(item)
+	}
+}
+
+// reconcile makes the object of the cluster identified by the given key match
+// its federated object, creating, updating or deleting it.
+func (a *Agent) reconcile(key objectKey) error {
+	obj, exists, err := a.clusterStore.GetByKey(a.clusterName)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("cluster %s is not joined to the federation", a.clusterName)
+	}
+	cluster := obj.(*federationapi.Cluster)
+	if !fedutil.IsClusterPullMode(cluster) {
+		glog.Warningf("Cluster %s is not in pull mode, the agent does not apply its objects", a.clusterName)
+		return nil
+	}
+
+	t, found := a.types[key.kind]
+	if !found {
+		return nil
+	}
+	adapter := t.adapter
+	clusterObj, err := adapter.ClusterGet(a.clusterClient, key.qualifiedName)
+	if errors.IsNotFound(err) {
+		clusterObj = nil
+	} else if err != nil {
+		return err
+	}
+
+	obj, exists, err = t.store.GetByKey(key.qualifiedName.String())
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return a.delete(adapter, nil, clusterObj)
+	}
+	fedObj := obj.(pkgruntime.Object)
+
+	if adapter.ObjectMeta(fedObj).DeletionTimestamp != nil {
+		orphan, err := finalizersutil.HasFinalizer(fedObj, metav1.FinalizerOrphanDependents)
+		if err != nil {
+			return err
+		}
+		if orphan {
+			return a.orphan(adapter, clusterObj)
+		}
+		return a.delete(adapter, fedObj, clusterObj)
+	}
+
+	selected, err := a.isSelected(adapter, fedObj, cluster)
+	if err != nil {
+		return err
+	}
+	if !selected {
+		return a.delete(adapter, fedObj, clusterObj)
+	}
+
+	desiredObj := adapter.Copy(fedObj)
+	federatedtypes.SetAnnotation(adapter, desiredObj, ManagedAnnotation, "true")
+	switch {
+	case clusterObj == nil && fedutil.IsClusterCordoned(cluster):
+		glog.V(3).Infof("Cluster %s is cordoned, %s %q is not created in it", a.clusterName, key.kind, key.qualifiedName)
+		return nil
+	case clusterObj == nil:
+		a.recordEvent(fedObj, v1.EventTypeNormal, "CreateInCluster", "Creating")
+		_, err = adapter.ClusterCreate(a.clusterClient, desiredObj)
+		if err != nil {
+			a.recordEvent(fedObj, v1.EventTypeWarning, "CreateInClusterFailed", "Failed to create", err)
+		}
+	case !adapter.Equivalent(desiredObj, clusterObj):
+		a.recordEvent(fedObj, v1.EventTypeNormal, "UpdateInCluster", "Updating")
+		_, err = adapter.ClusterUpdate(a.clusterClient, desiredObj)
+		if err != nil {
+			a.recordEvent(fedObj, v1.EventTypeWarning, "UpdateInClusterFailed", "Failed to update", err)
+		}
+	}
+	return err
+}
+
+// isSelected returns whether the given federated object is placed in the
+// cluster by its cluster selector, propagation policy and cluster set.
+func (a *Agent) isSelected(adapter federatedtypes.FederatedTypeAdapter, fedObj pkgruntime.Object, cluster *federationapi.Cluster) (bool, error) {
+	placementObj, err := propagationpolicy.PlacementObject(a.policyStore, adapter.Kind(), fedObj, "")
+	if err != nil {
+		return false, err
+	}
+	annotations := adapter.ObjectMeta(placementObj).Annotations
+	send, err := clusterselector.SendToCluster(cluster.Labels, annotations)
+	if err != nil || !send {
+		return false, err
+	}
+	selected, _, err := clusterset.SelectClusters(a.clusterSetStore, annotations, []*federationapi.Cluster{cluster}, nil)
+	return len(selected) == 1, err
+}
+
+// delete deletes the given object of the cluster if the agent applied it.
+// fedObj is the federated object, nil if it was deleted.
+func (a *Agent) delete(adapter federatedtypes.FederatedTypeAdapter, fedObj, clusterObj pkgruntime.Object) error {
+	if clusterObj == nil || !isManaged(adapter, clusterObj) {
+		return nil
+	}
+	a.recordEvent(fedObj, v1.EventTypeNormal, "DeleteInCluster", "Deleting")
+	orphanDependents := false
+	err := adapter.ClusterDelete(a.clusterClient, adapter.QualifiedName(clusterObj), &metav1.DeleteOptions{OrphanDependents: &orphanDependents})
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		a.recordEvent(fedObj, v1.EventTypeWarning, "DeleteInClusterFailed", "Failed to delete", err)
+	}
+	return err
+}
+
+// orphan makes the agent leave the given object of the cluster in place once
+// its federated object is deleted.
+func (a *Agent) orphan(adapter federatedtypes.FederatedTypeAdapter, clusterObj pkgruntime.Object) error {
+	if clusterObj == nil || !isManaged(adapter, clusterObj) {
+		return nil
+	}
+	orphanObj := adapter.Copy(clusterObj)
+	delete(adapter.ObjectMeta(orphanObj).Annotations, ManagedAnnotation)
+	_, err := adapter.ClusterUpdate(a.clusterClient, orphanObj)
+	return err
+}
+
+// recordEvent records an event on the given federated object about applying
+// it to the cluster. No event is recorded if the object was deleted.
+func (a *Agent) recordEvent(fedObj pkgruntime.Object, eventType, reason, verb string, args ...interface{}) {
+	if fedObj == nil {
+		return
+	}
+	accessor, err := meta.Accessor(fedObj)
+	if err != nil {
+		return
+	}
+	message := fmt.Sprintf("%s %q in cluster %s", verb, accessor.GetName(), a.clusterName)
+	if len(args) > 0 {
+		message = fmt.Sprintf("%s: %v", message, args[0])
+	}
+	a.eventRecorder.Event(fedObj, eventType, reason, message)
+}
+
+// isManaged returns whether the given object of the cluster was applied by
+// the agent.
+func isManaged(adapter federatedtypes.FederatedTypeAdapter, clusterObj pkgruntime.Object) bool {
+	_, found := adapter.ObjectMeta(clusterObj).Annotations[ManagedAnnotation]
+	return found
+}
+
+// reportStatus checks the health of the cluster and writes it to the status
+// of the cluster in the federation, which the cluster controller reads in
+// place of its own health checks.
+func (a *Agent) reportStatus() {
+	cluster, err := a.federationClient.Federation().Clusters().Get(a.clusterName, metav1.GetOptions{})
+	if err != nil {
+		runtime.HandleError(fmt.Errorf("failed to get cluster %s: %v", a.clusterName, err))
+		return
+	}
+	if !fedutil.IsClusterPullMode(cluster) {
+		glog.Warningf("Cluster %s is not in pull mode, the agent does not report its status", a.clusterName)
+		return
+	}
+	cluster.Status = *newClusterStatus(&cluster.Status, a.healthClient.GetClusterHealthStatus(""))
+	if _, err := a.federationClient.Federation().Clusters().UpdateStatus(cluster); err != nil {
+		runtime.HandleError(fmt.Errorf("failed to update the status of cluster %s: %v", a.clusterName, err))
+	}
+}
+
+// newClusterStatus returns the status of the cluster to report given its
+// previous status and the result of its health check.
+func newClusterStatus(oldStatus, probedStatus *federationapi.ClusterStatus) *federationapi.ClusterStatus {
+	status := probedStatus.DeepCopy()
+	// The federation does not reach the cluster at its addresses.
+	status.ServerAddresses = nil
+	status.ActiveServerAddress = ""
+	// Keep the last known version and resources of the cluster if it could
+	// not be discovered.
+	if status.Version == "" {
+		status.Version = oldStatus.Version
+	}
+	if len(status.APIResources) == 0 {
+		status.APIResources = oldStatus.APIResources
+	}
+	for i := range status.Conditions {
+		condition := &status.Conditions[i]
+		for _, oldCondition := range oldStatus.Conditions {
+			if oldCondition.Type == condition.Type && oldCondition.Status == condition.Status {
+				condition.LastTransitionTime = oldCondition.LastTransitionTime
+			}
+		}
+	}
+	return status
+}
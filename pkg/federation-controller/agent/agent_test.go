@@ -0,0 +1,177 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agent
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeclientfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+	federationapi "k8s.io/federation/apis/federation/v1beta1"
+	fedclientfake "k8s.io/federation/client/clientset_generated/federation_clientset/fake"
+	"k8s.io/federation/pkg/federatedtypes"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newConfigMap(value string, annotations map[string]string) *v1.ConfigMap {
+	return &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "settings", Annotations: annotations},
+		Data:       map[string]string{"key": value},
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	managed := map[string]string{ManagedAnnotation: "true"}
+	prodOnly := map[string]string{
+		federationapi.FederationClusterSelectorAnnotation: `[{"key": "environment", "operator": "in", "values": ["prod"]}]`,
+	}
+	deleting := func(finalizers ...string) *v1.ConfigMap {
+		configMap := newConfigMap("value", nil)
+		configMap.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+		configMap.Finalizers = finalizers
+		return configMap
+	}
+
+	tests := map[string]struct {
+		maintenance federationapi.ClusterMaintenance
+		fedObj      *v1.ConfigMap
+		clusterObj  *v1.ConfigMap
+		// expectedObj is the object expected in the cluster, nil if none.
+		expectedObj *v1.ConfigMap
+	}{
+		"create": {
+			fedObj:      newConfigMap("value", nil),
+			expectedObj: newConfigMap("value", managed),
+		},
+		"update": {
+			fedObj:      newConfigMap("value", nil),
+			clusterObj:  newConfigMap("old", managed),
+			expectedObj: newConfigMap("value", managed),
+		},
+		"not selected": {
+			fedObj:     newConfigMap("value", prodOnly),
+			clusterObj: newConfigMap("value", managed),
+		},
+		"federated object deleted": {
+			clusterObj: newConfigMap("value", managed),
+		},
+		"object not applied by the agent": {
+			clusterObj:  newConfigMap("local", nil),
+			expectedObj: newConfigMap("local", nil),
+		},
+		"federated object deleted with dependents": {
+			fedObj:     deleting(),
+			clusterObj: newConfigMap("value", managed),
+		},
+		"federated object deleted orphaning dependents": {
+			fedObj:      deleting(metav1.FinalizerOrphanDependents),
+			clusterObj:  newConfigMap("value", managed),
+			expectedObj: newConfigMap("value", map[string]string{}),
+		},
+		"cordoned cluster": {
+			maintenance: federationapi.ClusterCordoned,
+			fedObj:      newConfigMap("value", nil),
+		},
+		"cordoned cluster holding the object": {
+			maintenance: federationapi.ClusterCordoned,
+			fedObj:      newConfigMap("value", nil),
+			clusterObj:  newConfigMap("old", managed),
+			expectedObj: newConfigMap("value", managed),
+		},
+	}
+
+	for name, tc := range tests {
+		federationClient := &fedclientfake.Clientset{}
+		clusterClient := kubeclientfake.NewSimpleClientset()
+		if tc.clusterObj != nil {
+			clusterClient = kubeclientfake.NewSimpleClientset(tc.clusterObj)
+		}
+		a := newAgent("edge", federationClient, clusterClient, record.NewFakeRecorder(10))
+		adapter := federatedtypes.NewConfigMapAdapter(federationClient, nil, nil)
+		a.addType(adapter)
+		require.NoError(t, a.clusterStore.Add(&federationapi.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "edge", Labels: map[string]string{"environment": "edge"}},
+			Spec: federationapi.ClusterSpec{
+				ConnectionMode: federationapi.ClusterConnectionPull,
+				Maintenance:    tc.maintenance,
+			},
+		}))
+		if tc.fedObj != nil {
+			require.NoError(t, a.types[adapter.Kind()].store.Add(tc.fedObj))
+		}
+
+		key := objectKey{kind: adapter.Kind(), qualifiedName: federatedtypes.QualifiedName{Namespace: "ns", Name: "settings"}}
+		require.NoError(t, a.reconcile(key), name)
+
+		configMap, err := clusterClient.CoreV1().ConfigMaps("ns").Get("settings", metav1.GetOptions{})
+		if tc.expectedObj == nil {
+			assert.True(t, errors.IsNotFound(err), "%s: expected no object in the cluster, got %v", name, configMap)
+			continue
+		}
+		require.NoError(t, err, name)
+		assert.Equal(t, tc.expectedObj.Data, configMap.Data, name)
+		assert.Equal(t, len(tc.expectedObj.Annotations), len(configMap.Annotations), name)
+		assert.Equal(t, tc.expectedObj.Annotations[ManagedAnnotation], configMap.Annotations[ManagedAnnotation], name)
+	}
+}
+
+func TestReconcileClusterInPushMode(t *testing.T) {
+	federationClient := &fedclientfake.Clientset{}
+	clusterClient := kubeclientfake.NewSimpleClientset()
+	a := newAgent("edge", federationClient, clusterClient, record.NewFakeRecorder(10))
+	adapter := federatedtypes.NewConfigMapAdapter(federationClient, nil, nil)
+	a.addType(adapter)
+	require.NoError(t, a.clusterStore.Add(&federationapi.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "edge"}}))
+	require.NoError(t, a.types[adapter.Kind()].store.Add(newConfigMap("value", nil)))
+
+	key := objectKey{kind: adapter.Kind(), qualifiedName: federatedtypes.QualifiedName{Namespace: "ns", Name: "settings"}}
+	require.NoError(t, a.reconcile(key))
+	_, err := clusterClient.CoreV1().ConfigMaps("ns").Get("settings", metav1.GetOptions{})
+	assert.True(t, errors.IsNotFound(err), "expected the agent not to apply objects to a cluster the federation reaches")
+}
+
+func TestNewClusterStatus(t *testing.T) {
+	transition := metav1.NewTime(time.Now().Add(-time.Hour))
+	oldStatus := &federationapi.ClusterStatus{
+		Version: "v1.9.0",
+		Conditions: []federationapi.ClusterCondition{
+			{Type: federationapi.ClusterReady, Status: v1.ConditionTrue, LastTransitionTime: transition},
+		},
+	}
+	now := metav1.Now()
+	probedStatus := &federationapi.ClusterStatus{
+		ActiveServerAddress: "https://10.0.0.1",
+		ServerAddresses:     []federationapi.ServerAddressStatus{{ServerAddress: "https://10.0.0.1", Healthy: true}},
+		Conditions: []federationapi.ClusterCondition{
+			{Type: federationapi.ClusterReady, Status: v1.ConditionTrue, LastProbeTime: now, LastTransitionTime: now},
+		},
+	}
+
+	status := newClusterStatus(oldStatus, probedStatus)
+	assert.Empty(t, status.ActiveServerAddress)
+	assert.Empty(t, status.ServerAddresses)
+	assert.Equal(t, "v1.9.0", status.Version)
+	require.Len(t, status.Conditions, 1)
+	assert.Equal(t, now, status.Conditions[0].LastProbeTime)
+	assert.Equal(t, transition, status.Conditions[0].LastTransitionTime)
+}
@@ -18,47 +18,244 @@ package cluster
 
 import (
 	"fmt"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/golang/glog"
 	"k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/discovery"
 	restclient "k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
 	federation_v1beta1 "k8s.io/federation/apis/federation/v1beta1"
 	"k8s.io/federation/pkg/federation-controller/util"
 	api "k8s.io/kubernetes/pkg/apis/core"
 	clientset "k8s.io/kubernetes/pkg/client/clientset_generated/internalclientset"
+	"k8s.io/kubernetes/pkg/controller"
 	kubeletapis "k8s.io/kubernetes/pkg/kubelet/apis"
 )
 
 const (
 	UserAgentName = "Cluster-Controller"
+
+	// credentialsExpiryWarningPeriod is how long before their expiry the
+	// credentials of a cluster are reported as expiring.
+	credentialsExpiryWarningPeriod = 7 * 24 * time.Hour
 )
 
 type ClusterClient struct {
+	// endpoints are the API servers of the cluster reachable from the
+	// federation control plane, in the order they are listed in its spec.
+	endpoints []clusterEndpoint
+	// timeout bounds the health check of the cluster.
+	timeout time.Duration
+	// credentialsExpiry is when the credentials of the client expire, nil if
+	// they do not expire or their expiry is not known.
+	credentialsExpiry *time.Time
+	// credentialsKind is the kind of the credentials that expire first.
+	credentialsKind string
+
+	nodesLock sync.Mutex
+	// nodes caches the nodes of the cluster, read to find its zones and region.
+	nodes *nodeCache
+	// stopped is set once the client is stopped, no nodes are cached anymore.
+	stopped bool
+}
+
+// clusterEndpoint is a client of one of the API servers of a cluster.
+type clusterEndpoint struct {
+	serverAddress string
+	// kubeClient is used for health checks, its requests time out.
 	kubeClient *clientset.Clientset
+	// config is used for long running requests, such as watches.
+	config *restclient.Config
+}
+
+// nodeCache is an informer on the nodes of a cluster, watched through one of
+// its API servers.
+type nodeCache struct {
+	serverAddress string
+	store         cache.Store
+	controller    cache.Controller
+	stopChan      chan struct{}
 }
 
-func NewClusterClientSet(c *federation_v1beta1.Cluster) (*ClusterClient, error) {
-	clusterConfig, err := util.BuildClusterConfig(c)
+// NewClusterClientSet returns a client of the cluster whose health check
+// requests time out after the given timeout. The client must be stopped once
+// it is not used anymore.
+func NewClusterClientSet(c *federation_v1beta1.Cluster, timeout time.Duration) (*ClusterClient, error) {
+	serverAddresses, err := util.ClusterServerAddresses(c)
 	if err != nil {
 		return nil, err
 	}
-	var clusterClientSet = ClusterClient{}
-	if clusterConfig != nil {
-		clusterClientSet.kubeClient = clientset.NewForConfigOrDie((restclient.AddUserAgent(clusterConfig, UserAgentName)))
-		if clusterClientSet.kubeClient == nil {
-			return nil, nil
+	var clusterClientSet = ClusterClient{timeout: timeout}
+	for _, serverAddress := range serverAddresses {
+		clusterConfig, err := util.BuildClusterConfigForAddress(c, serverAddress)
+		if err != nil {
+			return nil, err
+		}
+		clusterConfig = restclient.AddUserAgent(clusterConfig, UserAgentName)
+		clusterClientSet.endpoints = append(clusterClientSet.endpoints, newClusterEndpoint(serverAddress, clusterConfig, timeout))
+		if len(clusterClientSet.endpoints) > 1 {
+			continue
 		}
+		// All the API servers of the cluster accept the same credentials.
+		clusterClientSet.credentialsExpiry, clusterClientSet.credentialsKind, err = util.ClusterCredentialsExpiry(clusterConfig)
+		if err != nil {
+			glog.Warningf("Failed to determine when the credentials of cluster %s expire: %v", c.Name, err)
+		}
+	}
+	if len(clusterClientSet.endpoints) == 0 {
+		return nil, nil
 	}
 	return &clusterClientSet, nil
 }
 
+// NewClusterClientForConfig returns a client of the cluster reached with the
+// given config, such as the cluster the agent of a cluster in pull mode runs
+// in. Its health check requests time out after the given timeout. The client
+// must be stopped once it is not used anymore.
+func NewClusterClientForConfig(config *restclient.Config, timeout time.Duration) *ClusterClient {
+	clusterClientSet := ClusterClient{
+		endpoints: []clusterEndpoint{newClusterEndpoint(config.Host, config, timeout)},
+		timeout:   timeout,
+	}
+	var err error
+	clusterClientSet.credentialsExpiry, clusterClientSet.credentialsKind, err = util.ClusterCredentialsExpiry(config)
+	if err != nil {
+		glog.Warningf("Failed to determine when the credentials of the cluster at %s expire: %v", config.Host, err)
+	}
+	return &clusterClientSet
+}
+
+// newClusterEndpoint returns a client of the API server at the given address,
+// whose health check requests time out after the given timeout.
+func newClusterEndpoint(serverAddress string, config *restclient.Config, timeout time.Duration) clusterEndpoint {
+	probeConfig := restclient.CopyConfig(config)
+	probeConfig.Timeout = timeout
+	return clusterEndpoint{
+		serverAddress: serverAddress,
+		kubeClient:    clientset.NewForConfigOrDie(probeConfig),
+		config:        config,
+	}
+}
+
+// Stop stops caching the nodes of the cluster.
+func (self *ClusterClient) Stop() {
+	self.nodesLock.Lock()
+	defer self.nodesLock.Unlock()
+	self.stopped = true
+	if self.nodes != nil {
+		close(self.nodes.stopChan)
+		self.nodes = nil
+	}
+}
+
+// getNodeCache returns the cache of the nodes of the cluster watched through
+// the given endpoint, starting it if needed. The nodes are watched again when
+// the cluster fails over to another endpoint. Returns nil once the client is
+// stopped.
+func (self *ClusterClient) getNodeCache(endpoint *clusterEndpoint) *nodeCache {
+	self.nodesLock.Lock()
+	defer self.nodesLock.Unlock()
+	if self.stopped {
+		return nil
+	}
+	if self.nodes != nil {
+		if self.nodes.serverAddress == endpoint.serverAddress {
+			return self.nodes
+		}
+		close(self.nodes.stopChan)
+	}
+
+	client := clientset.NewForConfigOrDie(endpoint.config)
+	nodes := &nodeCache{
+		serverAddress: endpoint.serverAddress,
+		stopChan:      make(chan struct{}),
+	}
+	nodes.store, nodes.controller = cache.NewInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				return client.Core().Nodes().List(options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				return client.Core().Nodes().Watch(options)
+			},
+		},
+		&api.Node{},
+		controller.NoResyncPeriodFunc(),
+		cache.ResourceEventHandlerFuncs{},
+	)
+	go nodes.controller.Run(nodes.stopChan)
+	self.nodes = nodes
+	return nodes
+}
+
+// endpointProbe is the result of the health check of an API server.
+type endpointProbe struct {
+	body []byte
+	err  error
+}
+
+func (p *endpointProbe) healthy() bool {
+	return p.err == nil && strings.EqualFold(string(p.body), "ok")
+}
+
+// probeEndpoints requests "/healthz" from every API server of the cluster at
+// the same time, so that the probes take at most the timeout of one request,
+// and returns the results in the order of the endpoints.
+func (self *ClusterClient) probeEndpoints() []endpointProbe {
+	probes := make([]endpointProbe, len(self.endpoints))
+	var wg sync.WaitGroup
+	for i := range self.endpoints {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			probes[i].body, probes[i].err = self.endpoints[i].kubeClient.DiscoveryClient.RESTClient().Get().AbsPath("/healthz").Do().Raw()
+		}(i)
+	}
+	wg.Wait()
+	return probes
+}
+
+// chooseActiveEndpoint returns the index of the endpoint the cluster should be
+// reached at. The active endpoint is kept while it is healthy, otherwise the
+// first healthy endpoint takes over. If no endpoint is healthy the active
+// endpoint is kept.
+func (self *ClusterClient) chooseActiveEndpoint(activeAddress string, probes []endpointProbe) int {
+	active := 0
+	for i, endpoint := range self.endpoints {
+		if endpoint.serverAddress == activeAddress {
+			active = i
+			break
+		}
+	}
+	if probes[active].healthy() {
+		return active
+	}
+	for i := range probes {
+		if probes[i].healthy() {
+			return i
+		}
+	}
+	return active
+}
+
 // GetClusterHealthStatus gets the kubernetes cluster health status by requesting "/healthz"
-func (self *ClusterClient) GetClusterHealthStatus() *federation_v1beta1.ClusterStatus {
+// from each API server of the cluster. The conditions reflect the health of the active
+// server, which fails over to another healthy server when it stops answering. The
+// servers are probed at the same time, and the discovery of the cluster is abandoned
+// once the timeout of the client has passed.
+func (self *ClusterClient) GetClusterHealthStatus(activeAddress string) *federation_v1beta1.ClusterStatus {
 	clusterStatus := federation_v1beta1.ClusterStatus{}
 	currentTime := metav1.Now()
+	deadline := currentTime.Add(self.timeout)
 	newClusterReadyCondition := federation_v1beta1.ClusterCondition{
 		Type:               federation_v1beta1.ClusterReady,
 		Status:             v1.ConditionTrue,
@@ -91,20 +288,56 @@ func (self *ClusterClient) GetClusterHealthStatus() *federation_v1beta1.ClusterS
 		LastProbeTime:      currentTime,
 		LastTransitionTime: currentTime,
 	}
-	body, err := self.kubeClient.DiscoveryClient.RESTClient().Get().AbsPath("/healthz").Do().Raw()
-	if err != nil {
+
+	probes := self.probeEndpoints()
+	for i, endpoint := range self.endpoints {
+		addressStatus := federation_v1beta1.ServerAddressStatus{
+			ServerAddress: endpoint.serverAddress,
+			Healthy:       probes[i].healthy(),
+			Message:       "/healthz responded with ok",
+			LastProbeTime: currentTime,
+		}
+		if probes[i].err != nil {
+			addressStatus.Message = fmt.Sprintf("server is not reachable: %v", probes[i].err)
+		} else if !addressStatus.Healthy {
+			addressStatus.Message = "/healthz responded without ok"
+		}
+		clusterStatus.ServerAddresses = append(clusterStatus.ServerAddresses, addressStatus)
+	}
+	active := self.chooseActiveEndpoint(activeAddress, probes)
+	clusterStatus.ActiveServerAddress = self.endpoints[active].serverAddress
+
+	if probes[active].err != nil {
 		clusterStatus.Conditions = append(clusterStatus.Conditions, newNodeOfflineCondition)
 	} else {
-		if !strings.EqualFold(string(body), "ok") {
+		if !probes[active].healthy() {
 			clusterStatus.Conditions = append(clusterStatus.Conditions, newClusterNotReadyCondition, newNodeNotOfflineCondition)
 		} else {
 			clusterStatus.Conditions = append(clusterStatus.Conditions, newClusterReadyCondition)
 		}
 	}
 
-	zones, region, err := self.GetClusterZones()
+	if !probes[active].healthy() {
+		// Nothing else can be learned about the cluster.
+	} else if time.Now().After(deadline) {
+		glog.Warningf("Skipping the discovery of the cluster at %s, its health check took longer than %v", clusterStatus.ActiveServerAddress, self.timeout)
+	} else {
+		version, apiResources, err := getClusterCapabilitiesUntil(self.endpoints[active].kubeClient, deadline)
+		if err != nil {
+			glog.Warningf("Failed to discover the version and API resources of cluster at %s: %v", clusterStatus.ActiveServerAddress, err)
+		} else {
+			clusterStatus.Version = version
+			clusterStatus.APIResources = apiResources
+		}
+	}
+
+	if condition := self.getCredentialsCondition(currentTime); condition != nil {
+		clusterStatus.Conditions = append(clusterStatus.Conditions, *condition)
+	}
+
+	zones, region, err := self.getClusterZones(&self.endpoints[active], deadline)
 	if err != nil {
-		glog.Warningf("Failed to get zones and region for cluster with client %v: %v", self, err)
+		glog.Warningf("Failed to get zones and region for cluster at %s: %v", clusterStatus.ActiveServerAddress, err)
 	} else {
 		clusterStatus.Zones = zones
 		clusterStatus.Region = region
@@ -113,9 +346,76 @@ func (self *ClusterClient) GetClusterHealthStatus() *federation_v1beta1.ClusterS
 	return &clusterStatus
 }
 
-// GetClusterZones gets the kubernetes cluster zones and region by inspecting labels on nodes in the cluster.
-func (self *ClusterClient) GetClusterZones() (zones []string, region string, err error) {
-	return getZoneNames(self.kubeClient)
+// clusterCapabilities is the result of the discovery of a cluster.
+type clusterCapabilities struct {
+	version      string
+	apiResources []federation_v1beta1.ClusterAPIResources
+	err          error
+}
+
+// getClusterCapabilitiesUntil discovers the version and the API resources of
+// the cluster, giving up at the deadline. Discovery issues a request per API
+// group, each of them bounded only by the timeout of the client, so it is run
+// aside and abandoned once the deadline has passed.
+func getClusterCapabilitiesUntil(client *clientset.Clientset, deadline time.Time) (string, []federation_v1beta1.ClusterAPIResources, error) {
+	// Buffered so that an abandoned discovery does not leak its goroutine.
+	result := make(chan clusterCapabilities, 1)
+	go func() {
+		var c clusterCapabilities
+		c.version, c.apiResources, c.err = getClusterCapabilities(client)
+		result <- c
+	}()
+	timer := time.NewTimer(time.Until(deadline))
+	defer timer.Stop()
+	select {
+	case c := <-result:
+		return c.version, c.apiResources, c.err
+	case <-timer.C:
+		return "", nil, fmt.Errorf("discovery did not finish before the deadline")
+	}
+}
+
+// getClusterCapabilities discovers the Kubernetes version of the cluster and
+// the API resources it serves.
+func getClusterCapabilities(client *clientset.Clientset) (string, []federation_v1beta1.ClusterAPIResources, error) {
+	version, err := client.Discovery().ServerVersion()
+	if err != nil {
+		return "", nil, err
+	}
+	resourceLists, err := client.Discovery().ServerResources()
+	// The resources of the API groups that fail to be discovered, typically
+	// aggregated ones, are left out.
+	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
+		return "", nil, err
+	}
+	return version.GitVersion, util.ClusterAPIResources(resourceLists), nil
+}
+
+// getCredentialsCondition returns the condition reporting the expiry of the
+// credentials of the client, or nil if their expiry is not known.
+func (self *ClusterClient) getCredentialsCondition(currentTime metav1.Time) *federation_v1beta1.ClusterCondition {
+	if self.credentialsExpiry == nil {
+		return nil
+	}
+	expiry := self.credentialsExpiry.UTC().Format(time.RFC3339)
+	condition := &federation_v1beta1.ClusterCondition{
+		Type:               federation_v1beta1.ClusterCredentialsExpiring,
+		Status:             v1.ConditionFalse,
+		Reason:             "CredentialsValid",
+		Message:            fmt.Sprintf("%s expires at %s", self.credentialsKind, expiry),
+		LastProbeTime:      currentTime,
+		LastTransitionTime: currentTime,
+	}
+	switch {
+	case !currentTime.Time.Before(*self.credentialsExpiry):
+		condition.Status = v1.ConditionTrue
+		condition.Reason = "CredentialsExpired"
+		condition.Message = fmt.Sprintf("%s expired at %s", self.credentialsKind, expiry)
+	case currentTime.Time.Add(credentialsExpiryWarningPeriod).After(*self.credentialsExpiry):
+		condition.Status = v1.ConditionTrue
+		condition.Reason = "CredentialsExpiring"
+	}
+	return condition
 }
 
 // Find the name of the zone in which a Node is running
@@ -140,27 +440,52 @@ func getRegionNameForNode(node api.Node) (string, error) {
 		node.Name, kubeletapis.LabelZoneRegion)
 }
 
+// getClusterZones returns the zones and the region of the nodes of the
+// cluster, read from the cache of its nodes. Waits for the cache to be synced
+// until the deadline.
+func (self *ClusterClient) getClusterZones(endpoint *clusterEndpoint, deadline time.Time) (zones []string, region string, err error) {
+	nodes := self.getNodeCache(endpoint)
+	if nodes == nil {
+		return nil, "", fmt.Errorf("the client of the cluster is stopped")
+	}
+	if !nodes.controller.HasSynced() {
+		// A zero timeout would wait forever.
+		remaining := time.Until(deadline)
+		if remaining > 0 {
+			err = wait.PollImmediate(100*time.Millisecond, remaining, func() (bool, error) {
+				return nodes.controller.HasSynced(), nil
+			})
+		}
+		if remaining <= 0 || err != nil {
+			return nil, "", fmt.Errorf("the nodes of the cluster were not synced in time")
+		}
+	}
+	return getZoneNames(nodes.store.List())
+}
+
 // Find the names of all zones and the region in which we have nodes in this cluster.
-func getZoneNames(client *clientset.Clientset) (zones []string, region string, err error) {
-	zoneNames := sets.NewString()
-	nodes, err := client.Core().Nodes().List(metav1.ListOptions{})
-	if err != nil {
-		glog.Errorf("Failed to list nodes while getting zone names: %v", err)
-		return nil, "", err
+func getZoneNames(objs []interface{}) (zones []string, region string, err error) {
+	nodes := make([]*api.Node, 0, len(objs))
+	for _, obj := range objs {
+		nodes = append(nodes, obj.(*api.Node))
 	}
-	for i, node := range nodes.Items {
+	// Node lists are sorted by name, the region is read from the first node.
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+
+	zoneNames := sets.NewString()
+	for i, node := range nodes {
 		// TODO: quinton-hoole make this more efficient.
 		//       For non-multi-zone clusters the zone will
 		//       be identical for all nodes, so we only need to look at one node
 		//       For multi-zone clusters we know at build time
 		//       which zones are included.  Rather get this info from there, because it's cheaper.
-		zoneName, err := getZoneNameForNode(node)
+		zoneName, err := getZoneNameForNode(*node)
 		if err != nil {
 			return nil, "", err
 		}
 		zoneNames.Insert(zoneName)
 		if i == 0 {
-			region, err = getRegionNameForNode(node)
+			region, err = getRegionNameForNode(*node)
 			if err != nil {
 				return nil, "", err
 			}
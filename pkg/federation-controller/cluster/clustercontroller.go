@@ -17,22 +17,30 @@ limitations under the License.
 package cluster
 
 import (
+	"fmt"
+	"reflect"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/golang/glog"
+	"k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/apimachinery/pkg/watch"
 	restclient "k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	federationapi "k8s.io/federation/apis/federation"
 	federationv1beta1 "k8s.io/federation/apis/federation/v1beta1"
 	clustercache "k8s.io/federation/client/cache"
 	federationclientset "k8s.io/federation/client/clientset_generated/federation_clientset"
+	"k8s.io/federation/pkg/federation-controller/util"
 	"k8s.io/kubernetes/pkg/controller"
 )
 
@@ -42,36 +50,86 @@ type ClusterController struct {
 
 	// clusterMonitorPeriod is the period for updating status of cluster
 	clusterMonitorPeriod time.Duration
+	// probeOptions control how the health of clusters is checked
+	probeOptions ProbeOptions
 
 	mu              sync.RWMutex
 	knownClusterSet sets.String
 	// clusterClusterStatusMap is a mapping of clusterName and cluster status of last sampling
 	clusterClusterStatusMap map[string]federationv1beta1.ClusterStatus
 	// clusterKubeClientMap is a mapping of clusterName and restclient
-	clusterKubeClientMap map[string]ClusterClient
+	clusterKubeClientMap map[string]*ClusterClient
+
+	// requiredResources are the API resources the sync controller of each
+	// federated kind requires the clusters to serve.
+	requiredResources map[string][]schema.GroupVersionResource
 
 	// cluster framework and store
 	clusterController cache.Controller
 	clusterStore      clustercache.StoreToClusterLister
 }
 
-// StartClusterController starts a new cluster controller
-func StartClusterController(config *restclient.Config, stopChan <-chan struct{}, clusterMonitorPeriod time.Duration) {
+const (
+	defaultProbeWorkers = 10
+	defaultProbeTimeout = 10 * time.Second
+	defaultAgentTimeout = 2 * time.Minute
+)
+
+// ProbeOptions control how the cluster controller checks the health of the
+// member clusters.
+type ProbeOptions struct {
+	// Thresholds dampen the changes of the readiness of clusters.
+	Thresholds ProbeThresholds
+	// Workers is the number of clusters probed concurrently, 10 if not set.
+	Workers int
+	// Timeout bounds the time spent probing a single cluster, 10s if not set.
+	Timeout time.Duration
+	// AgentTimeout is the time after which a cluster in pull mode whose agent
+	// stopped reporting its status is reported offline, 2m if not set.
+	AgentTimeout time.Duration
+}
+
+func (o ProbeOptions) workers() int {
+	if o.Workers < 1 {
+		return defaultProbeWorkers
+	}
+	return o.Workers
+}
+
+func (o ProbeOptions) timeout() time.Duration {
+	if o.Timeout <= 0 {
+		return defaultProbeTimeout
+	}
+	return o.Timeout
+}
+
+func (o ProbeOptions) agentTimeout() time.Duration {
+	if o.AgentTimeout <= 0 {
+		return defaultAgentTimeout
+	}
+	return o.AgentTimeout
+}
+
+// StartClusterController starts a new cluster controller. The status of the
+// clusters reports which of the given kinds they lack the resources for.
+func StartClusterController(config *restclient.Config, stopChan <-chan struct{}, clusterMonitorPeriod time.Duration, probeOptions ProbeOptions, requiredResources map[string][]schema.GroupVersionResource) {
 	restclient.AddUserAgent(config, "cluster-controller")
 	client := federationclientset.NewForConfigOrDie(config)
-	controller := newClusterController(client, clusterMonitorPeriod)
+	controller := newClusterController(client, clusterMonitorPeriod, probeOptions, requiredResources)
 	glog.Infof("Starting cluster controller")
 	controller.Run(stopChan)
 }
 
 // newClusterController returns a new cluster controller
-func newClusterController(federationClient federationclientset.Interface, clusterMonitorPeriod time.Duration) *ClusterController {
+func newClusterController(federationClient federationclientset.Interface, clusterMonitorPeriod time.Duration, probeOptions ProbeOptions, requiredResources map[string][]schema.GroupVersionResource) *ClusterController {
 	cc := &ClusterController{
 		knownClusterSet:         make(sets.String),
 		federationClient:        federationClient,
 		clusterMonitorPeriod:    clusterMonitorPeriod,
+		probeOptions:            probeOptions,
 		clusterClusterStatusMap: make(map[string]federationv1beta1.ClusterStatus),
-		clusterKubeClientMap:    make(map[string]ClusterClient),
+		clusterKubeClientMap:    make(map[string]*ClusterClient),
+		requiredResources:       requiredResources,
 	}
 	cc.clusterStore.Store, cc.clusterController = cache.NewInformer(
 		&cache.ListWatch{
@@ -87,6 +145,7 @@ func newClusterController(federationClient federationclientset.Interface, cluste
 		cache.ResourceEventHandlerFuncs{
 			DeleteFunc: cc.delFromClusterSet,
 			AddFunc:    cc.addToClusterSet,
+			UpdateFunc: cc.updateClusterClient,
 		},
 	)
 	return cc
@@ -107,6 +166,9 @@ func (cc *ClusterController) delFromClusterSet(obj interface{}) {
 func (cc *ClusterController) delFromClusterSetByName(clusterName string) {
 	glog.V(1).Infof("ClusterController observed a cluster deletion: %v", clusterName)
 	cc.knownClusterSet.Delete(clusterName)
+	if clusterClient, found := cc.clusterKubeClientMap[clusterName]; found {
+		clusterClient.Stop()
+	}
 	delete(cc.clusterKubeClientMap, clusterName)
 	delete(cc.clusterClusterStatusMap, clusterName)
 }
@@ -127,13 +189,54 @@ func (cc *ClusterController) addToClusterSetWithoutLock(cluster *federationv1bet
 	}
 	glog.V(1).Infof("ClusterController observed a new cluster: %v", cluster.Name)
 	cc.knownClusterSet.Insert(cluster.Name)
+	if util.IsClusterPullMode(cluster) {
+		// The agent of the cluster reports its status.
+		return
+	}
 	// create the restclient of cluster
-	restClient, err := NewClusterClientSet(cluster)
+	restClient, err := NewClusterClientSet(cluster, cc.probeOptions.timeout())
 	if err != nil || restClient == nil {
 		glog.Errorf("Failed to create corresponding restclient of kubernetes cluster: %v", err)
 		return
 	}
-	cc.clusterKubeClientMap[cluster.Name] = *restClient
+	cc.clusterKubeClientMap[cluster.Name] = restClient
+}
+
+// updateClusterClient recreates the restclient of a known cluster when its
+// address or credentials change, e.g. after the credentials were rotated. The
+// current restclient is kept if the new one can not be created.
+func (cc *ClusterController) updateClusterClient(oldObj, curObj interface{}) {
+	oldCluster := oldObj.(*federationv1beta1.Cluster)
+	curCluster := curObj.(*federationv1beta1.Cluster)
+	if reflect.DeepEqual(oldCluster.Spec, curCluster.Spec) &&
+		oldCluster.Annotations[federationapi.CredentialsRotatedAnnotation] == curCluster.Annotations[federationapi.CredentialsRotatedAnnotation] {
+		return
+	}
+
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	if !cc.knownClusterSet.Has(curCluster.Name) {
+		cc.addToClusterSetWithoutLock(curCluster)
+		return
+	}
+	glog.V(1).Infof("ClusterController observed a change of the address or credentials of cluster: %v", curCluster.Name)
+	if util.IsClusterPullMode(curCluster) {
+		if oldClient, found := cc.clusterKubeClientMap[curCluster.Name]; found {
+			oldClient.Stop()
+		}
+		delete(cc.clusterKubeClientMap, curCluster.Name)
+		delete(cc.clusterClusterStatusMap, curCluster.Name)
+		return
+	}
+	restClient, err := NewClusterClientSet(curCluster, cc.probeOptions.timeout())
+	if err != nil || restClient == nil {
+		glog.Errorf("Failed to recreate corresponding restclient of kubernetes cluster: %v", err)
+		return
+	}
+	if oldClient, found := cc.clusterKubeClientMap[curCluster.Name]; found {
+		oldClient.Stop()
+	}
+	cc.clusterKubeClientMap[curCluster.Name] = restClient
 }
 
 // Run begins watching and syncing.
@@ -155,55 +258,169 @@ func (cc *ClusterController) updateClusterStatus() error {
 		return err
 	}
 
-	for _, cluster := range clusters.Items {
-		cc.mu.RLock()
-		// skip updating status of the cluster which is not yet added to knownClusterSet.
-		if !cc.knownClusterSet.Has(cluster.Name) {
-			cc.mu.RUnlock()
-			continue
-		}
-		clusterClient, clientFound := cc.clusterKubeClientMap[cluster.Name]
-		clusterStatusOld, statusFound := cc.clusterClusterStatusMap[cluster.Name]
+	// A cluster that does not answer only delays the status of the others
+	// while all the workers wait for the timeout of their clusters.
+	workqueue.Parallelize(cc.probeOptions.workers(), len(clusters.Items), func(i int) {
+		cc.updateStatusOfCluster(&clusters.Items[i])
+	})
+	return nil
+}
+
+// updateStatusOfCluster checks the health of a known cluster and updates its status.
+func (cc *ClusterController) updateStatusOfCluster(cluster *federationv1beta1.Cluster) {
+	cc.mu.RLock()
+	// skip updating status of the cluster which is not yet added to knownClusterSet.
+	if !cc.knownClusterSet.Has(cluster.Name) {
 		cc.mu.RUnlock()
+		return
+	}
+	clusterClient, clientFound := cc.clusterKubeClientMap[cluster.Name]
+	clusterStatusOld, statusFound := cc.clusterClusterStatusMap[cluster.Name]
+	cc.mu.RUnlock()
 
-		if !clientFound {
-			glog.Warningf("Failed to get client for cluster %s", cluster.Name)
-			continue
-		}
-		clusterStatusNew := clusterClient.GetClusterHealthStatus()
-		if !statusFound {
-			glog.Infof("There is no status stored for cluster: %v before", cluster.Name)
+	if util.IsClusterPullMode(cluster) {
+		cc.updateStatusOfPullCluster(cluster)
+		return
+	}
+
+	if !clientFound {
+		glog.Warningf("Failed to get client for cluster %s", cluster.Name)
+		return
+	}
+	clusterStatusNew := clusterClient.GetClusterHealthStatus(cluster.Status.ActiveServerAddress)
+	if cluster.Status.ActiveServerAddress != "" && clusterStatusNew.ActiveServerAddress != cluster.Status.ActiveServerAddress {
+		glog.Infof("Cluster %s failed over from API server %s to %s", cluster.Name, cluster.Status.ActiveServerAddress, clusterStatusNew.ActiveServerAddress)
+	}
+	// Keep the last known version and resources of clusters that can not be discovered.
+	if clusterStatusNew.Version == "" {
+		clusterStatusNew.Version = cluster.Status.Version
+	}
+	if len(clusterStatusNew.APIResources) == 0 {
+		clusterStatusNew.APIResources = cluster.Status.APIResources
+	}
+	if condition := cc.getMissingResourcesCondition(clusterStatusNew); condition != nil {
+		clusterStatusNew.Conditions = append(clusterStatusNew.Conditions, *condition)
+	}
+	dampenClusterStatus(&cluster.Status, clusterStatusNew, cc.probeOptions.Thresholds)
+	if !statusFound {
+		glog.Infof("There is no status stored for cluster: %v before", cluster.Name)
+	} else {
+		hasTransition := false
+		if len(clusterStatusNew.Conditions) != len(clusterStatusOld.Conditions) {
+			hasTransition = true
 		} else {
-			hasTransition := false
-			if len(clusterStatusNew.Conditions) != len(clusterStatusOld.Conditions) {
-				hasTransition = true
-			} else {
-				for i := 0; i < len(clusterStatusNew.Conditions); i++ {
-					if !(strings.EqualFold(string(clusterStatusNew.Conditions[i].Type), string(clusterStatusOld.Conditions[i].Type)) &&
-						strings.EqualFold(string(clusterStatusNew.Conditions[i].Status), string(clusterStatusOld.Conditions[i].Status))) {
-						hasTransition = true
-						break
-					}
+			for i := 0; i < len(clusterStatusNew.Conditions); i++ {
+				if !(strings.EqualFold(string(clusterStatusNew.Conditions[i].Type), string(clusterStatusOld.Conditions[i].Type)) &&
+					strings.EqualFold(string(clusterStatusNew.Conditions[i].Status), string(clusterStatusOld.Conditions[i].Status))) {
+					hasTransition = true
+					break
 				}
 			}
+		}
 
-			if !hasTransition {
-				for j := 0; j < len(clusterStatusNew.Conditions); j++ {
-					clusterStatusNew.Conditions[j].LastTransitionTime = clusterStatusOld.Conditions[j].LastTransitionTime
-				}
+		if !hasTransition {
+			for j := 0; j < len(clusterStatusNew.Conditions); j++ {
+				clusterStatusNew.Conditions[j].LastTransitionTime = clusterStatusOld.Conditions[j].LastTransitionTime
 			}
 		}
+	}
 
-		cc.mu.Lock()
-		cc.clusterClusterStatusMap[cluster.Name] = *clusterStatusNew
-		cc.mu.Unlock()
-		cluster.Status = *clusterStatusNew
-		cluster, err := cc.federationClient.Federation().Clusters().UpdateStatus(&cluster)
-		if err != nil {
-			glog.Warningf("Failed to update the status of cluster: %v ,error is : %v", cluster.Name, err)
-			// Don't return err here, as we want to continue processing remaining clusters.
+	cc.mu.Lock()
+	cc.clusterClusterStatusMap[cluster.Name] = *clusterStatusNew
+	cc.mu.Unlock()
+	cluster.Status = *clusterStatusNew
+	if _, err := cc.federationClient.Federation().Clusters().UpdateStatus(cluster); err != nil {
+		// Don't return err here, as we want to continue processing remaining clusters.
+		glog.Warningf("Failed to update the status of cluster: %v ,error is : %v", cluster.Name, err)
+	}
+}
+
+// updateStatusOfPullCluster reports a cluster in pull mode offline once its
+// agent stopped reporting its status.
+func (cc *ClusterController) updateStatusOfPullCluster(cluster *federationv1beta1.Cluster) {
+	status := getAgentTimeoutStatus(&cluster.Status, metav1.Now(), cc.probeOptions.agentTimeout())
+	if status == nil {
+		return
+	}
+	glog.Infof("The agent of cluster %s stopped reporting its status", cluster.Name)
+	cluster.Status = *status
+	if _, err := cc.federationClient.Federation().Clusters().UpdateStatus(cluster); err != nil {
+		glog.Warningf("Failed to update the status of cluster: %v ,error is : %v", cluster.Name, err)
+	}
+}
+
+// getAgentTimeoutStatus returns the status of a cluster in pull mode whose
+// agent last reported the given status longer than the timeout ago, or nil
+// if the agent reported recently or the cluster is already offline.
+func getAgentTimeoutStatus(status *federationv1beta1.ClusterStatus, currentTime metav1.Time, timeout time.Duration) *federationv1beta1.ClusterStatus {
+	var lastReport time.Time
+	for _, condition := range status.Conditions {
+		if condition.Type == federationv1beta1.ClusterOffline && condition.Status == v1.ConditionTrue {
+			return nil
+		}
+		if condition.LastProbeTime.Time.After(lastReport) {
+			lastReport = condition.LastProbeTime.Time
+		}
+	}
+	if currentTime.Time.Sub(lastReport) < timeout {
+		return nil
+	}
+	message := "the agent of the cluster has not reported its status"
+	if !lastReport.IsZero() {
+		message = fmt.Sprintf("the agent of the cluster last reported its status at %s", lastReport.UTC().Format(time.RFC3339))
+	}
+	newStatus := status.DeepCopy()
+	newStatus.Conditions = []federationv1beta1.ClusterCondition{{
+		Type:               federationv1beta1.ClusterOffline,
+		Status:             v1.ConditionTrue,
+		Reason:             "AgentNotReporting",
+		Message:            message,
+		LastProbeTime:      currentTime,
+		LastTransitionTime: currentTime,
+	}}
+	return newStatus
+}
+
+// getMissingResourcesCondition returns the condition reporting the federated
+// kinds whose sync controllers skip the cluster because it does not serve
+// their resources, or nil if the resources of the cluster are not known.
+func (cc *ClusterController) getMissingResourcesCondition(status *federationv1beta1.ClusterStatus) *federationv1beta1.ClusterCondition {
+	if len(status.APIResources) == 0 {
+		return nil
+	}
+	kinds := make([]string, 0, len(cc.requiredResources))
+	for kind := range cc.requiredResources {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+
+	cluster := &federationv1beta1.Cluster{Status: *status}
+	var skipped []string
+	for _, kind := range kinds {
+		missing := util.ClusterMissingResources(cluster, cc.requiredResources[kind])
+		if len(missing) == 0 {
 			continue
 		}
+		resources := make([]string, 0, len(missing))
+		for _, resource := range missing {
+			resources = append(resources, resource.GroupVersion().String()+"/"+resource.Resource)
+		}
+		skipped = append(skipped, fmt.Sprintf("%s (missing %s)", kind, strings.Join(resources, ", ")))
 	}
-	return nil
+
+	currentTime := metav1.Now()
+	condition := &federationv1beta1.ClusterCondition{
+		Type:               federationv1beta1.ClusterMissingResources,
+		Status:             v1.ConditionFalse,
+		Reason:             "ResourcesServed",
+		Message:            "cluster serves the resources of all federated types",
+		LastProbeTime:      currentTime,
+		LastTransitionTime: currentTime,
+	}
+	if len(skipped) > 0 {
+		condition.Status = v1.ConditionTrue
+		condition.Reason = "MissingResources"
+		condition.Message = fmt.Sprintf("skipped by the sync controllers of %s", strings.Join(skipped, "; "))
+	}
+	return condition
 }
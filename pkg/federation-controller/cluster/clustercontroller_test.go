@@ -21,11 +21,14 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/uuid"
 	restclient "k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -123,7 +126,7 @@ func TestUpdateClusterStatusOK(t *testing.T) {
 	}
 	federationClientSet := federationclientset.NewForConfigOrDie(restclient.AddUserAgent(restClientCfg, "cluster-controller"))
 
-	manager := newClusterController(federationClientSet, 5)
+	manager := newClusterController(federationClientSet, 5, ProbeOptions{Timeout: time.Second}, nil)
 	manager.addToClusterSet(federationCluster)
 	err = manager.updateClusterStatus()
 	if err != nil {
@@ -158,7 +161,7 @@ func TestUpdateClusterRace(t *testing.T) {
 	}
 	federationClientSet := federationclientset.NewForConfigOrDie(restclient.AddUserAgent(restClientCfg, "cluster-controller"))
 
-	manager := newClusterController(federationClientSet, 1*time.Millisecond)
+	manager := newClusterController(federationClientSet, 1*time.Millisecond, ProbeOptions{Timeout: time.Second}, nil)
 
 	stop := make(chan struct{})
 	manager.Run(stop)
@@ -171,3 +174,456 @@ func TestUpdateClusterRace(t *testing.T) {
 
 	close(stop)
 }
+
+func TestCredentialsCondition(t *testing.T) {
+	now := metav1.Now()
+	expiry := func(d time.Duration) *time.Time {
+		t := now.Add(d)
+		return &t
+	}
+
+	tests := []struct {
+		note           string
+		expiry         *time.Time
+		expectedStatus v1.ConditionStatus
+		expectedReason string
+	}{
+		{"unknown expiry", nil, "", ""},
+		{"valid", expiry(30 * 24 * time.Hour), v1.ConditionFalse, "CredentialsValid"},
+		{"expiring", expiry(time.Hour), v1.ConditionTrue, "CredentialsExpiring"},
+		{"expired", expiry(-time.Hour), v1.ConditionTrue, "CredentialsExpired"},
+	}
+
+	for _, tc := range tests {
+		client := &ClusterClient{credentialsExpiry: tc.expiry, credentialsKind: "token"}
+		condition := client.getCredentialsCondition(now)
+		if tc.expiry == nil {
+			if condition != nil {
+				t.Errorf("%s: expected no condition, got %v", tc.note, condition)
+			}
+			continue
+		}
+		if condition == nil {
+			t.Errorf("%s: expected a condition", tc.note)
+			continue
+		}
+		if condition.Type != federationv1beta1.ClusterCredentialsExpiring || condition.Status != tc.expectedStatus || condition.Reason != tc.expectedReason {
+			t.Errorf("%s: expected status %s and reason %s, got %v", tc.note, tc.expectedStatus, tc.expectedReason, condition)
+		}
+	}
+}
+
+// createHttptestHealthzHandler simulates a cluster apiserver whose "/healthz"
+// responds with ok while healthy is set.
+func createHttptestHealthzHandler(healthy *int32) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/healthz" && atomic.LoadInt32(healthy) == 1 {
+			fmt.Fprint(w, "ok")
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+}
+
+func TestClusterFailover(t *testing.T) {
+	healthyA, healthyB := int32(1), int32(1)
+	serverA := httptest.NewServer(createHttptestHealthzHandler(&healthyA))
+	defer serverA.Close()
+	serverB := httptest.NewServer(createHttptestHealthzHandler(&healthyB))
+	defer serverB.Close()
+
+	cluster := newCluster("foobarCluster", serverA.URL)
+	cluster.Spec.ServerAddressByClientCIDRs = append(cluster.Spec.ServerAddressByClientCIDRs, federationv1beta1.ServerAddressByClientCIDR{
+		ClientCIDR:    "0.0.0.0/0",
+		ServerAddress: serverB.URL,
+	})
+	client, err := NewClusterClientSet(cluster, 200*time.Millisecond)
+	if err != nil || client == nil {
+		t.Fatalf("Failed to create cluster client: %v", err)
+	}
+	defer client.Stop()
+
+	tests := []struct {
+		note            string
+		healthyA        int32
+		healthyB        int32
+		expectedActive  string
+		expectedHealthy []bool
+		expectedReady   bool
+	}{
+		{"first address is preferred", 1, 1, serverA.URL, []bool{true, true}, true},
+		{"fails over when the active server stops answering", 0, 1, serverB.URL, []bool{false, true}, true},
+		{"keeps the active server when the previous one recovers", 1, 1, serverB.URL, []bool{true, true}, true},
+		{"keeps the active server when no server is healthy", 0, 0, serverB.URL, []bool{false, false}, false},
+		{"fails back when it is the only healthy server", 1, 0, serverA.URL, []bool{true, false}, true},
+	}
+
+	active := ""
+	for _, tc := range tests {
+		atomic.StoreInt32(&healthyA, tc.healthyA)
+		atomic.StoreInt32(&healthyB, tc.healthyB)
+		status := client.GetClusterHealthStatus(active)
+		active = status.ActiveServerAddress
+		if active != tc.expectedActive {
+			t.Errorf("%s: expected active server %s, got %s", tc.note, tc.expectedActive, active)
+		}
+		if len(status.ServerAddresses) != len(tc.expectedHealthy) {
+			t.Errorf("%s: expected %d server addresses, got %v", tc.note, len(tc.expectedHealthy), status.ServerAddresses)
+			continue
+		}
+		for i, healthy := range tc.expectedHealthy {
+			if status.ServerAddresses[i].Healthy != healthy {
+				t.Errorf("%s: expected health %v for %s, got %v", tc.note, healthy, status.ServerAddresses[i].ServerAddress, status.ServerAddresses[i])
+			}
+		}
+		ready := status.Conditions[0].Type == federationv1beta1.ClusterReady && status.Conditions[0].Status == v1.ConditionTrue
+		if ready != tc.expectedReady {
+			t.Errorf("%s: expected ready %v, got %v", tc.note, tc.expectedReady, status.Conditions[0])
+		}
+	}
+}
+
+func TestClusterCapabilities(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/healthz":
+			fmt.Fprint(w, "ok")
+		case "/version":
+			fmt.Fprint(w, `{"major":"1","minor":"9","gitVersion":"v1.9.3"}`)
+		case "/api":
+			fmt.Fprint(w, `{"kind":"APIVersions","versions":["v1"]}`)
+		case "/apis":
+			fmt.Fprint(w, `{"kind":"APIGroupList","groups":[]}`)
+		case "/api/v1":
+			fmt.Fprint(w, `{"kind":"APIResourceList","groupVersion":"v1","resources":[{"name":"services","namespaced":true,"kind":"Service"},{"name":"services/status","namespaced":true,"kind":"Service"},{"name":"pods","namespaced":true,"kind":"Pod"}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClusterClientSet(newCluster("foobarCluster", server.URL), 200*time.Millisecond)
+	if err != nil || client == nil {
+		t.Fatalf("Failed to create cluster client: %v", err)
+	}
+	defer client.Stop()
+	status := client.GetClusterHealthStatus("")
+	if status.Version != "v1.9.3" {
+		t.Errorf("Expected version v1.9.3, got %q", status.Version)
+	}
+	expected := []federationv1beta1.ClusterAPIResources{{GroupVersion: "v1", Resources: []string{"pods", "services"}}}
+	if !reflect.DeepEqual(status.APIResources, expected) {
+		t.Errorf("Expected API resources %v, got %v", expected, status.APIResources)
+	}
+}
+
+func TestMissingResourcesCondition(t *testing.T) {
+	cc := &ClusterController{
+		requiredResources: map[string][]schema.GroupVersionResource{
+			"service":    {v1.SchemeGroupVersion.WithResource("services")},
+			"replicaset": {{Group: "extensions", Version: "v1beta1", Resource: "replicasets"}},
+		},
+	}
+
+	tests := []struct {
+		note            string
+		apiResources    []federationv1beta1.ClusterAPIResources
+		expectedStatus  v1.ConditionStatus
+		expectedMessage string
+	}{
+		{"unknown resources", nil, "", ""},
+		{
+			"all served",
+			[]federationv1beta1.ClusterAPIResources{
+				{GroupVersion: "extensions/v1beta1", Resources: []string{"replicasets"}},
+				{GroupVersion: "v1", Resources: []string{"services"}},
+			},
+			v1.ConditionFalse,
+			"cluster serves the resources of all federated types",
+		},
+		{
+			"missing",
+			[]federationv1beta1.ClusterAPIResources{
+				{GroupVersion: "v1", Resources: []string{"services"}},
+			},
+			v1.ConditionTrue,
+			"skipped by the sync controllers of replicaset (missing extensions/v1beta1/replicasets)",
+		},
+	}
+
+	for _, tc := range tests {
+		condition := cc.getMissingResourcesCondition(&federationv1beta1.ClusterStatus{APIResources: tc.apiResources})
+		if tc.apiResources == nil {
+			if condition != nil {
+				t.Errorf("%s: expected no condition, got %v", tc.note, condition)
+			}
+			continue
+		}
+		if condition == nil {
+			t.Errorf("%s: expected a condition", tc.note)
+			continue
+		}
+		if condition.Type != federationv1beta1.ClusterMissingResources || condition.Status != tc.expectedStatus || condition.Message != tc.expectedMessage {
+			t.Errorf("%s: expected status %s and message %q, got %v", tc.note, tc.expectedStatus, tc.expectedMessage, condition)
+		}
+	}
+}
+
+func TestDampenClusterStatus(t *testing.T) {
+	probed := func(healthy bool) *federationv1beta1.ClusterStatus {
+		now := metav1.Now()
+		if healthy {
+			return &federationv1beta1.ClusterStatus{Conditions: []federationv1beta1.ClusterCondition{
+				{Type: federationv1beta1.ClusterReady, Status: v1.ConditionTrue, LastProbeTime: now},
+			}}
+		}
+		return &federationv1beta1.ClusterStatus{Conditions: []federationv1beta1.ClusterCondition{
+			{Type: federationv1beta1.ClusterOffline, Status: v1.ConditionTrue, LastProbeTime: now},
+		}}
+	}
+	degraded := func(status *federationv1beta1.ClusterStatus) bool {
+		for _, condition := range status.Conditions {
+			if condition.Type == federationv1beta1.ClusterDegraded {
+				return condition.Status == v1.ConditionTrue
+			}
+		}
+		t.Fatalf("Missing degraded condition in %v", status)
+		return false
+	}
+
+	thresholds := ProbeThresholds{Success: 2, Failure: 3}
+	tests := []struct {
+		healthy          bool
+		expectedReady    bool
+		expectedDegraded bool
+	}{
+		// The first health check sets the readiness.
+		{true, true, false},
+		{false, true, true},
+		{false, true, true},
+		{true, true, false},
+		{false, true, true},
+		{false, true, true},
+		{false, false, false},
+		{true, false, false},
+		{true, true, false},
+	}
+
+	status := &federationv1beta1.ClusterStatus{}
+	for i, tc := range tests {
+		newStatus := probed(tc.healthy)
+		dampenClusterStatus(status, newStatus, thresholds)
+		if ready := isStatusReady(newStatus); ready != tc.expectedReady {
+			t.Errorf("probe %d: expected ready %v, got %v", i, tc.expectedReady, newStatus.Conditions)
+		}
+		if degraded(newStatus) != tc.expectedDegraded {
+			t.Errorf("probe %d: expected degraded %v, got %v", i, tc.expectedDegraded, newStatus.Conditions)
+		}
+		if last := newStatus.ProbeHistory[len(newStatus.ProbeHistory)-1]; last.Healthy != tc.healthy {
+			t.Errorf("probe %d: expected the health check to be recorded, got %v", i, newStatus.ProbeHistory)
+		}
+		status = newStatus
+	}
+	if len(status.ProbeHistory) != len(tests) {
+		t.Errorf("Expected %d health checks in the history, got %d", len(tests), len(status.ProbeHistory))
+	}
+
+	for i := 0; i < 2*defaultProbeHistoryLength; i++ {
+		newStatus := probed(true)
+		dampenClusterStatus(status, newStatus, thresholds)
+		status = newStatus
+	}
+	if len(status.ProbeHistory) != defaultProbeHistoryLength {
+		t.Errorf("Expected the history to be capped at %d health checks, got %d", defaultProbeHistoryLength, len(status.ProbeHistory))
+	}
+}
+
+func TestAgentTimeoutStatus(t *testing.T) {
+	now := metav1.Now()
+	reported := func(ago time.Duration, conditionType federationv1beta1.ClusterConditionType) *federationv1beta1.ClusterStatus {
+		return &federationv1beta1.ClusterStatus{
+			Version: "v1.9.0",
+			Conditions: []federationv1beta1.ClusterCondition{
+				{Type: conditionType, Status: v1.ConditionTrue, LastProbeTime: metav1.NewTime(now.Add(-ago))},
+			},
+		}
+	}
+	tests := map[string]struct {
+		status          *federationv1beta1.ClusterStatus
+		expectedOffline bool
+	}{
+		"recent report":            {reported(time.Minute, federationv1beta1.ClusterReady), false},
+		"stale report":             {reported(3*time.Minute, federationv1beta1.ClusterReady), true},
+		"never reported":           {&federationv1beta1.ClusterStatus{}, true},
+		"already reported offline": {reported(time.Hour, federationv1beta1.ClusterOffline), false},
+	}
+	for name, tc := range tests {
+		status := getAgentTimeoutStatus(tc.status, now, 2*time.Minute)
+		if !tc.expectedOffline {
+			if status != nil {
+				t.Errorf("%s: expected the status to be kept, got %v", name, status)
+			}
+			continue
+		}
+		if status == nil || len(status.Conditions) != 1 || status.Conditions[0].Type != federationv1beta1.ClusterOffline || status.Conditions[0].Reason != "AgentNotReporting" {
+			t.Errorf("%s: expected the cluster to be reported offline, got %v", name, status)
+			continue
+		}
+		if status.Version != tc.status.Version {
+			t.Errorf("%s: expected the version %q to be kept, got %q", name, tc.status.Version, status.Version)
+		}
+	}
+}
+
+func TestUpdateClusterStatusWithHungCluster(t *testing.T) {
+	hung := make(chan struct{})
+	hungServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-hung
+	}))
+	defer hungServer.Close()
+	defer close(hung)
+	healthy := int32(1)
+	healthyServer := httptest.NewServer(createHttptestHealthzHandler(&healthy))
+	defer healthyServer.Close()
+
+	hungCluster := newCluster("hungCluster", hungServer.URL)
+	healthyCluster := newCluster("healthyCluster", healthyServer.URL)
+	federationClusterList := newClusterList(hungCluster)
+	federationClusterList.Items = append(federationClusterList.Items, *healthyCluster)
+	testFederationServer := httptest.NewServer(createHttptestFakeHandlerForFederation(federationClusterList, true))
+	defer testFederationServer.Close()
+
+	restClientCfg, err := clientcmd.BuildConfigFromFlags(testFederationServer.URL, "")
+	if err != nil {
+		t.Fatalf("Failed to build client config")
+	}
+	federationClientSet := federationclientset.NewForConfigOrDie(restclient.AddUserAgent(restClientCfg, "cluster-controller"))
+
+	timeout := 500 * time.Millisecond
+	manager := newClusterController(federationClientSet, 5, ProbeOptions{Workers: 2, Timeout: timeout}, nil)
+	manager.addToClusterSet(hungCluster)
+	manager.addToClusterSet(healthyCluster)
+	defer manager.delFromClusterSet(hungCluster)
+	defer manager.delFromClusterSet(healthyCluster)
+
+	start := time.Now()
+	if err := manager.updateClusterStatus(); err != nil {
+		t.Fatalf("Failed to update cluster status: %v", err)
+	}
+	// Both clusters are probed at the same time, each for at most the
+	// timeout of the health check and the timeout of the discovery of zones.
+	if elapsed := time.Since(start); elapsed > 4*timeout {
+		t.Errorf("Expected the probes to finish within %v, took %v", 4*timeout, elapsed)
+	}
+
+	for name, expectedReady := range map[string]bool{"hungCluster": false, "healthyCluster": true} {
+		status, found := manager.clusterClusterStatusMap[name]
+		if !found {
+			t.Errorf("Expected the status of cluster %s to be updated", name)
+			continue
+		}
+		ready := status.Conditions[0].Type == federationv1beta1.ClusterReady && status.Conditions[0].Status == v1.ConditionTrue
+		if ready != expectedReady {
+			t.Errorf("Expected cluster %s to be ready %v, got %v", name, expectedReady, status.Conditions)
+		}
+	}
+}
+
+func TestClusterHealthStatusDeadline(t *testing.T) {
+	timeout := 500 * time.Millisecond
+	hung := make(chan struct{})
+	hungServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-hung
+	}))
+	defer hungServer.Close()
+	defer close(hung)
+	// Every discovery request but the version takes most of the timeout.
+	slowServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/healthz":
+			fmt.Fprint(w, "ok")
+		case "/version":
+			fmt.Fprint(w, `{"major":"1","minor":"9","gitVersion":"v1.9.3"}`)
+		default:
+			time.Sleep(timeout * 8 / 10)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer slowServer.Close()
+
+	cluster := newCluster("foobarCluster", hungServer.URL)
+	for _, serverAddress := range []string{hungServer.URL, slowServer.URL} {
+		cluster.Spec.ServerAddressByClientCIDRs = append(cluster.Spec.ServerAddressByClientCIDRs, federationv1beta1.ServerAddressByClientCIDR{
+			ClientCIDR:    "0.0.0.0/0",
+			ServerAddress: serverAddress,
+		})
+	}
+	client, err := NewClusterClientSet(cluster, timeout)
+	if err != nil || client == nil {
+		t.Fatalf("Failed to create cluster client: %v", err)
+	}
+	defer client.Stop()
+
+	start := time.Now()
+	status := client.GetClusterHealthStatus("")
+	// The servers are probed at the same time and the discovery is abandoned
+	// at the deadline, probing them one after the other would take three
+	// times the timeout.
+	if elapsed := time.Since(start); elapsed > 2*timeout {
+		t.Errorf("Expected the health check to finish within %v, took %v", 2*timeout, elapsed)
+	}
+	if status.ActiveServerAddress != slowServer.URL {
+		t.Errorf("Expected active server %s, got %s", slowServer.URL, status.ActiveServerAddress)
+	}
+	if status.Version != "" || len(status.APIResources) != 0 {
+		t.Errorf("Expected the discovery to be abandoned, got version %q and API resources %v", status.Version, status.APIResources)
+	}
+}
+
+func TestClusterZonesFromNodeCache(t *testing.T) {
+	var nodeLists int32
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/healthz":
+			fmt.Fprint(w, "ok")
+		case r.URL.Path == "/api/v1/nodes" && r.URL.Query().Get("watch") == "true":
+			// Keep the watch open until the end of the test.
+			select {
+			case <-r.Context().Done():
+			case <-done:
+			}
+		case r.URL.Path == "/api/v1/nodes":
+			atomic.AddInt32(&nodeLists, 1)
+			fmt.Fprint(w, `{"kind":"NodeList","apiVersion":"v1","metadata":{"resourceVersion":"1"},"items":[`+
+				`{"metadata":{"name":"node-b","labels":{"failure-domain.beta.kubernetes.io/zone":"us-east-1b","failure-domain.beta.kubernetes.io/region":"us-east-1"}}},`+
+				`{"metadata":{"name":"node-a","labels":{"failure-domain.beta.kubernetes.io/zone":"us-east-1a","failure-domain.beta.kubernetes.io/region":"us-east-1"}}}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	defer close(done)
+
+	client, err := NewClusterClientSet(newCluster("foobarCluster", server.URL), 5*time.Second)
+	if err != nil || client == nil {
+		t.Fatalf("Failed to create cluster client: %v", err)
+	}
+	defer client.Stop()
+
+	for i := 0; i < 3; i++ {
+		status := client.GetClusterHealthStatus("")
+		if expected := []string{"us-east-1a", "us-east-1b"}; !reflect.DeepEqual(status.Zones, expected) {
+			t.Errorf("Expected zones %v, got %v", expected, status.Zones)
+		}
+		if status.Region != "us-east-1" {
+			t.Errorf("Expected region us-east-1, got %q", status.Region)
+		}
+	}
+	if lists := atomic.LoadInt32(&nodeLists); lists != 1 {
+		t.Errorf("Expected the nodes to be listed once, got %d lists", lists)
+	}
+}
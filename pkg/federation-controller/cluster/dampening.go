@@ -0,0 +1,168 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"fmt"
+
+	"k8s.io/api/core/v1"
+	federation_v1beta1 "k8s.io/federation/apis/federation/v1beta1"
+)
+
+// defaultProbeHistoryLength is the number of health checks kept in the
+// status of a cluster, unless a threshold needs more.
+const defaultProbeHistoryLength = 10
+
+// ProbeThresholds are the numbers of consecutive health checks of a cluster
+// that must agree before its readiness changes. Thresholds lower than 1 are
+// treated as 1.
+type ProbeThresholds struct {
+	// Success is the number of successful health checks that make an
+	// unready cluster ready.
+	Success int
+	// Failure is the number of failed health checks that make a ready
+	// cluster unready.
+	Failure int
+}
+
+func (t ProbeThresholds) success() int {
+	if t.Success < 1 {
+		return 1
+	}
+	return t.Success
+}
+
+func (t ProbeThresholds) failure() int {
+	if t.Failure < 1 {
+		return 1
+	}
+	return t.Failure
+}
+
+func (t ProbeThresholds) historyLength() int {
+	length := defaultProbeHistoryLength
+	if t.success() > length {
+		length = t.success()
+	}
+	if t.failure() > length {
+		length = t.failure()
+	}
+	return length
+}
+
+// isReadinessCondition returns whether the condition is derived from the
+// latest health check alone.
+func isReadinessCondition(condition federation_v1beta1.ClusterCondition) bool {
+	return condition.Type == federation_v1beta1.ClusterReady || condition.Type == federation_v1beta1.ClusterOffline
+}
+
+func isStatusReady(status *federation_v1beta1.ClusterStatus) bool {
+	for _, condition := range status.Conditions {
+		if condition.Type == federation_v1beta1.ClusterReady {
+			return condition.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// probeResult returns the result of the latest health check of the cluster
+// as reported by the readiness conditions of its probed status.
+func probeResult(status *federation_v1beta1.ClusterStatus) federation_v1beta1.ClusterProbe {
+	probe := federation_v1beta1.ClusterProbe{Healthy: isStatusReady(status)}
+	for _, condition := range status.Conditions {
+		if isReadinessCondition(condition) {
+			probe.Time = condition.LastProbeTime
+			probe.Message = condition.Message
+			break
+		}
+	}
+	return probe
+}
+
+// consecutiveProbes returns how many of the latest probes in the history
+// have the same result as the last one.
+func consecutiveProbes(history []federation_v1beta1.ClusterProbe) int {
+	count := 0
+	for i := len(history) - 1; i >= 0 && history[i].Healthy == history[len(history)-1].Healthy; i-- {
+		count++
+	}
+	return count
+}
+
+// dampenClusterStatus records the latest health check of the cluster in the
+// probe history of its probed status, and changes the readiness of the
+// cluster only once enough consecutive health checks agree on it. Until then
+// the readiness conditions of the old status are kept, and a ready cluster
+// failing its health checks is reported as degraded.
+func dampenClusterStatus(oldStatus, newStatus *federation_v1beta1.ClusterStatus, thresholds ProbeThresholds) {
+	probe := probeResult(newStatus)
+	history := append(append([]federation_v1beta1.ClusterProbe{}, oldStatus.ProbeHistory...), probe)
+	if length := thresholds.historyLength(); len(history) > length {
+		history = history[len(history)-length:]
+	}
+	newStatus.ProbeHistory = history
+
+	degraded := federation_v1beta1.ClusterCondition{
+		Type:               federation_v1beta1.ClusterDegraded,
+		Status:             v1.ConditionFalse,
+		Reason:             "ClusterHealthy",
+		Message:            "the latest health checks agree with the readiness of the cluster",
+		LastProbeTime:      probe.Time,
+		LastTransitionTime: probe.Time,
+	}
+
+	wasReady := isStatusReady(oldStatus)
+	consecutive := consecutiveProbes(history)
+	keepOldReadiness := false
+	hasOldReadiness := false
+	for _, condition := range oldStatus.Conditions {
+		if isReadinessCondition(condition) {
+			hasOldReadiness = true
+			break
+		}
+	}
+	if hasOldReadiness && probe.Healthy != wasReady {
+		if probe.Healthy {
+			keepOldReadiness = consecutive < thresholds.success()
+		} else {
+			keepOldReadiness = consecutive < thresholds.failure()
+			if keepOldReadiness {
+				degraded.Status = v1.ConditionTrue
+				degraded.Reason = "HealthChecksFailing"
+				degraded.Message = fmt.Sprintf("%d consecutive health checks failed, the cluster becomes unready after %d: %s",
+					consecutive, thresholds.failure(), probe.Message)
+			}
+		}
+	}
+
+	if keepOldReadiness {
+		var conditions []federation_v1beta1.ClusterCondition
+		for _, condition := range oldStatus.Conditions {
+			if isReadinessCondition(condition) {
+				condition.LastProbeTime = probe.Time
+				conditions = append(conditions, condition)
+			}
+		}
+		for _, condition := range newStatus.Conditions {
+			if !isReadinessCondition(condition) {
+				conditions = append(conditions, condition)
+			}
+		}
+		newStatus.Conditions = conditions
+	}
+	newStatus.Conditions = append(newStatus.Conditions, degraded)
+}
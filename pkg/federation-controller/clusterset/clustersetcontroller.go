@@ -0,0 +1,175 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Package clusterset contains the controller that reports the members of
+// cluster sets in their status.
+package clusterset
+
+import (
+	"fmt"
+	"time"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	pkgruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	federationapi "k8s.io/federation/apis/federation/v1beta1"
+	federationclientset "k8s.io/federation/client/clientset_generated/federation_clientset"
+	fedutil "k8s.io/federation/pkg/federation-controller/util"
+	clustersetutil "k8s.io/federation/pkg/federation-controller/util/clusterset"
+	"k8s.io/kubernetes/pkg/controller"
+
+	"github.com/golang/glog"
+)
+
+const (
+	// UserAgentName is the user agent used in the federation client
+	UserAgentName = "Federation-ClusterSet-Controller"
+	// ControllerName is name of this controller
+	ControllerName = "clustersets"
+)
+
+// RequiredResources is the resource group version of the type this controller manages
+var RequiredResources = []schema.GroupVersionResource{federationapi.SchemeGroupVersion.WithResource("clustersets")}
+
+// ClusterSetController keeps the status of every cluster set up to date with
+// the clusters of the federation that are members of the set.
+type ClusterSetController struct {
+	client federationclientset.Interface
+
+	// Store and informer controller of the clusters of the federation.
+	clusterStore      cache.Store
+	clusterController cache.Controller
+	// Store and informer controller of the cluster sets.
+	setStore      cache.Store
+	setController cache.Controller
+
+	// Names of the cluster sets whose status needs to be updated.
+	queue workqueue.RateLimitingInterface
+}
+
+// NewClusterSetController returns a new cluster set controller.
+func NewClusterSetController(client federationclientset.Interface) *ClusterSetController {
+	c := &ClusterSetController{
+		client: client,
+		queue:  workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "clustersets"),
+	}
+
+	// Joining and unjoining clusters and changes of their labels may change
+	// the members of any set.
+	c.clusterStore, c.clusterController = cache.NewInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (pkgruntime.Object, error) {
+				return client.Federation().Clusters().List(options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				return client.Federation().Clusters().Watch(options)
+			},
+		},
+		&federationapi.Cluster{},
+		controller.NoResyncPeriodFunc(),
+		fedutil.NewTriggerOnMetaAndSpecChanges(func(obj pkgruntime.Object) { c.enqueueAll() }),
+	)
+
+	c.setStore, c.setController = clustersetutil.NewInformer(
+		client,
+		fedutil.NewTriggerOnMetaAndSpecChanges(func(obj pkgruntime.Object) {
+			c.queue.Add(obj.(*federationapi.ClusterSet).Name)
+		}),
+	)
+	return c
+}
+
+// Run starts updating the status of the cluster sets.
+func (c *ClusterSetController) Run(workers int, stopCh <-chan struct{}) {
+	defer runtime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	go c.clusterController.Run(stopCh)
+	go c.setController.Run(stopCh)
+	if !cache.WaitForCacheSync(stopCh, c.clusterController.HasSynced, c.setController.HasSynced) {
+		runtime.HandleError(fmt.Errorf("timed out waiting for the cluster and cluster set caches to sync"))
+		return
+	}
+	// Sets created while the caches synced are already queued; the sets
+	// whose clusters changed since the controller last ran are not.
+	c.enqueueAll()
+
+	for i := 0; i < workers; i++ {
+		go wait.Until(c.worker, time.Second, stopCh)
+	}
+	<-stopCh
+	glog.Infof("Shutting down ClusterSetController")
+}
+
+func (c *ClusterSetController) enqueueAll() {
+	for _, name := range c.setStore.ListKeys() {
+		c.queue.Add(name)
+	}
+}
+
+func (c *ClusterSetController) worker() {
+	for {
+		key, quit := c.queue.Get()
+		if quit {
+			return
+		}
+		err := c.updateStatus(key.(string))
+		if err == nil {
+			c.queue.Forget(key)
+		} else {
+			runtime.HandleError(fmt.Errorf("failed to update the status of cluster set %q: %v", key, err))
+			c.queue.AddRateLimited(key)
+		}
+		c.queue.Done(key)
+	}
+}
+
+// updateStatus writes the current members of the named cluster set to its
+// status.
+func (c *ClusterSetController) updateStatus(name string) error {
+	obj, exists, err := c.setStore.GetByKey(name)
+	if err != nil || !exists {
+		return err
+	}
+	set := obj.(*federationapi.ClusterSet)
+
+	var clusters []*federationapi.Cluster
+	for _, obj := range c.clusterStore.List() {
+		clusters = append(clusters, obj.(*federationapi.Cluster))
+	}
+	members, err := clustersetutil.Members(set, clusters)
+	if err != nil {
+		return err
+	}
+	if apiequality.Semantic.DeepEqual(members, set.Status.Clusters) {
+		return nil
+	}
+
+	updated := set.DeepCopy()
+	updated.Status.Clusters = members
+	glog.V(4).Infof("Cluster set %q has members %v", name, members)
+	_, err = c.client.Federation().ClusterSets().UpdateStatus(updated)
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
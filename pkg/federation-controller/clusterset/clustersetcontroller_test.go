@@ -0,0 +1,82 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package clusterset
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	core "k8s.io/client-go/testing"
+	federationapi "k8s.io/federation/apis/federation/v1beta1"
+	fedclientfake "k8s.io/federation/client/clientset_generated/federation_clientset/fake"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCluster(name string, labels map[string]string) *federationapi.Cluster {
+	return &federationapi.Cluster{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+}
+
+func TestUpdateStatus(t *testing.T) {
+	prodEU := &federationapi.ClusterSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod-eu"},
+		Spec: federationapi.ClusterSetSpec{
+			Clusters: []string{"canary", "missing"},
+			ClusterSelector: []federationapi.ClusterSelectorRequirement{
+				{Key: "region", Operator: "in", Values: []string{"eu"}},
+			},
+		},
+	}
+	upToDate := &federationapi.ClusterSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "up-to-date"},
+		Spec:       federationapi.ClusterSetSpec{Clusters: []string{"canary"}},
+		Status:     federationapi.ClusterSetStatus{Clusters: []string{"canary"}},
+	}
+
+	client := &fedclientfake.Clientset{}
+	var updated *federationapi.ClusterSet
+	client.AddReactor("update", "clustersets", func(action core.Action) (bool, runtime.Object, error) {
+		require.Equal(t, "status", action.GetSubresource())
+		updated = action.(core.UpdateAction).GetObject().(*federationapi.ClusterSet)
+		return true, updated, nil
+	})
+
+	c := NewClusterSetController(client)
+	for _, cluster := range []*federationapi.Cluster{
+		newCluster("eu-west", map[string]string{"region": "eu"}),
+		newCluster("us-east", map[string]string{"region": "us"}),
+		newCluster("canary", nil),
+		newCluster("eu-north", map[string]string{"region": "eu"}),
+	} {
+		c.clusterStore.Add(cluster)
+	}
+	c.setStore.Add(prodEU)
+	c.setStore.Add(upToDate)
+
+	require.NoError(t, c.updateStatus("prod-eu"))
+	require.NotNil(t, updated)
+	assert.Equal(t, []string{"canary", "eu-north", "eu-west"}, updated.Status.Clusters)
+	assert.Empty(t, prodEU.Status.Clusters, "the cached set must not be modified")
+
+	updated = nil
+	require.NoError(t, c.updateStatus("up-to-date"))
+	assert.Nil(t, updated, "status of a set that is up to date is not updated")
+
+	require.NoError(t, c.updateStatus("deleted"))
+	assert.Nil(t, updated)
+}
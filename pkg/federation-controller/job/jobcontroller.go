@@ -37,21 +37,25 @@ import (
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/flowcontrol"
 	"k8s.io/client-go/util/workqueue"
-	fed "k8s.io/federation/apis/federation"
 	fedv1 "k8s.io/federation/apis/federation/v1beta1"
 	fedclientset "k8s.io/federation/client/clientset_generated/federation_clientset"
 	fedutil "k8s.io/federation/pkg/federation-controller/util"
+	"k8s.io/federation/pkg/federation-controller/util/clusterset"
 	"k8s.io/federation/pkg/federation-controller/util/deletionhelper"
 	"k8s.io/federation/pkg/federation-controller/util/eventsink"
 	"k8s.io/federation/pkg/federation-controller/util/planner"
+	"k8s.io/federation/pkg/federation-controller/util/propagationpolicy"
 	"k8s.io/federation/pkg/federation-controller/util/replicapreferences"
+	"k8s.io/federation/pkg/federation-controller/util/scheduler"
 	"k8s.io/kubernetes/pkg/api/legacyscheme"
 	api "k8s.io/kubernetes/pkg/apis/core"
 	"k8s.io/kubernetes/pkg/controller"
 )
 
 const (
-	fedJobPreferencesAnnotation = "federation.kubernetes.io/job-preferences"
+	// FedJobPreferencesAnnotation is the annotation holding the json-serialized
+	// ReplicaAllocationPreferences of a federated job.
+	FedJobPreferencesAnnotation = "federation.kubernetes.io/job-preferences"
 	allClustersKey              = "THE_ALL_CLUSTER_KEY"
 	// UserAgentName is the user agent used in the federation client
 	UserAgentName = "Federation-Job-Controller"
@@ -80,6 +84,12 @@ type FederationJobController struct {
 
 	fedJobInformer fedutil.FederatedInformer
 
+	policyController cache.Controller
+	policyStore      cache.Store
+
+	clusterSetController cache.Controller
+	clusterSetStore      cache.Store
+
 	jobDeliverer     *fedutil.DelayingDeliverer
 	clusterDeliverer *fedutil.DelayingDeliverer
 	jobWorkQueue     workqueue.Interface
@@ -90,27 +100,24 @@ type FederationJobController struct {
 	// For events
 	eventRecorder record.EventRecorder
 
-	defaultPlanner *planner.Planner
-	deletionHelper *deletionhelper.DeletionHelper
+	schedulerFramework *scheduler.Framework
+	deletionHelper     *deletionhelper.DeletionHelper
 }
 
-// NewJobController creates a new federation job controller
-func NewJobController(fedClient fedclientset.Interface) *FederationJobController {
+// NewJobController creates a new federation job controller. Job parallelism
+// is placed using the given scheduler framework, or the default one if nil.
+func NewJobController(fedClient fedclientset.Interface, schedulerFramework *scheduler.Framework) *FederationJobController {
 	broadcaster := record.NewBroadcaster()
 	broadcaster.StartRecordingToSink(eventsink.NewFederatedEventSink(fedClient))
 	recorder := broadcaster.NewRecorder(legacyscheme.Scheme, clientv1.EventSource{Component: "federated-job-controller"})
 	fjc := &FederationJobController{
-		fedClient:        fedClient,
-		jobDeliverer:     fedutil.NewDelayingDeliverer(),
-		clusterDeliverer: fedutil.NewDelayingDeliverer(),
-		jobWorkQueue:     workqueue.New(),
-		jobBackoff:       flowcontrol.NewBackOff(backoffInitial, backoffMax),
-		defaultPlanner: planner.NewPlanner(&fed.ReplicaAllocationPreferences{
-			Clusters: map[string]fed.ClusterPreferences{
-				"*": {Weight: 1},
-			},
-		}),
-		eventRecorder: recorder,
+		fedClient:          fedClient,
+		jobDeliverer:       fedutil.NewDelayingDeliverer(),
+		clusterDeliverer:   fedutil.NewDelayingDeliverer(),
+		jobWorkQueue:       workqueue.New(),
+		jobBackoff:         flowcontrol.NewBackOff(backoffInitial, backoffMax),
+		schedulerFramework: schedulerFramework,
+		eventRecorder:      recorder,
 	}
 
 	jobFedInformerFactory := func(cluster *fedv1.Cluster, clientset kubeclientset.Interface) (cache.Store, cache.Controller) {
@@ -156,6 +163,22 @@ func NewJobController(fedClient fedclientset.Interface) *FederationJobController
 		),
 	)
 
+	// Propagation policies may set the preferences of any job.
+	fjc.policyStore, fjc.policyController = propagationpolicy.NewInformer(
+		fedClient,
+		fedutil.NewTriggerOnAllChanges(
+			func(obj runtime.Object) { fjc.clusterDeliverer.DeliverAfter(allClustersKey, nil, 0) },
+		),
+	)
+
+	// Jobs and their preferences may refer to any cluster set.
+	fjc.clusterSetStore, fjc.clusterSetController = clusterset.NewInformer(
+		fedClient,
+		fedutil.NewTriggerOnMetaAndSpecChanges(
+			func(obj runtime.Object) { fjc.clusterDeliverer.DeliverAfter(allClustersKey, nil, 0) },
+		),
+	)
+
 	fjc.fedUpdater = fedutil.NewFederatedUpdater(fjc.fedJobInformer, "job", updateTimeout, fjc.eventRecorder,
 		func(client kubeclientset.Interface, obj runtime.Object) error {
 			rs := obj.(*batchv1.Job)
@@ -197,6 +220,8 @@ func (fjc *FederationJobController) updateJob(obj runtime.Object) (runtime.Objec
 // Run starts the syncing of federation jobs to the clusters.
 func (fjc *FederationJobController) Run(workers int, stopCh <-chan struct{}) {
 	go fjc.jobController.Run(stopCh)
+	go fjc.policyController.Run(stopCh)
+	go fjc.clusterSetController.Run(stopCh)
 	fjc.fedJobInformer.Start()
 
 	fjc.jobDeliverer.StartWithHandler(func(item *fedutil.DelayingDelivererItem) {
@@ -243,6 +268,16 @@ func (fjc *FederationJobController) isSynced() bool {
 		glog.V(2).Infof("federation job list not synced")
 		return false
 	}
+
+	if !fjc.policyController.HasSynced() {
+		glog.V(2).Infof("propagation policy list not synced")
+		return false
+	}
+
+	if !fjc.clusterSetController.HasSynced() {
+		glog.V(2).Infof("cluster set list not synced")
+		return false
+	}
 	return true
 }
 
@@ -325,37 +360,73 @@ type scheduleResult struct {
 	Completions *int32
 }
 
-func (fjc *FederationJobController) schedule(fjob *batchv1.Job, clusters []*fedv1.Cluster) map[string]scheduleResult {
-	plnr := fjc.defaultPlanner
-	frsPref, err := replicapreferences.GetAllocationPreferences(fjob, fedJobPreferencesAnnotation)
+func (fjc *FederationJobController) schedule(fjob *batchv1.Job, clusters []*fedv1.Cluster) (map[string]scheduleResult, error) {
+	key := fjob.Namespace + "/" + fjob.Name
+	profile := fjc.schedulerFramework.ProfileFor(fjob)
+	feasibility, err := profile.Schedule(&scheduler.Context{
+		Object:   fjob,
+		Key:      key,
+		Clusters: clusters,
+	})
 	if err != nil {
-		glog.Warningf("Invalid job specific preference, use default. rs: %v, err: %v", fjob, err)
+		return nil, fmt.Errorf("scheduler profile %q failed: %v", profile.Name(), err)
+	}
+	// Jobs referring to a cluster set only run in its members.
+	_, nonMembers, err := clusterset.SelectClusters(fjc.clusterSetStore, fjob.Annotations, clusters, nil)
+	if err != nil {
+		return nil, err
 	}
-	if frsPref != nil { // create a new planner if user specified a preference
-		plnr = planner.NewPlanner(frsPref)
+	for _, cluster := range nonMembers {
+		feasibility.Reject(cluster.Name, "cluster is not a member of the cluster set")
+	}
+	// Drained clusters give up their parallelism, cordoned clusters keep it
+	// but do not start running new jobs.
+	for _, cluster := range clusters {
+		if fedutil.IsClusterDrained(cluster) {
+			feasibility.Reject(cluster.Name, "cluster is drained")
+		} else if fedutil.IsClusterCordoned(cluster) {
+			_, exists, err := fjc.fedJobInformer.GetTargetStore().GetByKey(cluster.Name, key)
+			if err != nil {
+				return nil, err
+			}
+			if !exists {
+				feasibility.Reject(cluster.Name, "cluster is cordoned")
+			}
+		}
 	}
 
+	frsPref, err := replicapreferences.GetAllocationPreferences(fjob, FedJobPreferencesAnnotation)
+	if err != nil {
+		glog.Warningf("Invalid job specific preference, use default. rs: %v, err: %v", fjob, err)
+	}
+	topology := planner.TopologyFromClusters(clusters)
+	plnr := planner.NewTopologyAwarePlanner(feasibility.Preferences(frsPref), topology)
+
 	parallelism := int64(*fjob.Spec.Parallelism)
-	var clusterNames []string
-	for _, cluster := range clusters {
-		clusterNames = append(clusterNames, cluster.Name)
+	parallelismResult, _ := plnr.Plan(parallelism, feasibility.Feasible, nil, nil, key)
+	if err := plnr.CheckTopology(parallelismResult); err != nil {
+		return nil, fmt.Errorf("failed to schedule %q: %v", key, err)
+	}
+	// Clusters rejected by the scheduler get no parallelism, so that jobs
+	// already running there are scaled down rather than left alone.
+	for clusterName := range feasibility.Infeasible {
+		parallelismResult[clusterName] = 0
 	}
-	parallelismResult, _ := plnr.Plan(parallelism, clusterNames, nil, nil, fjob.Namespace+"/"+fjob.Name)
 
 	if frsPref != nil {
 		for _, clusterPref := range frsPref.Clusters {
 			clusterPref.MinReplicas = 0
 			clusterPref.MaxReplicas = nil
 		}
-		plnr = planner.NewPlanner(frsPref)
+		plnr = planner.NewTopologyAwarePlanner(frsPref, topology)
 	}
-	clusterNames = nil
+	var clusterNames []string
 	for clusterName := range parallelismResult {
 		clusterNames = append(clusterNames, clusterName)
 	}
 	completionsResult := make(map[string]int64)
 	if fjob.Spec.Completions != nil {
-		completionsResult, _ = plnr.Plan(int64(*fjob.Spec.Completions), clusterNames, nil, nil, fjob.Namespace+"/"+fjob.Name)
+		completionsResult, _ = plnr.Plan(int64(*fjob.Spec.Completions), feasibility.Feasible, nil, nil, key)
 	}
 
 	results := make(map[string]scheduleResult)
@@ -371,7 +442,7 @@ func (fjc *FederationJobController) schedule(fjob *batchv1.Job, clusters []*fedv
 		results[clusterName] = result
 	}
 
-	return results
+	return results, nil
 }
 
 func (fjc *FederationJobController) reconcileJob(key string) (reconciliationStatus, error) {
@@ -421,7 +492,21 @@ func (fjc *FederationJobController) reconcileJob(key string) (reconciliationStat
 		return statusError, err
 	}
 
-	scheduleResult := fjc.schedule(fjob, clusters)
+	// The job with the preferences of its propagation policy, if any, scoped
+	// to their cluster set.
+	placementJob, err := propagationpolicy.PlacementObject(fjc.policyStore, "job", fjob, FedJobPreferencesAnnotation)
+	if err != nil {
+		return statusError, err
+	}
+	placementJob, err = clusterset.PlacementObject(fjc.clusterSetStore, placementJob, FedJobPreferencesAnnotation, clusters)
+	if err != nil {
+		return statusError, err
+	}
+
+	scheduleResult, err := fjc.schedule(placementJob.(*batchv1.Job), clusters)
+	if err != nil {
+		return statusError, err
+	}
 	glog.V(3).Infof("Start syncing local job %s: %s\n", key, spew.Sprintf("%v", scheduleResult))
 
 	fedStatus := batchv1.JobStatus{}
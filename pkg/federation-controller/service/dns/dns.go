@@ -290,10 +290,15 @@ func (s *ServiceDNSController) getHealthyEndpoints(clusterName string, service *
 
 	for _, lbClusterIngress := range serviceIngress.Items {
 		lbClusterName := lbClusterIngress.Cluster
-		lbZoneNames, lbRegionName, err := s.getClusterZoneNames(lbClusterName)
+		lbCluster, err := s.federationClient.Federation().Clusters().Get(lbClusterName, metav1.GetOptions{})
 		if err != nil {
 			return nil, nil, nil, err
 		}
+		// Traffic is moved away from drained clusters.
+		if util.IsClusterDrained(lbCluster) {
+			continue
+		}
+		lbZoneNames, lbRegionName := lbCluster.Status.Zones, lbCluster.Status.Region
 		for _, ingress := range lbClusterIngress.Items {
 			var address string
 			// We should get either an IP address or a hostname - use whichever one we get
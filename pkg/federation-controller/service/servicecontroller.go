@@ -32,6 +32,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/apimachinery/pkg/watch"
 	kubeclientset "k8s.io/client-go/kubernetes"
@@ -46,8 +47,10 @@ import (
 	"k8s.io/federation/pkg/federation-controller/service/ingress"
 	fedutil "k8s.io/federation/pkg/federation-controller/util"
 	"k8s.io/federation/pkg/federation-controller/util/clusterselector"
+	"k8s.io/federation/pkg/federation-controller/util/clusterset"
 	"k8s.io/federation/pkg/federation-controller/util/deletionhelper"
 	"k8s.io/federation/pkg/federation-controller/util/eventsink"
+	"k8s.io/federation/pkg/federation-controller/util/propagationpolicy"
 	"k8s.io/kubernetes/pkg/api/legacyscheme"
 	api "k8s.io/kubernetes/pkg/apis/core"
 	"k8s.io/kubernetes/pkg/controller"
@@ -76,8 +79,16 @@ type ServiceController struct {
 	// Watches changes to all services
 	serviceController cache.Controller
 	federatedInformer fedutil.FederatedInformer
-	eventBroadcaster  record.EventBroadcaster
-	eventRecorder     record.EventRecorder
+	// A store of propagation policies, populated by the policyController
+	policyStore cache.Store
+	// Watches changes to all propagation policies
+	policyController cache.Controller
+	// A store of cluster sets, populated by the clusterSetController
+	clusterSetStore cache.Store
+	// Watches changes to all cluster sets
+	clusterSetController cache.Controller
+	eventBroadcaster     record.EventBroadcaster
+	eventRecorder        record.EventRecorder
 	// services that need to be synced
 	queue *workqueue.Type
 
@@ -136,6 +147,20 @@ func New(federationClient fedclientset.Interface) *ServiceController {
 	)
 	s.serviceStore = corelisters.NewServiceLister(serviceIndexer)
 
+	s.policyStore, s.policyController = propagationpolicy.NewInformer(
+		federationClient,
+		fedutil.NewTriggerOnAllChanges(func(obj pkgruntime.Object) {
+			s.clusterDeliverer.DeliverAt(allClustersKey, nil, time.Now())
+		}),
+	)
+
+	s.clusterSetStore, s.clusterSetController = clusterset.NewInformer(
+		federationClient,
+		fedutil.NewTriggerOnMetaAndSpecChanges(func(obj pkgruntime.Object) {
+			s.clusterDeliverer.DeliverAt(allClustersKey, nil, time.Now())
+		}),
+	)
+
 	clusterLifecycle := fedutil.ClusterLifecycleHandlerFuncs{
 		ClusterAvailable: func(cluster *v1beta1.Cluster) {
 			s.clusterDeliverer.DeliverAfter(allClustersKey, nil, clusterAvailableDelay)
@@ -241,6 +266,9 @@ func (s *ServiceController) Run(workers int, stopCh <-chan struct{}) {
 	defer runtime.HandleCrash()
 	defer s.queue.ShutDown()
 
+	go s.policyController.Run(stopCh)
+	go s.clusterSetController.Run(stopCh)
+
 	s.federatedInformer.Start()
 	defer s.federatedInformer.Stop()
 
@@ -370,6 +398,14 @@ func (s *ServiceController) deliverService(key string, delay time.Duration, fail
 // Check whether all data stores are in sync. False is returned if any of the informer/stores is not yet synced with
 // the corresponding api server.
 func (s *ServiceController) isSynced() bool {
+	if !s.policyController.HasSynced() {
+		glog.V(2).Infof("Propagation policy list not synced")
+		return false
+	}
+	if !s.clusterSetController.HasSynced() {
+		glog.V(2).Infof("Cluster set list not synced")
+		return false
+	}
 	if !s.federatedInformer.ClustersSynced() {
 		glog.V(2).Infof("Cluster list not synced")
 		return false
@@ -455,12 +491,39 @@ func (s *ServiceController) reconcileService(key string) reconciliationStatus {
 		return statusRecoverableError
 	}
 
+	// The cluster selector of the propagation policy of the service applies
+	// unless the service has its own.
+	placementService, err := propagationpolicy.PlacementObject(s.policyStore, "service", fedService, "")
+	if err != nil {
+		runtime.HandleError(fmt.Errorf("Failed to apply propagation policy to service %s: %v", key, err))
+		return statusRecoverableError
+	}
+	placementAnnotations := placementService.(*v1.Service).Annotations
+	// Services referring to a cluster set are only propagated to its members.
+	_, nonMembers, err := clusterset.SelectClusters(s.clusterSetStore, placementAnnotations, clusters, nil)
+	if err != nil {
+		runtime.HandleError(fmt.Errorf("Failed to select the clusters of service %s: %v", key, err))
+		return statusRecoverableError
+	}
+	excluded := sets.NewString()
+	for _, cluster := range nonMembers {
+		excluded.Insert(cluster.Name)
+	}
+	sendToCluster := func(clusterName string) clusterSelectorFunc {
+		return func(clusterLabels map[string]string, _ map[string]string) (bool, error) {
+			if excluded.Has(clusterName) {
+				return false, nil
+			}
+			return clusterselector.SendToCluster(clusterLabels, placementAnnotations)
+		}
+	}
+
 	newLBStatus := newLoadbalancerStatus()
 	newServiceIngress := ingress.NewFederatedServiceIngress()
 	operations := make([]fedutil.FederatedOperation, 0)
 	for _, cluster := range clusters {
 		// Aggregate all operations to perform on all federated clusters
-		operation, err := getOperationsToPerformOnCluster(s.federatedInformer, cluster, fedService, clusterselector.SendToCluster)
+		operation, err := getOperationsToPerformOnCluster(s.federatedInformer, cluster, fedService, sendToCluster(cluster.Name))
 		if err != nil {
 			return statusRecoverableError
 		}
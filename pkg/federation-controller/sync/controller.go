@@ -24,6 +24,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	pkgruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/apimachinery/pkg/watch"
@@ -38,8 +39,10 @@ import (
 	"k8s.io/federation/pkg/federatedtypes"
 	"k8s.io/federation/pkg/federation-controller/util"
 	"k8s.io/federation/pkg/federation-controller/util/clusterselector"
+	"k8s.io/federation/pkg/federation-controller/util/clusterset"
 	"k8s.io/federation/pkg/federation-controller/util/deletionhelper"
 	"k8s.io/federation/pkg/federation-controller/util/eventsink"
+	"k8s.io/federation/pkg/federation-controller/util/propagationpolicy"
 	"k8s.io/kubernetes/pkg/api/legacyscheme"
 	api "k8s.io/kubernetes/pkg/apis/core"
 	"k8s.io/kubernetes/pkg/controller"
@@ -72,6 +75,14 @@ type FederationSyncController struct {
 	store cache.Store
 	// Informer controller for resources that should be federated.
 	controller cache.Controller
+	// Propagation policies deciding the placement of the resources.
+	policyStore cache.Store
+	// Informer controller for propagation policies.
+	policyController cache.Controller
+	// Cluster sets the placement of the resources may refer to.
+	clusterSetStore cache.Store
+	// Informer controller for cluster sets.
+	clusterSetController cache.Controller
 
 	// Work queue allowing parallel processing of resources
 	workQueue workqueue.Interface
@@ -98,7 +109,8 @@ func StartFederationSyncController(kind string, adapterFactory federatedtypes.Ad
 	restclient.AddUserAgent(config, fmt.Sprintf("federation-%s-controller", kind))
 	client := federationclientset.NewForConfigOrDie(config)
 	adapter := adapterFactory(client, config, adapterSpecificArgs)
-	controller := newFederationSyncController(client, adapter)
+	requiredResources := federatedtypes.FederatedTypes()[kind].RequiredResources
+	controller := newFederationSyncController(client, adapter, requiredResources)
 	if minimizeLatency {
 		controller.minimizeLatency()
 	}
@@ -106,8 +118,9 @@ func StartFederationSyncController(kind string, adapterFactory federatedtypes.Ad
 	controller.Run(stopChan)
 }
 
-// newFederationSyncController returns a new sync controller for the given client and type adapter
-func newFederationSyncController(client federationclientset.Interface, adapter federatedtypes.FederatedTypeAdapter) *FederationSyncController {
+// newFederationSyncController returns a new sync controller for the given client and type adapter.
+// Clusters that do not serve the required resources of the type are skipped.
+func newFederationSyncController(client federationclientset.Interface, adapter federatedtypes.FederatedTypeAdapter, requiredResources []schema.GroupVersionResource) *FederationSyncController {
 	broadcaster := record.NewBroadcaster()
 	broadcaster.StartRecordingToSink(eventsink.NewFederatedEventSink(client))
 	recorder := broadcaster.NewRecorder(legacyscheme.Scheme, v1.EventSource{Component: fmt.Sprintf("federation-%v-controller", adapter.Kind())})
@@ -142,9 +155,26 @@ func newFederationSyncController(client federationclientset.Interface, adapter f
 		controller.NoResyncPeriodFunc(),
 		util.NewTriggerOnAllChanges(func(obj pkgruntime.Object) { s.deliverObj(obj, 0, false) }))
 
+	// Informer on the propagation policies. A changed policy may change the
+	// placement of any resource, so all of them are reconciled.
+	s.policyStore, s.policyController = propagationpolicy.NewInformer(
+		client,
+		util.NewTriggerOnAllChanges(func(obj pkgruntime.Object) {
+			s.clusterDeliverer.DeliverAt(allClustersKey, nil, time.Now())
+		}))
+
+	// Informer on the cluster sets, whose members may change the placement
+	// of any resource referring to them.
+	s.clusterSetStore, s.clusterSetController = clusterset.NewInformer(
+		client,
+		util.NewTriggerOnMetaAndSpecChanges(func(obj pkgruntime.Object) {
+			s.clusterDeliverer.DeliverAt(allClustersKey, nil, time.Now())
+		}))
+
 	// Federated informer on the resource type in members of federation.
-	s.informer = util.NewFederatedInformer(
+	s.informer = util.NewFederatedInformerForResources(
 		client,
+		requiredResources,
 		func(cluster *federationapi.Cluster, targetClient kubeclientset.Interface) (cache.Store, cache.Controller) {
 			return cache.NewInformer(
 				&cache.ListWatch{
@@ -224,6 +254,8 @@ func (s *FederationSyncController) updateObject(obj pkgruntime.Object) (pkgrunti
 
 func (s *FederationSyncController) Run(stopChan <-chan struct{}) {
 	go s.controller.Run(stopChan)
+	go s.policyController.Run(stopChan)
+	go s.clusterSetController.Run(stopChan)
 	s.informer.Start()
 	s.deliverer.StartWithHandler(func(item *util.DelayingDelivererItem) {
 		s.workQueue.Add(item)
@@ -301,6 +333,14 @@ func (s *FederationSyncController) deliver(qualifiedName federatedtypes.Qualifie
 // Check whether all data stores are in sync. False is returned if any of the informer/stores is not yet
 // synced with the corresponding api server.
 func (s *FederationSyncController) isSynced() bool {
+	if !s.policyController.HasSynced() {
+		glog.V(2).Infof("Propagation policy list not synced")
+		return false
+	}
+	if !s.clusterSetController.HasSynced() {
+		glog.V(2).Infof("Cluster set list not synced")
+		return false
+	}
 	if !s.informer.ClustersSynced() {
 		glog.V(2).Infof("Cluster list not synced")
 		return false
@@ -367,6 +407,12 @@ func (s *FederationSyncController) reconcile(qualifiedName federatedtypes.Qualif
 		return statusError
 	}
 
+	placementObj, err := s.placementObject(obj)
+	if err != nil {
+		runtime.HandleError(fmt.Errorf("Failed to resolve the placement of %s %q: %v", kind, key, err))
+		return statusError
+	}
+
 	operationsAccessor := func(adapter federatedtypes.FederatedTypeAdapter, selectedClusters []*federationapi.Cluster, unselectedClusters []*federationapi.Cluster, obj pkgruntime.Object, schedulingInfo interface{}) ([]util.FederatedOperation, error) {
 		operations, err := clusterOperations(adapter, selectedClusters, unselectedClusters, obj, key, schedulingInfo, func(clusterName string) (interface{}, bool, error) {
 			return s.informer.GetTargetStore().GetByKey(clusterName, key)
@@ -377,17 +423,53 @@ func (s *FederationSyncController) reconcile(qualifiedName federatedtypes.Qualif
 		return operations, err
 	}
 
+	// Objects referring to a cluster set are only placed in its members.
+	selector := func(objMeta *metav1.ObjectMeta, sendToCluster func(map[string]string, map[string]string) (bool, error), clusters []*federationapi.Cluster) ([]*federationapi.Cluster, []*federationapi.Cluster, error) {
+		selected, unselected, err := selectClusters(s.clusterSetStore, objMeta, sendToCluster, clusters)
+		if err != nil {
+			runtime.HandleError(fmt.Errorf("Failed to select the clusters of %s %q: %v", kind, key, err))
+		}
+		return selected, unselected, err
+	}
+
 	return syncToClusters(
 		s.informer.GetReadyClusters,
 		operationsAccessor,
-		selectedClusters,
+		selector,
 		s.updater.Update,
 		s.adapter,
 		s.informer,
 		obj,
+		placementObj,
 	)
 }
 
+// placementObject returns the object to read the placement of the given
+// object from.
+func (s *FederationSyncController) placementObject(obj pkgruntime.Object) (pkgruntime.Object, error) {
+	clusters, err := s.informer.GetReadyClusters()
+	if err != nil {
+		return nil, err
+	}
+	return PlacementObject(s.adapter, s.policyStore, s.clusterSetStore, obj, clusters)
+}
+
+// PlacementObject returns the object to read the placement of the given object
+// from, with the annotations of the propagation policy that applies to it if
+// any, and its replica preferences scoped to their cluster set among the given
+// clusters.
+func PlacementObject(adapter federatedtypes.FederatedTypeAdapter, policyStore, clusterSetStore cache.Store, obj pkgruntime.Object, clusters []*federationapi.Cluster) (pkgruntime.Object, error) {
+	preferencesAnnotation := ""
+	if preferencesAdapter, ok := adapter.(federatedtypes.PreferencesAdapter); ok {
+		preferencesAnnotation = preferencesAdapter.PreferencesAnnotation()
+	}
+	placementObj, err := propagationpolicy.PlacementObject(policyStore, adapter.Kind(), obj, preferencesAnnotation)
+	if err != nil || preferencesAnnotation == "" {
+		return placementObj, err
+	}
+	return clusterset.PlacementObject(clusterSetStore, placementObj, preferencesAnnotation, clusters)
+}
+
 func (s *FederationSyncController) objFromCache(kind, key string) (pkgruntime.Object, error) {
 	cachedObj, exist, err := s.store.GetByKey(key)
 	if err != nil {
@@ -438,7 +520,9 @@ type clusterSelectorFunc func(*metav1.ObjectMeta, func(map[string]string, map[st
 type executionFunc func([]util.FederatedOperation) error
 
 // syncToClusters ensures that the state of the given object is synchronized to member clusters.
-func syncToClusters(clustersAccessor clustersAccessorFunc, operationsAccessor operationsFunc, selector clusterSelectorFunc, execute executionFunc, adapter federatedtypes.FederatedTypeAdapter, informer util.FederatedInformer, obj pkgruntime.Object) reconciliationStatus {
+// The clusters and the schedule are determined from placementObj, which is obj with the
+// annotations of its propagation policy, if any.
+func syncToClusters(clustersAccessor clustersAccessorFunc, operationsAccessor operationsFunc, selector clusterSelectorFunc, execute executionFunc, adapter federatedtypes.FederatedTypeAdapter, informer util.FederatedInformer, obj, placementObj pkgruntime.Object) reconciliationStatus {
 	kind := adapter.Kind()
 	key := federatedtypes.ObjectKey(adapter, obj)
 
@@ -450,8 +534,16 @@ func syncToClusters(clustersAccessor clustersAccessorFunc, operationsAccessor op
 		return statusNotSynced
 	}
 
-	selectedClusters, unselectedClusters, err := selector(adapter.ObjectMeta(obj), clusterselector.SendToCluster, clusters)
+	selectedClusters, unselectedClusters, err := selector(adapter.ObjectMeta(placementObj), clusterselector.SendToCluster, clusters)
+	if err != nil {
+		return statusError
+	}
+	selectedClusters, unselectedClusters, err = excludeUnschedulableClusters(selectedClusters, unselectedClusters, func(clusterName string) (bool, error) {
+		_, found, err := informer.GetTargetStore().GetByKey(clusterName, key)
+		return found, err
+	})
 	if err != nil {
+		runtime.HandleError(fmt.Errorf("Failed to check the placement of %s %q in degraded or cordoned clusters: %v", kind, key, err))
 		return statusError
 	}
 
@@ -461,7 +553,7 @@ func syncToClusters(clustersAccessor clustersAccessorFunc, operationsAccessor op
 		if !ok {
 			glog.Fatalf("Adapter for kind %q does not properly implement SchedulingAdapter.", kind)
 		}
-		schedulingInfo, err = schedulingAdapter.GetSchedule(obj, key, selectedClusters, informer)
+		schedulingInfo, err = schedulingAdapter.GetSchedule(placementObj, key, selectedClusters, informer)
 		if err != nil {
 			runtime.HandleError(fmt.Errorf("adapter.GetSchedule() failed on adapter for %s %q: %v", kind, key, err))
 			return statusError
@@ -486,6 +578,10 @@ func syncToClusters(clustersAccessor clustersAccessorFunc, operationsAccessor op
 	}
 
 	if len(operations) == 0 {
+		// Recheck objects whose schedule could not be applied at once.
+		if pending, ok := schedulingInfo.(federatedtypes.PendingScheduleInfo); ok && pending.SchedulePending() {
+			return statusNeedsRecheck
+		}
 		return statusAllOK
 	}
 
@@ -499,6 +595,29 @@ func syncToClusters(clustersAccessor clustersAccessorFunc, operationsAccessor op
 	return statusNeedsRecheck
 }
 
+// SelectClusters splits the given clusters into the clusters an object whose
+// placement is read from objMeta is placed in and the others, the way the sync
+// controller does before scheduling the object. placed tells whether the
+// object is already in a cluster, degraded and cordoned clusters only keep the
+// objects they hold.
+func SelectClusters(clusterSetStore cache.Store, objMeta *metav1.ObjectMeta, clusters []*federationapi.Cluster, placed func(clusterName string) (bool, error)) ([]*federationapi.Cluster, []*federationapi.Cluster, error) {
+	selected, unselected, err := selectClusters(clusterSetStore, objMeta, clusterselector.SendToCluster, clusters)
+	if err != nil {
+		return nil, nil, err
+	}
+	return excludeUnschedulableClusters(selected, unselected, placed)
+}
+
+// selectClusters selects the clusters matching the cluster selector
+// annotation of an object that are members of its cluster set, if any.
+func selectClusters(clusterSetStore cache.Store, objMeta *metav1.ObjectMeta, selector func(map[string]string, map[string]string) (bool, error), clusters []*federationapi.Cluster) ([]*federationapi.Cluster, []*federationapi.Cluster, error) {
+	selected, unselected, err := selectedClusters(objMeta, selector, clusters)
+	if err != nil {
+		return nil, nil, err
+	}
+	return clusterset.SelectClusters(clusterSetStore, objMeta.Annotations, selected, unselected)
+}
+
 // selectedClusters filters the provided clusters into two slices, one containing the clusters selected by selector and the other containing the rest of the provided clusters.
 func selectedClusters(objMeta *metav1.ObjectMeta, selector func(map[string]string, map[string]string) (bool, error), clusters []*federationapi.Cluster) ([]*federationapi.Cluster, []*federationapi.Cluster, error) {
 	selectedClusters := []*federationapi.Cluster{}
@@ -517,6 +636,35 @@ func selectedClusters(objMeta *metav1.ObjectMeta, selector func(map[string]strin
 	return selectedClusters, unselectedClusters, nil
 }
 
+// excludeUnschedulableClusters moves the selected clusters that are degraded or cordoned and
+// do not hold the object yet to the unselected clusters, so that the object is only placed in
+// them once they recover or are uncordoned. Such clusters already holding the object keep it.
+func excludeUnschedulableClusters(selectedClusters []*federationapi.Cluster, unselectedClusters []*federationapi.Cluster, placed func(clusterName string) (bool, error)) ([]*federationapi.Cluster, []*federationapi.Cluster, error) {
+	selected := make([]*federationapi.Cluster, 0, len(selectedClusters))
+	for _, cluster := range selectedClusters {
+		reason := ""
+		switch {
+		case util.IsClusterDegraded(cluster):
+			reason = "degraded"
+		case util.IsClusterCordoned(cluster):
+			reason = "cordoned"
+		}
+		if reason != "" {
+			found, err := placed(cluster.Name)
+			if err != nil {
+				return nil, nil, err
+			}
+			if !found {
+				glog.V(3).Infof("Cluster %q is %s, no new objects are placed in it", cluster.Name, reason)
+				unselectedClusters = append(unselectedClusters, cluster)
+				continue
+			}
+		}
+		selected = append(selected, cluster)
+	}
+	return selected, unselectedClusters, nil
+}
+
 type clusterObjectAccessorFunc func(clusterName string) (interface{}, bool, error)
 
 // clusterOperations returns the list of operations needed to synchronize the state of the given object to the provided clusters
@@ -93,6 +93,7 @@ func TestSyncToClusters(t *testing.T) {
 				adapter,
 				nil,
 				obj,
+				obj,
 			)
 			require.Equal(t, testCase.status, status, "Unexpected status!")
 		})
@@ -160,6 +161,38 @@ func TestSelectedClusters(t *testing.T) {
 	}
 }
 
+func TestExcludeUnschedulableClusters(t *testing.T) {
+	healthy := fedtest.NewCluster("healthy", apiv1.ConditionTrue)
+	degradedWithObject := fedtest.NewCluster("degraded-with-object", apiv1.ConditionTrue)
+	degradedWithoutObject := fedtest.NewCluster("degraded-without-object", apiv1.ConditionTrue)
+	cordonedWithObject := fedtest.NewCluster("cordoned-with-object", apiv1.ConditionTrue)
+	drainedWithoutObject := fedtest.NewCluster("drained-without-object", apiv1.ConditionTrue)
+	unselected := fedtest.NewCluster("unselected", apiv1.ConditionTrue)
+	for _, cluster := range []*federationapi.Cluster{degradedWithObject, degradedWithoutObject} {
+		cluster.Status.Conditions = append(cluster.Status.Conditions, federationapi.ClusterCondition{
+			Type:   federationapi.ClusterDegraded,
+			Status: apiv1.ConditionTrue,
+		})
+	}
+	cordonedWithObject.Spec.Maintenance = federationapi.ClusterCordoned
+	drainedWithoutObject.Spec.Maintenance = federationapi.ClusterDrained
+
+	selectedClusters, unselectedClusters, err := excludeUnschedulableClusters(
+		[]*federationapi.Cluster{healthy, degradedWithObject, degradedWithoutObject, cordonedWithObject, drainedWithoutObject},
+		[]*federationapi.Cluster{unselected},
+		func(clusterName string) (bool, error) {
+			return clusterName == degradedWithObject.Name || clusterName == cordonedWithObject.Name, nil
+		})
+	require.NoError(t, err)
+	require.Equal(t, []*federationapi.Cluster{healthy, degradedWithObject, cordonedWithObject}, selectedClusters)
+	require.Equal(t, []*federationapi.Cluster{unselected, degradedWithoutObject, drainedWithoutObject}, unselectedClusters)
+
+	_, _, err = excludeUnschedulableClusters([]*federationapi.Cluster{degradedWithObject}, nil, func(string) (bool, error) {
+		return false, errors.New("boom")
+	})
+	require.Error(t, err)
+}
+
 func TestClusterOperations(t *testing.T) {
 	adapter := &federatedtypes.SecretAdapter{}
 	obj := adapter.NewTestObject("foo")
@@ -0,0 +1,409 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/golang/glog"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/apimachinery/pkg/util/sets"
+	restclient "k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+
+	// Initialize the auth provider plugins, e.g. oidc and gcp, that member
+	// cluster credentials may use.
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+)
+
+const (
+	// execCredentialKind is the kind of the output of exec credential plugins.
+	execCredentialKind = "ExecCredential"
+	// tokenFileRefreshPeriod is how often token files, e.g. projected
+	// service account tokens, are read again.
+	tokenFileRefreshPeriod = time.Minute
+)
+
+// AllowedExecCommands are the commands that the exec credential plugins of
+// member cluster kubeconfigs may run. The kubeconfigs are read from secrets
+// that anyone allowed to write secrets in the federation namespace can
+// change, while the plugins run on the host and with the identity of the
+// federation control plane. Plugins are therefore only run if the operator
+// allowed their command, e.g. with --cluster-exec-commands. None are by
+// default.
+var AllowedExecCommands = sets.NewString()
+
+// ClusterAuthPersister persists the credentials of a member cluster that its
+// clients refresh, so that they survive restarts and are reused by the
+// clients built later for the cluster.
+type ClusterAuthPersister interface {
+	// AuthProviderPersister returns the persister of the refreshed auth
+	// provider config of the user, e.g. oidc tokens.
+	AuthProviderPersister(user string) restclient.AuthProviderConfigPersister
+	// ExecCredential returns the persisted credential of the exec
+	// credential plugin, or nil if there is none.
+	ExecCredential() *ExecCredentialStatus
+	// PersistExecCredential persists the credential last returned by the
+	// exec credential plugin.
+	PersistExecCredential(credential *ExecCredentialStatus) error
+}
+
+// ExecConfig is the configuration of an exec credential plugin of a kubeconfig
+// user. Plugins print an ExecCredential with a bearer token to stdout.
+type ExecConfig struct {
+	Command    string       `json:"command"`
+	Args       []string     `json:"args,omitempty"`
+	Env        []ExecEnvVar `json:"env,omitempty"`
+	APIVersion string       `json:"apiVersion,omitempty"`
+}
+
+// ExecEnvVar is an environment variable set for an exec credential plugin.
+type ExecEnvVar struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// ExecCredentialStatus is the credential returned by an exec credential
+// plugin.
+type ExecCredentialStatus struct {
+	Token               string       `json:"token"`
+	ExpirationTimestamp *metav1.Time `json:"expirationTimestamp,omitempty"`
+}
+
+// rawKubeconfig holds the parts of a kubeconfig needed to find the exec
+// credential plugin of a context, which clientcmd drops when loading.
+type rawKubeconfig struct {
+	CurrentContext string `json:"current-context"`
+	Contexts       []struct {
+		Name    string `json:"name"`
+		Context struct {
+			AuthInfo string `json:"user"`
+		} `json:"context"`
+	} `json:"contexts"`
+	AuthInfos []struct {
+		Name     string `json:"name"`
+		AuthInfo struct {
+			Exec json.RawMessage `json:"exec,omitempty"`
+		} `json:"user"`
+	} `json:"users"`
+}
+
+// KubeconfigExec returns the user of the given context, or of the current
+// context if empty, and the raw exec credential plugin configuration of that
+// user if any. The kubeconfigs are merged in order, the first to set a value
+// wins, as clientcmd does.
+func KubeconfigExec(kubeconfigs [][]byte, context string) (string, json.RawMessage, error) {
+	var configs []rawKubeconfig
+	for _, data := range kubeconfigs {
+		var config rawKubeconfig
+		if err := yaml.Unmarshal(data, &config); err != nil {
+			return "", nil, err
+		}
+		configs = append(configs, config)
+	}
+
+	for i := 0; context == "" && i < len(configs); i++ {
+		context = configs[i].CurrentContext
+	}
+	user := ""
+	for _, config := range configs {
+		for _, c := range config.Contexts {
+			if c.Name == context && user == "" {
+				user = c.Context.AuthInfo
+			}
+		}
+	}
+	if user == "" {
+		return "", nil, nil
+	}
+	for _, config := range configs {
+		for _, u := range config.AuthInfos {
+			if u.Name == user {
+				if len(u.AuthInfo.Exec) == 0 || bytes.Equal(u.AuthInfo.Exec, []byte("null")) {
+					return user, nil, nil
+				}
+				return user, u.AuthInfo.Exec, nil
+			}
+		}
+	}
+	return user, nil, nil
+}
+
+// ConfigureClusterAuth completes a config built from the given kubeconfig with
+// the credentials that clientcmd does not handle or does not refresh: exec
+// credential plugins and token files are run or read again as needed. Exec
+// credential plugins must run one of the AllowedExecCommands. Refreshed auth
+// provider and exec credentials are persisted with the persister, if any.
+func ConfigureClusterAuth(config *restclient.Config, kubeconfig []byte, persister ClusterAuthPersister) error {
+	if len(kubeconfig) == 0 {
+		return nil
+	}
+
+	user, rawExec, err := KubeconfigExec([][]byte{kubeconfig}, "")
+	if err != nil {
+		return err
+	}
+	if rawExec != nil {
+		execConfig, err := parseExecConfig(rawExec)
+		if err != nil {
+			return fmt.Errorf("user %q: %v", user, err)
+		}
+		if !AllowedExecCommands.Has(execConfig.Command) {
+			return fmt.Errorf("user %q: command %q of the exec credential plugin is not allowed", user, execConfig.Command)
+		}
+		configureExecAuth(config, execConfig, persister)
+		return nil
+	}
+
+	clientConfig, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return err
+	}
+	authInfo := clientConfig.AuthInfos[user]
+	if authInfo == nil {
+		return nil
+	}
+	if len(authInfo.Token) == 0 && len(authInfo.TokenFile) > 0 {
+		config.BearerToken = ""
+		wrapTransport(config, func(rt http.RoundTripper) http.RoundTripper {
+			return newTokenFileRoundTripper(authInfo.TokenFile, rt)
+		})
+	}
+	if config.AuthProvider != nil && persister != nil {
+		config.AuthConfigPersister = persister.AuthProviderPersister(user)
+	}
+	return nil
+}
+
+// ConfigureExecAuth makes config authenticate with the token of the exec
+// credential plugin with the given raw configuration. The plugin is trusted,
+// use ConfigureClusterAuth for the kubeconfigs of member clusters.
+func ConfigureExecAuth(config *restclient.Config, rawExec json.RawMessage) error {
+	execConfig, err := parseExecConfig(rawExec)
+	if err != nil {
+		return err
+	}
+	configureExecAuth(config, execConfig, nil)
+	return nil
+}
+
+func parseExecConfig(rawExec json.RawMessage) (*ExecConfig, error) {
+	execConfig := &ExecConfig{}
+	if err := json.Unmarshal(rawExec, execConfig); err != nil {
+		return nil, fmt.Errorf("invalid exec credential plugin: %v", err)
+	}
+	if execConfig.Command == "" {
+		return nil, fmt.Errorf("exec credential plugin has no command")
+	}
+	return execConfig, nil
+}
+
+func configureExecAuth(config *restclient.Config, execConfig *ExecConfig, persister ClusterAuthPersister) {
+	config.BearerToken = ""
+	wrapTransport(config, func(rt http.RoundTripper) http.RoundTripper {
+		return newExecRoundTripper(execConfig, rt, persister)
+	})
+}
+
+// wrapTransport adds the given wrapper to the transport wrappers of config.
+func wrapTransport(config *restclient.Config, wrapper func(http.RoundTripper) http.RoundTripper) {
+	existing := config.WrapTransport
+	if existing == nil {
+		config.WrapTransport = wrapper
+		return
+	}
+	config.WrapTransport = func(rt http.RoundTripper) http.RoundTripper {
+		return wrapper(existing(rt))
+	}
+}
+
+// execRoundTripper authenticates requests with the token of an exec
+// credential plugin. The token is cached until it expires or is rejected, and
+// persisted with the persister, if any.
+type execRoundTripper struct {
+	config    *ExecConfig
+	rt        http.RoundTripper
+	persister ClusterAuthPersister
+	run       func(config *ExecConfig) ([]byte, error)
+	now       func() time.Time
+
+	lock   sync.Mutex
+	token  string
+	expiry *time.Time
+}
+
+func newExecRoundTripper(config *ExecConfig, rt http.RoundTripper, persister ClusterAuthPersister) *execRoundTripper {
+	e := &execRoundTripper{
+		config:    config,
+		rt:        rt,
+		persister: persister,
+		run:       runExecPlugin,
+		now:       time.Now,
+	}
+	if persister != nil {
+		if credential := persister.ExecCredential(); credential != nil {
+			e.setCredential(credential)
+		}
+	}
+	return e
+}
+
+func (e *execRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if len(req.Header.Get("Authorization")) != 0 {
+		return e.rt.RoundTrip(req)
+	}
+	token, err := e.getToken()
+	if err != nil {
+		return nil, err
+	}
+	req = utilnet.CloneRequest(req)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := e.rt.RoundTrip(req)
+	if resp != nil && resp.StatusCode == http.StatusUnauthorized {
+		e.invalidate(token)
+	}
+	return resp, err
+}
+
+func (e *execRoundTripper) getToken() (string, error) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if e.token != "" && (e.expiry == nil || e.now().Before(*e.expiry)) {
+		return e.token, nil
+	}
+
+	out, err := e.run(e.config)
+	if err != nil {
+		return "", fmt.Errorf("exec credential plugin %q failed: %v", e.config.Command, err)
+	}
+	var credential struct {
+		Kind   string                `json:"kind"`
+		Status *ExecCredentialStatus `json:"status"`
+	}
+	if err := json.Unmarshal(out, &credential); err != nil {
+		return "", fmt.Errorf("invalid output of exec credential plugin %q: %v", e.config.Command, err)
+	}
+	if credential.Kind != execCredentialKind || credential.Status == nil || credential.Status.Token == "" {
+		return "", fmt.Errorf("exec credential plugin %q did not return an %s with a token", e.config.Command, execCredentialKind)
+	}
+	e.setCredential(credential.Status)
+	if e.persister != nil {
+		if err := e.persister.PersistExecCredential(credential.Status); err != nil {
+			glog.Warningf("Failed to persist the credential of exec credential plugin %q: %v", e.config.Command, err)
+		}
+	}
+	return e.token, nil
+}
+
+func (e *execRoundTripper) setCredential(credential *ExecCredentialStatus) {
+	e.token = credential.Token
+	e.expiry = nil
+	if credential.ExpirationTimestamp != nil {
+		expiry := credential.ExpirationTimestamp.Time
+		e.expiry = &expiry
+	}
+}
+
+// invalidate drops the cached token, unless it was replaced meanwhile.
+func (e *execRoundTripper) invalidate(token string) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if e.token == token {
+		glog.V(2).Infof("Token of exec credential plugin %q was rejected, running the plugin again", e.config.Command)
+		e.token = ""
+	}
+}
+
+func runExecPlugin(config *ExecConfig) ([]byte, error) {
+	cmd := exec.Command(config.Command, config.Args...)
+	cmd.Env = os.Environ()
+	for _, env := range config.Env {
+		cmd.Env = append(cmd.Env, env.Name+"="+env.Value)
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("%v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
+
+// tokenFileRoundTripper authenticates requests with the token of a file,
+// which is read again periodically so that rotated tokens are picked up.
+type tokenFileRoundTripper struct {
+	path string
+	rt   http.RoundTripper
+	now  func() time.Time
+
+	lock   sync.Mutex
+	token  string
+	readAt time.Time
+}
+
+func newTokenFileRoundTripper(path string, rt http.RoundTripper) *tokenFileRoundTripper {
+	return &tokenFileRoundTripper{
+		path: path,
+		rt:   rt,
+		now:  time.Now,
+	}
+}
+
+func (t *tokenFileRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if len(req.Header.Get("Authorization")) != 0 {
+		return t.rt.RoundTrip(req)
+	}
+	token, err := t.getToken()
+	if err != nil {
+		return nil, err
+	}
+	req = utilnet.CloneRequest(req)
+	req.Header.Set("Authorization", "Bearer "+token)
+	return t.rt.RoundTrip(req)
+}
+
+func (t *tokenFileRoundTripper) getToken() (string, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	now := t.now()
+	if t.token != "" && now.Sub(t.readAt) < tokenFileRefreshPeriod {
+		return t.token, nil
+	}
+	data, err := ioutil.ReadFile(t.path)
+	if err != nil {
+		if t.token != "" {
+			// Keep using the last token, the file may be being replaced.
+			glog.Warningf("Failed to read token file %s: %v", t.path, err)
+			return t.token, nil
+		}
+		return "", err
+	}
+	t.token = strings.TrimSpace(string(data))
+	t.readAt = now
+	return t.token, nil
+}
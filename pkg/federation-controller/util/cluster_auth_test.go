@@ -0,0 +1,311 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	restclient "k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const execKubeconfig = `
+apiVersion: v1
+kind: Config
+current-context: exec
+clusters:
+- name: cluster
+  cluster:
+    server: https://example.com
+contexts:
+- name: exec
+  context:
+    cluster: cluster
+    user: exec-user
+- name: token
+  context:
+    cluster: cluster
+    user: token-user
+users:
+- name: exec-user
+  user:
+    exec:
+      apiVersion: client.authentication.k8s.io/v1alpha1
+      command: get-token
+      args: [--cluster, foo]
+- name: token-user
+  user:
+    token: deadbeef
+`
+
+func TestKubeconfigExec(t *testing.T) {
+	user, rawExec, err := KubeconfigExec([][]byte{[]byte(execKubeconfig)}, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if user != "exec-user" || rawExec == nil {
+		t.Errorf("expected the exec plugin of exec-user, got %q %s", user, rawExec)
+	}
+
+	user, rawExec, err = KubeconfigExec([][]byte{[]byte(execKubeconfig)}, "token")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if user != "token-user" || rawExec != nil {
+		t.Errorf("expected no exec plugin for token-user, got %q %s", user, rawExec)
+	}
+
+	// Earlier kubeconfigs win.
+	override := []byte(`current-context: token`)
+	user, _, err = KubeconfigExec([][]byte{override, []byte(execKubeconfig)}, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if user != "token-user" {
+		t.Errorf("expected the user of the overridden current context, got %q", user)
+	}
+
+	// clientcmd tolerates the exec plugin it does not know about.
+	if _, err := clientcmd.Load([]byte(execKubeconfig)); err != nil {
+		t.Errorf("unexpected error loading kubeconfig with exec plugin: %v", err)
+	}
+}
+
+func TestExecRoundTripper(t *testing.T) {
+	var authorizations []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorizations = append(authorizations, r.Header.Get("Authorization"))
+		if r.Header.Get("Authorization") == "Bearer rejected" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+	defer allowExecCommands("get-token")()
+
+	config := &restclient.Config{Host: server.URL, BearerToken: "static"}
+	if err := ConfigureClusterAuth(config, []byte(execKubeconfig), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if config.BearerToken != "" || config.WrapTransport == nil {
+		t.Fatalf("expected the exec plugin to replace the static token")
+	}
+
+	now := time.Now()
+	runs := 0
+	tokens := []string{"rejected", "first", "second"}
+	rt := config.WrapTransport(http.DefaultTransport).(*execRoundTripper)
+	rt.now = func() time.Time { return now }
+	rt.run = func(config *ExecConfig) ([]byte, error) {
+		if config.Command != "get-token" || len(config.Args) != 2 {
+			return nil, fmt.Errorf("unexpected plugin config %v", config)
+		}
+		token := tokens[runs]
+		runs++
+		expiry := now.Add(time.Hour).UTC().Format(time.RFC3339)
+		return []byte(fmt.Sprintf(`{"kind": "ExecCredential", "status": {"token": %q, "expirationTimestamp": %q}}`, token, expiry)), nil
+	}
+
+	client := &http.Client{Transport: rt}
+	for i := 0; i < 3; i++ {
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		resp.Body.Close()
+	}
+	// The token expires.
+	now = now.Add(2 * time.Hour)
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	expected := []string{"Bearer rejected", "Bearer first", "Bearer first", "Bearer second"}
+	if fmt.Sprint(authorizations) != fmt.Sprint(expected) {
+		t.Errorf("expected authorizations %v, got %v", expected, authorizations)
+	}
+	if runs != 3 {
+		t.Errorf("expected the plugin to run 3 times, got %d", runs)
+	}
+}
+
+func TestExecCommandNotAllowed(t *testing.T) {
+	defer allowExecCommands("other-command")()
+
+	config := &restclient.Config{Host: "https://example.com", BearerToken: "static"}
+	if err := ConfigureClusterAuth(config, []byte(execKubeconfig), nil); err == nil {
+		t.Fatalf("expected an error for an exec plugin that is not allowed")
+	}
+	if config.WrapTransport != nil {
+		t.Errorf("expected the exec plugin not to be configured")
+	}
+
+	// Exec plugins of trusted kubeconfigs are not restricted.
+	_, rawExec, err := KubeconfigExec([][]byte{[]byte(execKubeconfig)}, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := ConfigureExecAuth(config, rawExec); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+type fakeClusterAuthPersister struct {
+	credential *ExecCredentialStatus
+	persisted  []string
+}
+
+func (p *fakeClusterAuthPersister) AuthProviderPersister(user string) restclient.AuthProviderConfigPersister {
+	return nil
+}
+
+func (p *fakeClusterAuthPersister) ExecCredential() *ExecCredentialStatus {
+	return p.credential
+}
+
+func (p *fakeClusterAuthPersister) PersistExecCredential(credential *ExecCredentialStatus) error {
+	p.credential = credential
+	p.persisted = append(p.persisted, credential.Token)
+	return nil
+}
+
+func TestExecRoundTripperPersistsCredential(t *testing.T) {
+	var authorizations []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorizations = append(authorizations, r.Header.Get("Authorization"))
+	}))
+	defer server.Close()
+	defer allowExecCommands("get-token")()
+
+	now := time.Now()
+	expiry := metav1.NewTime(now.Add(time.Hour))
+	persister := &fakeClusterAuthPersister{credential: &ExecCredentialStatus{Token: "persisted", ExpirationTimestamp: &expiry}}
+	config := &restclient.Config{Host: server.URL}
+	if err := ConfigureClusterAuth(config, []byte(execKubeconfig), persister); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	runs := 0
+	rt := config.WrapTransport(http.DefaultTransport).(*execRoundTripper)
+	rt.now = func() time.Time { return now }
+	rt.run = func(config *ExecConfig) ([]byte, error) {
+		runs++
+		return []byte(`{"kind": "ExecCredential", "status": {"token": "refreshed"}}`), nil
+	}
+
+	client := &http.Client{Transport: rt}
+	get := func() {
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		resp.Body.Close()
+	}
+	get()
+	// The persisted token expires.
+	now = now.Add(2 * time.Hour)
+	get()
+
+	expected := []string{"Bearer persisted", "Bearer refreshed"}
+	if fmt.Sprint(authorizations) != fmt.Sprint(expected) {
+		t.Errorf("expected authorizations %v, got %v", expected, authorizations)
+	}
+	if runs != 1 {
+		t.Errorf("expected the plugin to run once, got %d", runs)
+	}
+	if fmt.Sprint(persister.persisted) != "[refreshed]" {
+		t.Errorf("expected the refreshed token to be persisted, got %v", persister.persisted)
+	}
+}
+
+// allowExecCommands allows the given exec commands and returns a function
+// restoring the allowed commands.
+func allowExecCommands(commands ...string) func() {
+	allowed := AllowedExecCommands
+	AllowedExecCommands = sets.NewString(commands...)
+	return func() {
+		AllowedExecCommands = allowed
+	}
+}
+
+func TestTokenFileRoundTripper(t *testing.T) {
+	file, err := ioutil.TempFile("", "token")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.Remove(file.Name())
+	if err := ioutil.WriteFile(file.Name(), []byte("first\n"), 0600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var authorizations []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorizations = append(authorizations, r.Header.Get("Authorization"))
+	}))
+	defer server.Close()
+
+	kubeconfig := fmt.Sprintf(`
+current-context: ctx
+contexts:
+- name: ctx
+  context: {cluster: cluster, user: user}
+users:
+- name: user
+  user: {tokenFile: %s}
+`, file.Name())
+	config := &restclient.Config{Host: server.URL, BearerToken: "first"}
+	if err := ConfigureClusterAuth(config, []byte(kubeconfig), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if config.BearerToken != "" || config.WrapTransport == nil {
+		t.Fatalf("expected the token file to replace the static token")
+	}
+
+	now := time.Now()
+	rt := config.WrapTransport(http.DefaultTransport).(*tokenFileRoundTripper)
+	rt.now = func() time.Time { return now }
+	client := &http.Client{Transport: rt}
+
+	get := func() {
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		resp.Body.Close()
+	}
+	get()
+	if err := ioutil.WriteFile(file.Name(), []byte("second\n"), 0600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	get()
+	now = now.Add(tokenFileRefreshPeriod)
+	get()
+
+	expected := []string{"Bearer first", "Bearer first", "Bearer second"}
+	if fmt.Sprint(authorizations) != fmt.Sprint(expected) {
+		t.Errorf("expected authorizations %v, got %v", expected, authorizations)
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	restclient "k8s.io/client-go/rest"
+)
+
+// ClusterCredentialsExpiry returns when the credentials of the given cluster
+// config expire, and what kind of credentials expire then. It returns nil if
+// the credentials do not expire or their expiry is not known, as for opaque
+// tokens and service account tokens without an exp claim.
+func ClusterCredentialsExpiry(config *restclient.Config) (*time.Time, string, error) {
+	var expiry *time.Time
+	var kind string
+
+	if len(config.BearerToken) > 0 {
+		tokenExpiry, err := tokenExpiry(config.BearerToken)
+		if err != nil {
+			return nil, "", err
+		}
+		if tokenExpiry != nil {
+			expiry, kind = tokenExpiry, "token"
+		}
+	}
+
+	certData := config.CertData
+	if len(certData) == 0 && len(config.CertFile) > 0 {
+		var err error
+		certData, err = ioutil.ReadFile(config.CertFile)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+	if len(certData) > 0 {
+		certExpiry, err := certificateExpiry(certData)
+		if err != nil {
+			return nil, "", err
+		}
+		if expiry == nil || certExpiry.Before(*expiry) {
+			expiry, kind = certExpiry, "client certificate"
+		}
+	}
+	return expiry, kind, nil
+}
+
+// tokenExpiry returns the expiry in the exp claim of a JWT, or nil if the
+// token is not a JWT or has no such claim.
+func tokenExpiry(token string) (*time.Time, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, nil
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, nil
+	}
+	var claims struct {
+		Exp *float64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == nil {
+		return nil, nil
+	}
+	expiry := time.Unix(int64(*claims.Exp), 0)
+	return &expiry, nil
+}
+
+// certificateExpiry returns the earliest expiry of the certificates in the
+// given PEM data.
+func certificateExpiry(data []byte) (*time.Time, error) {
+	var expiry *time.Time
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse client certificate: %v", err)
+		}
+		if expiry == nil || cert.NotAfter.Before(*expiry) {
+			notAfter := cert.NotAfter
+			expiry = &notAfter
+		}
+	}
+	if expiry == nil {
+		return nil, fmt.Errorf("no client certificate found in PEM data")
+	}
+	return expiry, nil
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	restclient "k8s.io/client-go/rest"
+)
+
+func makeCertificate(t *testing.T, notAfter time.Time) []byte {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "federation"},
+		NotBefore:    notAfter.Add(-time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func makeToken(claims string) string {
+	encode := base64.RawURLEncoding.EncodeToString
+	return encode([]byte(`{"alg":"RS256"}`)) + "." + encode([]byte(claims)) + ".signature"
+}
+
+func TestClusterCredentialsExpiry(t *testing.T) {
+	certExpiry := time.Unix(2000000000, 0)
+	tokenExpiry := time.Unix(1900000000, 0)
+
+	tests := []struct {
+		note         string
+		config       restclient.Config
+		expectedTime *time.Time
+		expectedKind string
+		wantErr      bool
+	}{
+		{
+			note: "no credentials",
+		},
+		{
+			note:   "opaque token",
+			config: restclient.Config{BearerToken: "deadbeef"},
+		},
+		{
+			note:   "token without expiry",
+			config: restclient.Config{BearerToken: makeToken(`{"iss":"kubernetes/serviceaccount"}`)},
+		},
+		{
+			note:         "token with expiry",
+			config:       restclient.Config{BearerToken: makeToken(`{"exp":1900000000}`)},
+			expectedTime: &tokenExpiry,
+			expectedKind: "token",
+		},
+		{
+			note:         "client certificate",
+			config:       restclient.Config{TLSClientConfig: restclient.TLSClientConfig{CertData: makeCertificate(t, certExpiry)}},
+			expectedTime: &certExpiry,
+			expectedKind: "client certificate",
+		},
+		{
+			note: "earliest of token and certificate",
+			config: restclient.Config{
+				BearerToken:     makeToken(`{"exp":1900000000}`),
+				TLSClientConfig: restclient.TLSClientConfig{CertData: makeCertificate(t, certExpiry)},
+			},
+			expectedTime: &tokenExpiry,
+			expectedKind: "token",
+		},
+		{
+			note:    "invalid certificate",
+			config:  restclient.Config{TLSClientConfig: restclient.TLSClientConfig{CertData: []byte("garbage")}},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		expiry, kind, err := ClusterCredentialsExpiry(&tc.config)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("%s: expected error", tc.note)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.note, err)
+			continue
+		}
+		if (expiry == nil) != (tc.expectedTime == nil) || (expiry != nil && !expiry.Equal(*tc.expectedTime)) {
+			t.Errorf("%s: expected expiry %v, got %v", tc.note, tc.expectedTime, expiry)
+		}
+		if kind != tc.expectedKind {
+			t.Errorf("%s: expected kind %q, got %q", tc.note, tc.expectedKind, kind)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	federationapi "k8s.io/federation/apis/federation/v1beta1"
+)
+
+// ClusterAPIResources returns the API resources of the given discovery
+// results in the form reported in the status of a cluster, sorted by group
+// version and name. Subresources are left out.
+func ClusterAPIResources(resourceLists []*metav1.APIResourceList) []federationapi.ClusterAPIResources {
+	var result []federationapi.ClusterAPIResources
+	for _, resourceList := range resourceLists {
+		if resourceList == nil {
+			continue
+		}
+		apiResources := federationapi.ClusterAPIResources{GroupVersion: resourceList.GroupVersion}
+		for _, resource := range resourceList.APIResources {
+			if strings.Contains(resource.Name, "/") {
+				continue
+			}
+			apiResources.Resources = append(apiResources.Resources, resource.Name)
+		}
+		sort.Strings(apiResources.Resources)
+		result = append(result, apiResources)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].GroupVersion < result[j].GroupVersion })
+	return result
+}
+
+// ClusterMissingResources returns the given API resources that the cluster
+// does not serve. Nothing is missing from clusters whose API resources are not
+// known, so that they keep working with controllers that do not report them.
+func ClusterMissingResources(cluster *federationapi.Cluster, requiredResources []schema.GroupVersionResource) []schema.GroupVersionResource {
+	if len(cluster.Status.APIResources) == 0 {
+		return nil
+	}
+	var missing []schema.GroupVersionResource
+	for _, required := range requiredResources {
+		if !clusterServesResource(cluster, required) {
+			missing = append(missing, required)
+		}
+	}
+	return missing
+}
+
+func clusterServesResource(cluster *federationapi.Cluster, resource schema.GroupVersionResource) bool {
+	groupVersion := resource.GroupVersion().String()
+	for _, apiResources := range cluster.Status.APIResources {
+		if apiResources.GroupVersion != groupVersion {
+			continue
+		}
+		for _, name := range apiResources.Resources {
+			if name == resource.Resource {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	federationapi "k8s.io/federation/apis/federation/v1beta1"
+)
+
+func TestClusterAPIResources(t *testing.T) {
+	resourceLists := []*metav1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{{Name: "services"}, {Name: "pods"}, {Name: "pods/status"}},
+		},
+		nil,
+		{
+			GroupVersion: "apps/v1",
+			APIResources: []metav1.APIResource{{Name: "deployments"}},
+		},
+	}
+	expected := []federationapi.ClusterAPIResources{
+		{GroupVersion: "apps/v1", Resources: []string{"deployments"}},
+		{GroupVersion: "v1", Resources: []string{"pods", "services"}},
+	}
+	if actual := ClusterAPIResources(resourceLists); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+}
+
+func TestClusterMissingResources(t *testing.T) {
+	services := schema.GroupVersionResource{Version: "v1", Resource: "services"}
+	replicaSets := schema.GroupVersionResource{Group: "extensions", Version: "v1beta1", Resource: "replicasets"}
+	deployments := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	required := []schema.GroupVersionResource{services, replicaSets, deployments}
+
+	tests := []struct {
+		note         string
+		apiResources []federationapi.ClusterAPIResources
+		expected     []schema.GroupVersionResource
+	}{
+		{
+			note:     "unknown resources",
+			expected: nil,
+		},
+		{
+			note: "all served",
+			apiResources: []federationapi.ClusterAPIResources{
+				{GroupVersion: "apps/v1", Resources: []string{"deployments"}},
+				{GroupVersion: "extensions/v1beta1", Resources: []string{"ingresses", "replicasets"}},
+				{GroupVersion: "v1", Resources: []string{"services"}},
+			},
+			expected: nil,
+		},
+		{
+			note: "missing group version and resource",
+			apiResources: []federationapi.ClusterAPIResources{
+				{GroupVersion: "extensions/v1beta1", Resources: []string{"ingresses"}},
+				{GroupVersion: "v1", Resources: []string{"services"}},
+			},
+			expected: []schema.GroupVersionResource{replicaSets, deployments},
+		},
+	}
+
+	for _, tc := range tests {
+		cluster := &federationapi.Cluster{Status: federationapi.ClusterStatus{APIResources: tc.apiResources}}
+		if actual := ClusterMissingResources(cluster, required); !reflect.DeepEqual(actual, tc.expected) {
+			t.Errorf("%s: expected %v, got %v", tc.note, tc.expected, actual)
+		}
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package util
 
 import (
+	"encoding/json"
 	"fmt"
 	"net"
 	"os"
@@ -41,25 +42,83 @@ const (
 	getSecretTimeout        = 1 * time.Minute
 )
 
-func BuildClusterConfig(c *federation_v1beta1.Cluster) (*restclient.Config, error) {
-	var serverAddress string
-	var clusterConfig *restclient.Config
+// getSecretNoRetryTimeout bounds the single attempt to get the secret of a
+// cluster in the request path.
+const getSecretNoRetryTimeout = 5 * time.Second
+
+// ExecCredentialSecretDataKey is the key of the persisted credential of the
+// exec credential plugin of the kubeconfig in a cluster secret.
+const ExecCredentialSecretDataKey = "exec-credential"
+
+// ClusterServerAddresses returns the API server addresses of the cluster
+// that match the client CIDR of this host, in the order they are listed in
+// its spec. Clusters with highly available control planes list several.
+func ClusterServerAddresses(c *federation_v1beta1.Cluster) ([]string, error) {
 	hostIP, err := utilnet.ChooseHostInterface()
 	if err != nil {
 		return nil, err
 	}
+	myaddr := net.ParseIP(hostIP.String())
 
+	var serverAddresses []string
 	for _, item := range c.Spec.ServerAddressByClientCIDRs {
 		_, cidrnet, err := net.ParseCIDR(item.ClientCIDR)
 		if err != nil {
 			return nil, err
 		}
-		myaddr := net.ParseIP(hostIP.String())
-		if cidrnet.Contains(myaddr) == true {
-			serverAddress = item.ServerAddress
-			break
+		if cidrnet.Contains(myaddr) {
+			serverAddresses = append(serverAddresses, item.ServerAddress)
+		}
+	}
+	return serverAddresses, nil
+}
+
+// ActiveServerAddress returns the address the cluster should be reached at:
+// the active address reported in its status if it is still one of the given
+// addresses, the first of them otherwise.
+func ActiveServerAddress(c *federation_v1beta1.Cluster, serverAddresses []string) string {
+	if len(serverAddresses) == 0 {
+		return ""
+	}
+	for _, serverAddress := range serverAddresses {
+		if serverAddress == c.Status.ActiveServerAddress {
+			return serverAddress
 		}
 	}
+	return serverAddresses[0]
+}
+
+// BuildClusterConfig returns the config of a client of the active API server
+// of the cluster, or nil if none of its addresses match the client CIDR of
+// this host.
+func BuildClusterConfig(c *federation_v1beta1.Cluster) (*restclient.Config, error) {
+	serverAddresses, err := ClusterServerAddresses(c)
+	if err != nil {
+		return nil, err
+	}
+	return BuildClusterConfigForAddress(c, ActiveServerAddress(c, serverAddresses))
+}
+
+// BuildClusterConfigNoRetry is like BuildClusterConfig, but fails at once if
+// the secret of the cluster can not be read instead of retrying for up to
+// getSecretTimeout. It is meant for callers that serve requests.
+func BuildClusterConfigNoRetry(c *federation_v1beta1.Cluster) (*restclient.Config, error) {
+	serverAddresses, err := ClusterServerAddresses(c)
+	if err != nil {
+		return nil, err
+	}
+	return buildClusterConfig(c, ActiveServerAddress(c, serverAddresses), getSecretNoRetry)
+}
+
+// BuildClusterConfigForAddress returns the config of a client of the API
+// server of the cluster at the given address, or nil if the address is empty.
+func BuildClusterConfigForAddress(c *federation_v1beta1.Cluster, serverAddress string) (*restclient.Config, error) {
+	return buildClusterConfig(c, serverAddress, getSecret)
+}
+
+func buildClusterConfig(c *federation_v1beta1.Cluster, serverAddress string, getSecret func(secretName string) (*api.Secret, error)) (*restclient.Config, error) {
+	var clusterConfig *restclient.Config
+	var err error
 	if serverAddress != "" {
 		if c.Spec.SecretRef == nil {
 			glog.Infof("didn't find secretRef for cluster %s. Trying insecure access", c.Name)
@@ -88,6 +147,9 @@ func BuildClusterConfig(c *federation_v1beta1.Cluster) (*restclient.Config, erro
 			} else {
 				kubeconfigGetter := KubeconfigGetterForSecret(secret)
 				clusterConfig, err = clientcmd.BuildConfigFromKubeconfigGetter(serverAddress, kubeconfigGetter)
+				if err == nil {
+					err = ConfigureClusterAuth(clusterConfig, secret.Data[KubeconfigSecretDataKey], &secretAuthPersister{secret: secret})
+				}
 			}
 		}
 		if err != nil {
@@ -99,21 +161,34 @@ func BuildClusterConfig(c *federation_v1beta1.Cluster) (*restclient.Config, erro
 	return clusterConfig, nil
 }
 
-// getSecret gets a secret from the cluster.
-func getSecret(secretName string) (*api.Secret, error) {
+// inClusterClient returns a client to talk to the k8s apiserver of the
+// cluster this is running in, and the namespace this is running in. The
+// requests of the client time out after the given timeout, if not zero.
+func inClusterClient(timeout time.Duration) (clientset.Interface, string, error) {
 	// Get the namespace this is running in from the env variable.
 	namespace := os.Getenv("POD_NAMESPACE")
 	if namespace == "" {
-		return nil, fmt.Errorf("unexpected: POD_NAMESPACE env var returned empty string")
+		return nil, "", fmt.Errorf("unexpected: POD_NAMESPACE env var returned empty string")
 	}
-	// Get a client to talk to the k8s apiserver, to fetch secrets from it.
 	cc, err := restclient.InClusterConfig()
 	if err != nil {
-		return nil, fmt.Errorf("error in creating in-cluster config: %s", err)
+		return nil, "", fmt.Errorf("error in creating in-cluster config: %s", err)
 	}
+	cc.Timeout = timeout
 	client, err := clientset.NewForConfig(cc)
 	if err != nil {
-		return nil, fmt.Errorf("error in creating in-cluster client: %s", err)
+		return nil, "", fmt.Errorf("error in creating in-cluster client: %s", err)
+	}
+	return client, namespace, nil
+}
+
+// getSecret gets a secret from the cluster, retrying for up to
+// getSecretTimeout.
+func getSecret(secretName string) (*api.Secret, error) {
+	// Get a client to talk to the k8s apiserver, to fetch secrets from it.
+	client, namespace, err := inClusterClient(0)
+	if err != nil {
+		return nil, err
 	}
 	var secret *api.Secret
 	err = wait.PollImmediate(1*time.Second, getSecretTimeout, func() (bool, error) {
@@ -133,6 +208,104 @@ func getSecret(secretName string) (*api.Secret, error) {
 	return secret, nil
 }
 
+// getSecretNoRetry gets a secret from the cluster with a single attempt.
+func getSecretNoRetry(secretName string) (*api.Secret, error) {
+	client, namespace, err := inClusterClient(getSecretNoRetryTimeout)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := client.Core().Secrets(namespace).Get(secretName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error in fetching secret %s: %v", secretName, err)
+	}
+	return secret, nil
+}
+
+// secretAuthPersister persists the refreshed credentials of a cluster, e.g.
+// oidc tokens or the tokens of exec credential plugins, to its secret, so
+// that the credentials survive restarts.
+type secretAuthPersister struct {
+	secret *api.Secret
+}
+
+func (p *secretAuthPersister) AuthProviderPersister(user string) restclient.AuthProviderConfigPersister {
+	return &secretAuthProviderPersister{secret: p, user: user}
+}
+
+func (p *secretAuthPersister) ExecCredential() *ExecCredentialStatus {
+	data, ok := p.secret.Data[ExecCredentialSecretDataKey]
+	if !ok {
+		return nil
+	}
+	credential := &ExecCredentialStatus{}
+	if err := json.Unmarshal(data, credential); err != nil {
+		glog.Warningf("Ignoring invalid exec credential in secret %s: %v", p.secret.Name, err)
+		return nil
+	}
+	return credential
+}
+
+func (p *secretAuthPersister) PersistExecCredential(credential *ExecCredentialStatus) error {
+	data, err := json.Marshal(credential)
+	if err != nil {
+		return err
+	}
+	return p.update(func(secret *api.Secret) error {
+		secret.Data[ExecCredentialSecretDataKey] = data
+		return nil
+	})
+}
+
+// update applies the change to the current version of the secret.
+func (p *secretAuthPersister) update(change func(secret *api.Secret) error) error {
+	client, namespace, err := inClusterClient(0)
+	if err != nil {
+		return err
+	}
+	if p.secret.Namespace != "" {
+		namespace = p.secret.Namespace
+	}
+	secret, err := client.Core().Secrets(namespace).Get(p.secret.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	if err := change(secret); err != nil {
+		return err
+	}
+	_, err = client.Core().Secrets(namespace).Update(secret)
+	return err
+}
+
+// secretAuthProviderPersister persists the refreshed auth provider config of
+// a user to the kubeconfig in a cluster secret.
+type secretAuthProviderPersister struct {
+	secret *secretAuthPersister
+	user   string
+}
+
+func (p *secretAuthProviderPersister) Persist(config map[string]string) error {
+	return p.secret.update(func(secret *api.Secret) error {
+		kubeconfig, err := clientcmd.Load(secret.Data[KubeconfigSecretDataKey])
+		if err != nil {
+			return err
+		}
+		authInfo, ok := kubeconfig.AuthInfos[p.user]
+		if !ok || authInfo.AuthProvider == nil {
+			return nil
+		}
+		authInfo.AuthProvider.Config = config
+		data, err := clientcmd.Write(*kubeconfig)
+		if err != nil {
+			return err
+		}
+		secret.Data[KubeconfigSecretDataKey] = data
+		return nil
+	})
+}
+
 // KubeconfigGetterForSecret gets the kubeconfig from the given secret.
 // This is to inject a different KubeconfigGetter in tests. We don't use
 // the standard one which calls NewInCluster in tests to avoid having to
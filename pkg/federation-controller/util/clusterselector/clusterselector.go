@@ -40,13 +40,17 @@ func SendToCluster(clusterLabels map[string]string, annotations map[string]strin
 }
 
 func getSelector(annotation string) (labels.Selector, error) {
-	selector := labels.NewSelector()
 	requirements := make([]federation_v1beta1.ClusterSelectorRequirement, 0)
 	err := json.Unmarshal([]byte(annotation), &requirements)
 	if err != nil {
 		return nil, err
 	}
+	return NewSelector(requirements)
+}
 
+// NewSelector returns the label selector matching the clusters that satisfy all the given requirements.
+func NewSelector(requirements []federation_v1beta1.ClusterSelectorRequirement) (labels.Selector, error) {
+	selector := labels.NewSelector()
 	for _, requirement := range requirements {
 		r, err := labels.NewRequirement(requirement.Key, ConvertOperator(requirement.Operator), requirement.Values)
 		if err != nil {
@@ -0,0 +1,206 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Package clusterset resolves the members of cluster sets and restricts the
+// placement of federated objects to the cluster sets they refer to.
+package clusterset
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	pkgruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	federationapi "k8s.io/federation/apis/federation/v1beta1"
+	federationclientset "k8s.io/federation/client/clientset_generated/federation_clientset"
+	"k8s.io/federation/pkg/federation-controller/util/clusterselector"
+	"k8s.io/kubernetes/pkg/controller"
+)
+
+// NewInformer returns an informer on the cluster sets of the federation.
+func NewInformer(client federationclientset.Interface, handler cache.ResourceEventHandler) (cache.Store, cache.Controller) {
+	return cache.NewInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (pkgruntime.Object, error) {
+				return client.Federation().ClusterSets().List(options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				return client.Federation().ClusterSets().Watch(options)
+			},
+		},
+		&federationapi.ClusterSet{},
+		controller.NoResyncPeriodFunc(),
+		handler,
+	)
+}
+
+// IsMember returns whether the cluster is a member of the set, either by name
+// or by its labels.
+func IsMember(set *federationapi.ClusterSet, cluster *federationapi.Cluster) (bool, error) {
+	for _, name := range set.Spec.Clusters {
+		if name == cluster.Name {
+			return true, nil
+		}
+	}
+	if len(set.Spec.ClusterSelector) == 0 {
+		return false, nil
+	}
+	selector, err := clusterselector.NewSelector(set.Spec.ClusterSelector)
+	if err != nil {
+		return false, fmt.Errorf("invalid cluster selector in cluster set %q: %v", set.Name, err)
+	}
+	return selector.Matches(labels.Set(cluster.Labels)), nil
+}
+
+// Members returns the sorted names of the given clusters that are members of
+// the set.
+func Members(set *federationapi.ClusterSet, clusters []*federationapi.Cluster) ([]string, error) {
+	members := []string{}
+	for _, cluster := range clusters {
+		member, err := IsMember(set, cluster)
+		if err != nil {
+			return nil, err
+		}
+		if member {
+			members = append(members, cluster.Name)
+		}
+	}
+	sort.Strings(members)
+	return members, nil
+}
+
+// Get returns the cluster set with the given name. Placement that refers to a
+// missing set fails rather than dropping the objects from all clusters.
+func Get(store cache.Store, name string) (*federationapi.ClusterSet, error) {
+	obj, exists, err := store.GetByKey(name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("cluster set %q not found", name)
+	}
+	return obj.(*federationapi.ClusterSet), nil
+}
+
+// SelectClusters moves the clusters that are not members of the cluster set
+// named by the cluster set annotation, if any, from the selected to the
+// unselected clusters.
+func SelectClusters(store cache.Store, annotations map[string]string, selected, unselected []*federationapi.Cluster) ([]*federationapi.Cluster, []*federationapi.Cluster, error) {
+	name, found := annotations[federationapi.FederationClusterSetAnnotation]
+	if !found {
+		return selected, unselected, nil
+	}
+	set, err := Get(store, name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	members := make([]*federationapi.Cluster, 0, len(selected))
+	for _, cluster := range selected {
+		member, err := IsMember(set, cluster)
+		if err != nil {
+			return nil, nil, err
+		}
+		if member {
+			members = append(members, cluster)
+		} else {
+			unselected = append(unselected, cluster)
+		}
+	}
+	return members, unselected, nil
+}
+
+// ScopePreferences returns the preferences with the "*" entry replaced by an
+// entry for every member of their cluster set among the given clusters that
+// has no explicit entry. Preferences without a cluster set are returned as is.
+func ScopePreferences(store cache.Store, prefs *federationapi.ReplicaAllocationPreferences, clusters []*federationapi.Cluster) (*federationapi.ReplicaAllocationPreferences, error) {
+	if prefs.ClusterSet == "" {
+		return prefs, nil
+	}
+	set, err := Get(store, prefs.ClusterSet)
+	if err != nil {
+		return nil, err
+	}
+
+	scoped := prefs.DeepCopy()
+	scoped.ClusterSet = ""
+	wildcard, found := scoped.Clusters["*"]
+	if !found {
+		return scoped, nil
+	}
+	delete(scoped.Clusters, "*")
+	for _, cluster := range clusters {
+		if _, found := scoped.Clusters[cluster.Name]; found {
+			continue
+		}
+		member, err := IsMember(set, cluster)
+		if err != nil {
+			return nil, err
+		}
+		if member {
+			scoped.Clusters[cluster.Name] = wildcard
+		}
+	}
+	return scoped, nil
+}
+
+// PlacementObject returns the object to take the placement of the given object
+// from: the object itself if its replica preferences do not refer to a cluster
+// set, or a copy with the preferences scoped to the members of the set among
+// the given clusters otherwise.
+func PlacementObject(store cache.Store, obj pkgruntime.Object, preferencesAnnotation string, clusters []*federationapi.Cluster) (pkgruntime.Object, error) {
+	if preferencesAnnotation == "" {
+		return obj, nil
+	}
+	objMeta, err := meta.Accessor(obj)
+	if err != nil {
+		return nil, err
+	}
+	value, found := objMeta.GetAnnotations()[preferencesAnnotation]
+	if !found {
+		return obj, nil
+	}
+	prefs := &federationapi.ReplicaAllocationPreferences{}
+	if err := json.Unmarshal([]byte(value), prefs); err != nil {
+		// Left to the consumers of the annotation to report.
+		return obj, nil
+	}
+	if prefs.ClusterSet == "" {
+		return obj, nil
+	}
+
+	scoped, err := ScopePreferences(store, prefs, clusters)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(scoped)
+	if err != nil {
+		return nil, err
+	}
+	placementObj := obj.DeepCopyObject()
+	placementMeta, err := meta.Accessor(placementObj)
+	if err != nil {
+		return nil, err
+	}
+	annotations := placementMeta.GetAnnotations()
+	annotations[preferencesAnnotation] = string(data)
+	placementMeta.SetAnnotations(annotations)
+	return placementObj, nil
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package clusterset
+
+import (
+	"testing"
+
+	extensionsv1 "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	fedapi "k8s.io/federation/apis/federation"
+	federationapi "k8s.io/federation/apis/federation/v1beta1"
+	"k8s.io/federation/pkg/federation-controller/util/replicapreferences"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const preferencesAnnotation = "federation.kubernetes.io/replica-set-preferences"
+
+func newCluster(name string, labels map[string]string) *federationapi.Cluster {
+	return &federationapi.Cluster{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+}
+
+func newStore() cache.Store {
+	store := cache.NewStore(cache.MetaNamespaceKeyFunc)
+	store.Add(&federationapi.ClusterSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod-eu"},
+		Spec: federationapi.ClusterSetSpec{
+			Clusters: []string{"canary"},
+			ClusterSelector: []federationapi.ClusterSelectorRequirement{
+				{Key: "region", Operator: "in", Values: []string{"eu"}},
+			},
+		},
+	})
+	return store
+}
+
+var clusters = []*federationapi.Cluster{
+	newCluster("eu-west", map[string]string{"region": "eu"}),
+	newCluster("us-east", map[string]string{"region": "us"}),
+	newCluster("canary", nil),
+}
+
+func clusterNames(clusters []*federationapi.Cluster) []string {
+	names := []string{}
+	for _, cluster := range clusters {
+		names = append(names, cluster.Name)
+	}
+	return names
+}
+
+func TestMembers(t *testing.T) {
+	set, err := Get(newStore(), "prod-eu")
+	require.NoError(t, err)
+	members, err := Members(set, clusters)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"canary", "eu-west"}, members)
+
+	set.Spec.ClusterSelector = nil
+	members, err = Members(set, clusters)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"canary"}, members)
+
+	_, err = Get(newStore(), "missing")
+	assert.Error(t, err)
+}
+
+func TestSelectClusters(t *testing.T) {
+	store := newStore()
+
+	selected, unselected, err := SelectClusters(store, nil, clusters[:2], clusters[2:])
+	require.NoError(t, err)
+	assert.Equal(t, []string{"eu-west", "us-east"}, clusterNames(selected))
+	assert.Equal(t, []string{"canary"}, clusterNames(unselected))
+
+	annotations := map[string]string{federationapi.FederationClusterSetAnnotation: "prod-eu"}
+	selected, unselected, err = SelectClusters(store, annotations, clusters, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"eu-west", "canary"}, clusterNames(selected))
+	assert.Equal(t, []string{"us-east"}, clusterNames(unselected))
+
+	annotations[federationapi.FederationClusterSetAnnotation] = "missing"
+	_, _, err = SelectClusters(store, annotations, clusters, nil)
+	assert.Error(t, err, "objects of a missing set must not be removed from all clusters")
+}
+
+func TestPlacementObject(t *testing.T) {
+	store := newStore()
+	newReplicaSet := func(preferences string) *extensionsv1.ReplicaSet {
+		return &extensionsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "frontend",
+				Namespace:   "default",
+				Annotations: map[string]string{preferencesAnnotation: preferences},
+			},
+		}
+	}
+
+	obj := newReplicaSet(`{"clusters":{"*":{"weight":1}}}`)
+	placementObj, err := PlacementObject(store, obj, preferencesAnnotation, clusters)
+	require.NoError(t, err)
+	assert.True(t, obj == placementObj, "preferences without a cluster set are used as is")
+
+	obj = newReplicaSet(`{"clusterSet":"prod-eu","clusters":{"*":{"weight":1},"us-east":{"weight":2},"canary":{"weight":3}}}`)
+	placementObj, err = PlacementObject(store, obj, preferencesAnnotation, clusters)
+	require.NoError(t, err)
+	assert.Contains(t, obj.Annotations[preferencesAnnotation], `"clusterSet":"prod-eu"`, "the given object must not be modified")
+
+	prefs, err := replicapreferences.GetAllocationPreferences(placementObj, preferencesAnnotation)
+	require.NoError(t, err)
+	assert.Empty(t, prefs.ClusterSet)
+	assert.Equal(t, map[string]fedapi.ClusterPreferences{
+		"eu-west": {Weight: 1},
+		"us-east": {Weight: 2},
+		"canary":  {Weight: 3},
+	}, prefs.Clusters)
+
+	obj = newReplicaSet(`{"clusterSet":"missing","clusters":{"*":{"weight":1}}}`)
+	_, err = PlacementObject(store, obj, preferencesAnnotation, clusters)
+	assert.Error(t, err)
+}
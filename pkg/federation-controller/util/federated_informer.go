@@ -24,7 +24,9 @@ import (
 
 	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	pkgruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/watch"
 	kubeclientset "k8s.io/client-go/kubernetes"
 	restclient "k8s.io/client-go/rest"
@@ -40,6 +42,14 @@ const (
 	userAgentName     = "federation-controller"
 )
 
+var (
+	// readyClusterSelector selects the clusters the target informers may run
+	// in. Clusters that become unready are deleted from the watch.
+	readyClusterSelector = fields.ParseSelectorOrDie("status.ready=" + string(apiv1.ConditionTrue))
+	// unreadyClusterSelector selects the other clusters.
+	unreadyClusterSelector = fields.ParseSelectorOrDie("status.ready!=" + string(apiv1.ConditionTrue))
+)
+
 // An object with an origin information.
 type FederatedObject struct {
 	Object      interface{}
@@ -137,7 +147,20 @@ func NewFederatedInformer(
 	targetInformerFactory TargetInformerFactory,
 	clusterLifecycle *ClusterLifecycleHandlerFuncs) FederatedInformer {
 
+	return NewFederatedInformerForResources(federationClient, nil, targetInformerFactory, clusterLifecycle)
+}
+
+// Builds a FederatedInformer for the given federation client and factory that only
+// considers ready the clusters serving the given API resources. Target informers are
+// not run in the other clusters.
+func NewFederatedInformerForResources(
+	federationClient federationclientset.Interface,
+	requiredResources []schema.GroupVersionResource,
+	targetInformerFactory TargetInformerFactory,
+	clusterLifecycle *ClusterLifecycleHandlerFuncs) FederatedInformer {
+
 	federatedInformer := &federatedInformerImpl{
+		requiredResources:     requiredResources,
 		targetInformerFactory: targetInformerFactory,
 		clientFactory: func(cluster *federationapi.Cluster) (kubeclientset.Interface, error) {
 			clusterConfig, err := BuildClusterConfig(cluster)
@@ -160,18 +183,14 @@ func NewFederatedInformer(
 	}
 
 	federatedInformer.clusterInformer.store, federatedInformer.clusterInformer.controller = cache.NewInformer(
-		&cache.ListWatch{
-			ListFunc: func(options metav1.ListOptions) (pkgruntime.Object, error) {
-				return federationClient.Federation().Clusters().List(options)
-			},
-			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
-				return federationClient.Federation().Clusters().Watch(options)
-			},
-		},
+		clusterListWatch(federationClient, readyClusterSelector),
 		&federationapi.Cluster{},
 		clusterSyncPeriod,
 		cache.ResourceEventHandlerFuncs{
 			DeleteFunc: func(old interface{}) {
+				if tombstone, ok := old.(cache.DeletedFinalStateUnknown); ok {
+					old = tombstone.Obj
+				}
 				oldCluster, ok := old.(*federationapi.Cluster)
 				if ok {
 					var data []interface{}
@@ -186,7 +205,7 @@ func NewFederatedInformer(
 			},
 			AddFunc: func(cur interface{}) {
 				curCluster, ok := cur.(*federationapi.Cluster)
-				if ok && isClusterReady(curCluster) {
+				if ok && federatedInformer.isClusterUsable(curCluster) {
 					federatedInformer.addCluster(curCluster)
 					if clusterLifecycle.ClusterAvailable != nil {
 						clusterLifecycle.ClusterAvailable(curCluster)
@@ -206,7 +225,7 @@ func NewFederatedInformer(
 					glog.Errorf("Internal error: Cluster %v not updated.  New cluster not of correct type.", cur)
 					return
 				}
-				if isClusterReady(oldCluster) != isClusterReady(curCluster) || !reflect.DeepEqual(oldCluster.Spec, curCluster.Spec) || !reflect.DeepEqual(oldCluster.ObjectMeta.Annotations, curCluster.ObjectMeta.Annotations) {
+				if federatedInformer.isClusterUsable(oldCluster) != federatedInformer.isClusterUsable(curCluster) || !reflect.DeepEqual(oldCluster.Spec, curCluster.Spec) || !reflect.DeepEqual(oldCluster.ObjectMeta.Annotations, curCluster.ObjectMeta.Annotations) {
 					var data []interface{}
 					if clusterLifecycle.ClusterUnavailable != nil {
 						data = getClusterData(oldCluster.Name)
@@ -216,21 +235,53 @@ func NewFederatedInformer(
 						clusterLifecycle.ClusterUnavailable(oldCluster, data)
 					}
 
-					if isClusterReady(curCluster) {
+					if federatedInformer.isClusterUsable(curCluster) {
 						federatedInformer.addCluster(curCluster)
 						if clusterLifecycle.ClusterAvailable != nil {
 							clusterLifecycle.ClusterAvailable(curCluster)
 						}
 					}
+				} else if oldCluster.Status.ActiveServerAddress != curCluster.Status.ActiveServerAddress && federatedInformer.isClusterUsable(curCluster) {
+					// The cluster failed over to another API server, the
+					// informers of the cluster reconnect to it.
+					glog.V(2).Infof("Cluster %s failed over from %q to %q", curCluster.Name, oldCluster.Status.ActiveServerAddress, curCluster.Status.ActiveServerAddress)
+					federatedInformer.deleteCluster(oldCluster)
+					federatedInformer.addCluster(curCluster)
+				} else if IsClusterDegraded(oldCluster) && !IsClusterDegraded(curCluster) && federatedInformer.isClusterUsable(curCluster) {
+					// The cluster recovered, objects may be placed in it again.
+					if clusterLifecycle.ClusterAvailable != nil {
+						clusterLifecycle.ClusterAvailable(curCluster)
+					}
 				} else {
 					glog.V(4).Infof("Cluster %v not updated to %v as ready status and specs are identical", oldCluster, curCluster)
 				}
 			},
 		},
 	)
+	federatedInformer.unreadyClusterInformer.store, federatedInformer.unreadyClusterInformer.controller = cache.NewInformer(
+		clusterListWatch(federationClient, unreadyClusterSelector),
+		&federationapi.Cluster{},
+		clusterSyncPeriod,
+		cache.ResourceEventHandlerFuncs{},
+	)
 	return federatedInformer
 }
 
+// clusterListWatch returns a ListWatch of the clusters that match the field
+// selector.
+func clusterListWatch(federationClient federationclientset.Interface, fieldSelector fields.Selector) *cache.ListWatch {
+	return &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (pkgruntime.Object, error) {
+			options.FieldSelector = fieldSelector.String()
+			return federationClient.Federation().Clusters().List(options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			options.FieldSelector = fieldSelector.String()
+			return federationClient.Federation().Clusters().Watch(options)
+		},
+	}
+}
+
 func isClusterReady(cluster *federationapi.Cluster) bool {
 	for _, condition := range cluster.Status.Conditions {
 		if condition.Type == federationapi.ClusterReady {
@@ -242,6 +293,41 @@ func isClusterReady(cluster *federationapi.Cluster) bool {
 	return false
 }
 
+// IsClusterDegraded returns whether the cluster is ready but failing its
+// latest health checks. No new objects should be placed in degraded clusters.
+func IsClusterDegraded(cluster *federationapi.Cluster) bool {
+	for _, condition := range cluster.Status.Conditions {
+		if condition.Type == federationapi.ClusterDegraded {
+			return condition.Status == apiv1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// IsClusterCordoned returns whether the cluster is cordoned or drained. No new
+// objects or replicas should be placed in cordoned clusters.
+func IsClusterCordoned(cluster *federationapi.Cluster) bool {
+	return cluster.Spec.Maintenance == federationapi.ClusterCordoned || IsClusterDrained(cluster)
+}
+
+// IsClusterDrained returns whether the replicas of federated workloads should
+// be moved out of the cluster.
+func IsClusterDrained(cluster *federationapi.Cluster) bool {
+	return cluster.Spec.Maintenance == federationapi.ClusterDrained
+}
+
+// IsClusterPullMode returns whether the cluster is reached through its agent.
+// The federation controllers do not operate on such clusters directly.
+func IsClusterPullMode(cluster *federationapi.Cluster) bool {
+	return cluster.Spec.ConnectionMode == federationapi.ClusterConnectionPull
+}
+
+// isClusterUsable returns whether the cluster is ready, reachable from the
+// federation and serves the API resources required by the informer.
+func (f *federatedInformerImpl) isClusterUsable(cluster *federationapi.Cluster) bool {
+	return isClusterReady(cluster) && !IsClusterPullMode(cluster) && len(ClusterMissingResources(cluster, f.requiredResources)) == 0
+}
+
 type informer struct {
 	controller cache.Controller
 	store      cache.Store
@@ -251,9 +337,15 @@ type informer struct {
 type federatedInformerImpl struct {
 	sync.Mutex
 
-	// Informer on federated clusters.
+	// Informer on ready federated clusters.
 	clusterInformer informer
 
+	// Informer on the other federated clusters, which only caches them.
+	unreadyClusterInformer informer
+
+	// API resources the clusters must serve to be considered ready.
+	requiredResources []schema.GroupVersionResource
+
 	// Target informers factory
 	targetInformerFactory TargetInformerFactory
 
@@ -276,8 +368,9 @@ func (f *federatedInformerImpl) Stop() {
 	f.Lock()
 	defer f.Unlock()
 
-	glog.V(4).Infof("... Closing cluster informer channel.")
+	glog.V(4).Infof("... Closing cluster informer channels.")
 	close(f.clusterInformer.stopChan)
+	close(f.unreadyClusterInformer.stopChan)
 	for key, informer := range f.targetInformers {
 		glog.V(4).Infof("... Closing informer channel for %q.", key)
 		close(informer.stopChan)
@@ -294,6 +387,8 @@ func (f *federatedInformerImpl) Start() {
 
 	f.clusterInformer.stopChan = make(chan struct{})
 	go f.clusterInformer.controller.Run(f.clusterInformer.stopChan)
+	f.unreadyClusterInformer.stopChan = make(chan struct{})
+	go f.unreadyClusterInformer.controller.Run(f.unreadyClusterInformer.stopChan)
 }
 
 func (f *federatedInformerImpl) SetClientFactory(clientFactory func(*federationapi.Cluster) (kubeclientset.Interface, error)) {
@@ -324,21 +419,34 @@ func (f *federatedInformerImpl) getClientsetForClusterUnlocked(clusterName strin
 	return nil, fmt.Errorf("cluster %q not found", clusterName)
 }
 
+// GetUnreadyClusters returns the clusters that are not ready, and the ready
+// clusters that the target informers do not run in.
 func (f *federatedInformerImpl) GetUnreadyClusters() ([]*federationapi.Cluster, error) {
 	f.Lock()
 	defer f.Unlock()
 
 	items := f.clusterInformer.store.List()
-	result := make([]*federationapi.Cluster, 0, len(items))
+	unreadyItems := f.unreadyClusterInformer.store.List()
+	result := make([]*federationapi.Cluster, 0, len(unreadyItems))
 	for _, item := range items {
 		if cluster, ok := item.(*federationapi.Cluster); ok {
-			if !isClusterReady(cluster) {
+			if isClusterReady(cluster) && !f.isClusterUsable(cluster) {
 				result = append(result, cluster)
 			}
 		} else {
 			return nil, fmt.Errorf("wrong data in FederatedInformerImpl cluster store: %v", item)
 		}
 	}
+	for _, item := range unreadyItems {
+		if cluster, ok := item.(*federationapi.Cluster); ok {
+			// The informers may briefly disagree while a cluster changes.
+			if !isClusterReady(cluster) {
+				result = append(result, cluster)
+			}
+		} else {
+			return nil, fmt.Errorf("wrong data in FederatedInformerImpl unready cluster store: %v", item)
+		}
+	}
 	return result, nil
 }
 
@@ -351,7 +459,7 @@ func (f *federatedInformerImpl) GetReadyClusters() ([]*federationapi.Cluster, er
 	result := make([]*federationapi.Cluster, 0, len(items))
 	for _, item := range items {
 		if cluster, ok := item.(*federationapi.Cluster); ok {
-			if isClusterReady(cluster) {
+			if f.isClusterUsable(cluster) {
 				result = append(result, cluster)
 			}
 		} else {
@@ -371,7 +479,7 @@ func (f *federatedInformerImpl) GetReadyCluster(name string) (*federationapi.Clu
 func (f *federatedInformerImpl) getReadyClusterUnlocked(name string) (*federationapi.Cluster, bool, error) {
 	if obj, exist, err := f.clusterInformer.store.GetByKey(name); exist && err == nil {
 		if cluster, ok := obj.(*federationapi.Cluster); ok {
-			if isClusterReady(cluster) {
+			if f.isClusterUsable(cluster) {
 				return cluster, true, nil
 			}
 			return nil, false, nil
@@ -386,7 +494,7 @@ func (f *federatedInformerImpl) getReadyClusterUnlocked(name string) (*federatio
 
 // Synced returns true if the view is synced (for the first time)
 func (f *federatedInformerImpl) ClustersSynced() bool {
-	return f.clusterInformer.controller.HasSynced()
+	return f.clusterInformer.controller.HasSynced() && f.unreadyClusterInformer.controller.HasSynced()
 }
 
 // Adds the given cluster to federated informer.
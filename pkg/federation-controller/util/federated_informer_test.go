@@ -17,12 +17,15 @@ limitations under the License.
 package util
 
 import (
+	"sort"
 	"testing"
 	"time"
 
 	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/watch"
 	kubeclientset "k8s.io/client-go/kubernetes"
 	fakekubeclientset "k8s.io/client-go/kubernetes/fake"
@@ -130,8 +133,8 @@ func TestFederatedInformer(t *testing.T) {
 	assert.EqualValues(t, &service, service1)
 	assert.Equal(t, "mycluster", <-addedClusters)
 
-	// All checked, lets delete the cluster.
-	deleteChan <- struct{}{}
+	// All checked, lets delete the cluster from all the cluster watches.
+	close(deleteChan)
 	for !informer.GetTargetStore().ClustersSynced([]*federationapi.Cluster{}) {
 		time.Sleep(time.Millisecond * 100)
 	}
@@ -148,3 +151,101 @@ func TestFederatedInformer(t *testing.T) {
 	// Test complete.
 	informer.Stop()
 }
+
+// Checks that clusters not serving the required resources, or reached through
+// their agent, are not considered ready, and that unready clusters are watched
+// separately.
+func TestFederatedInformerRequiredResources(t *testing.T) {
+	newCluster := func(name string, resources ...string) federationapi.Cluster {
+		return federationapi.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: federationapi.ClusterStatus{
+				Conditions: []federationapi.ClusterCondition{
+					{Type: federationapi.ClusterReady, Status: apiv1.ConditionTrue},
+				},
+				APIResources: []federationapi.ClusterAPIResources{
+					{GroupVersion: "v1", Resources: resources},
+				},
+			},
+		}
+	}
+	serving := newCluster("serving", "pods", "services")
+	missing := newCluster("missing", "pods")
+	pull := newCluster("pull", "pods", "services")
+	pull.Spec.ConnectionMode = federationapi.ClusterConnectionPull
+	unready := newCluster("unready", "pods", "services")
+	unready.Status.Conditions[0].Status = apiv1.ConditionFalse
+
+	var listSelectors []string
+	fakeFederationClient := &fakefederationclientset.Clientset{}
+	fakeFederationClient.AddReactor("list", "clusters", func(action core.Action) (bool, runtime.Object, error) {
+		selector := action.(core.ListAction).GetListRestrictions().Fields
+		listSelectors = append(listSelectors, selector.String())
+		list := &federationapi.ClusterList{}
+		for _, cluster := range []federationapi.Cluster{serving, missing, pull, unready} {
+			if selector.Matches(fields.Set{"status.ready": string(cluster.Status.Conditions[0].Status)}) {
+				list.Items = append(list.Items, cluster)
+			}
+		}
+		return true, list, nil
+	})
+	fakeFederationClient.AddWatchReactor("clusters", func(action core.Action) (bool, watch.Interface, error) {
+		return true, watch.NewFake(), nil
+	})
+
+	fakeKubeClient := &fakekubeclientset.Clientset{}
+	fakeKubeClient.AddReactor("list", "services", func(action core.Action) (bool, runtime.Object, error) {
+		return true, &apiv1.ServiceList{}, nil
+	})
+	fakeKubeClient.AddWatchReactor("services", func(action core.Action) (bool, watch.Interface, error) {
+		return true, watch.NewFake(), nil
+	})
+
+	addedClusters := make(chan string, 3)
+	targetInformerFactory := func(cluster *federationapi.Cluster, clientset kubeclientset.Interface) (cache.Store, cache.Controller) {
+		addedClusters <- cluster.Name
+		return cache.NewInformer(
+			&cache.ListWatch{
+				ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+					return clientset.Core().Services(metav1.NamespaceAll).List(options)
+				},
+				WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+					return clientset.Core().Services(metav1.NamespaceAll).Watch(options)
+				},
+			},
+			&apiv1.Service{},
+			10*time.Second,
+			cache.ResourceEventHandlerFuncs{})
+	}
+
+	services := []schema.GroupVersionResource{apiv1.SchemeGroupVersion.WithResource("services")}
+	informer := NewFederatedInformerForResources(fakeFederationClient, services, targetInformerFactory, &ClusterLifecycleHandlerFuncs{}).(*federatedInformerImpl)
+	informer.clientFactory = func(cluster *federationapi.Cluster) (kubeclientset.Interface, error) {
+		return fakeKubeClient, nil
+	}
+	informer.Start()
+	defer informer.Stop()
+
+	for !informer.ClustersSynced() {
+		time.Sleep(time.Millisecond * 100)
+	}
+	readyClusters, err := informer.GetReadyClusters()
+	assert.NoError(t, err)
+	assert.Equal(t, []*federationapi.Cluster{&serving}, readyClusters)
+	sort.Strings(listSelectors)
+	assert.Equal(t, []string{"status.ready!=True", "status.ready=True"}, listSelectors)
+	// The unready clusters are served from the informers without a request
+	// to the federation.
+	actions := len(fakeFederationClient.Actions())
+	unreadyClusters, err := informer.GetUnreadyClusters()
+	assert.NoError(t, err)
+	var unreadyNames []string
+	for _, cluster := range unreadyClusters {
+		unreadyNames = append(unreadyNames, cluster.Name)
+	}
+	sort.Strings(unreadyNames)
+	assert.Equal(t, []string{"missing", "pull", "unready"}, unreadyNames)
+	assert.Len(t, fakeFederationClient.Actions(), actions)
+	assert.Equal(t, "serving", <-addedClusters)
+	assert.Empty(t, addedClusters)
+}
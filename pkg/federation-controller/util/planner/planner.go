@@ -27,6 +27,9 @@ import (
 // federated clusters.
 type Planner struct {
 	preferences *fedapi.ReplicaAllocationPreferences
+	// topology is needed to honour topology spread constraints. The
+	// constraints are ignored if it is nil.
+	topology map[string]ClusterTopology
 }
 
 type namedClusterPreferences struct {
@@ -64,9 +67,26 @@ func NewPlanner(preferences *fedapi.ReplicaAllocationPreferences) *Planner {
 // * a map that contains information how many replicas will be possible to run in a cluster.
 // * a map that contains information how many extra replicas would be nice to schedule in a cluster so,
 //   if by chance, they are scheduled we will be closer to the desired replicas layout.
+// If the planner knows the cluster topology, replicas are first distributed among the
+// regions and zones according to the topology spread constraints of the preferences.
+// Clusters with higher priority are filled before clusters with lower priority.
 func (p *Planner) Plan(replicasToDistribute int64, availableClusters []string, currentReplicaCount map[string]int64,
 	estimatedCapacity map[string]int64, replicaSetKey string) (map[string]int64, map[string]int64) {
 
+	var plan, overflow map[string]int64
+	if constraints := p.activeConstraints(); len(constraints) > 0 {
+		plan, overflow = p.planSpread(constraints, replicasToDistribute, availableClusters, currentReplicaCount, estimatedCapacity, replicaSetKey)
+	} else {
+		plan, overflow = p.plan(replicasToDistribute, availableClusters, currentReplicaCount, estimatedCapacity, replicaSetKey)
+	}
+	p.limitDrainBack(plan, currentReplicaCount)
+	return plan, overflow
+}
+
+// planWeighted distributes the replicas among clusters of the same priority.
+func (p *Planner) planWeighted(replicasToDistribute int64, availableClusters []string, currentReplicaCount map[string]int64,
+	estimatedCapacity map[string]int64, replicaSetKey string) (map[string]int64, map[string]int64) {
+
 	preferences := make([]*namedClusterPreferences, 0, len(availableClusters))
 	plan := make(map[string]int64, len(preferences))
 	overflow := make(map[string]int64, len(preferences))
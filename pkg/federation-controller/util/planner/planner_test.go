@@ -346,3 +346,158 @@ func TestWeight(t *testing.T) {
 		91, []string{"A", "B", "C", "D", "E"},
 		map[string]int64{"A": 10, "B": 25, "C": 21, "D": 10, "E": 25})
 }
+
+func TestTopologySpread(t *testing.T) {
+	topology := map[string]ClusterTopology{
+		"eu1": {Region: "eu", Zones: []string{"eu-a"}},
+		"eu2": {Region: "eu", Zones: []string{"eu-b"}},
+		"us1": {Region: "us", Zones: []string{"us-a"}},
+	}
+	clusters := []string{"eu1", "eu2", "us1"}
+	doCheckSpread := func(pref map[string]fedapi.ClusterPreferences, constraints []fedapi.TopologySpreadConstraint,
+		replicas int64, existing map[string]int64, expected map[string]int64) *Planner {
+		planer := NewTopologyAwarePlanner(&fedapi.ReplicaAllocationPreferences{
+			Clusters:                  pref,
+			TopologySpreadConstraints: constraints,
+		}, topology)
+		plan, overflow := planer.Plan(replicas, clusters, existing, map[string]int64{}, "")
+		assert.EqualValues(t, expected, plan)
+		assert.Equal(t, 0, len(overflow))
+		return planer
+	}
+
+	// Skew between regions is bounded even if weights favour a single region.
+	doCheckSpread(map[string]fedapi.ClusterPreferences{
+		"eu1": {Weight: 10},
+		"eu2": {Weight: 10},
+		"us1": {Weight: 1}},
+		[]fedapi.TopologySpreadConstraint{{TopologyKey: fedapi.TopologyKeyRegion, MaxSkew: pint(1)}},
+		12, map[string]int64{},
+		map[string]int64{"eu1": 3, "eu2": 3, "us1": 6})
+
+	// Clusters already running replicas are kept when a region has too many.
+	doCheckSpread(map[string]fedapi.ClusterPreferences{
+		"*": {Weight: 1}},
+		[]fedapi.TopologySpreadConstraint{{TopologyKey: fedapi.TopologyKeyRegion, MaxClustersPerDomain: pint(1)}},
+		6, map[string]int64{"eu2": 2},
+		map[string]int64{"eu1": 0, "eu2": 3, "us1": 3})
+
+	// Replicas are moved to reach the minimum number of zones.
+	planer := doCheckSpread(map[string]fedapi.ClusterPreferences{
+		"eu1": {Weight: 100},
+		"eu2": {Weight: 1}},
+		[]fedapi.TopologySpreadConstraint{
+			{TopologyKey: fedapi.TopologyKeyZone, MinDomains: pint(2)},
+			{TopologyKey: fedapi.TopologyKeyRegion, MaxSkew: pint(5)},
+		},
+		2, map[string]int64{},
+		map[string]int64{"eu1": 1, "eu2": 1, "us1": 0})
+	assert.NoError(t, planer.CheckTopology(map[string]int64{"eu1": 1, "eu2": 1, "us1": 0}))
+	assert.Error(t, planer.CheckTopology(map[string]int64{"eu1": 2, "eu2": 0, "us1": 0}))
+
+	// Negative limits are ignored rather than panicking or looping forever.
+	doCheckSpread(map[string]fedapi.ClusterPreferences{
+		"*": {Weight: 1}},
+		[]fedapi.TopologySpreadConstraint{{TopologyKey: fedapi.TopologyKeyRegion, MaxClustersPerDomain: pint(-1), MaxSkew: pint(-1)}},
+		6, map[string]int64{},
+		map[string]int64{"eu1": 2, "eu2": 2, "us1": 2})
+
+	// A skew of 0 can not be met with an odd number of replicas.
+	doCheckSpread(map[string]fedapi.ClusterPreferences{
+		"*": {Weight: 1}},
+		[]fedapi.TopologySpreadConstraint{{TopologyKey: fedapi.TopologyKeyRegion, MaxSkew: pint(0)}},
+		5, map[string]int64{},
+		map[string]int64{"eu1": 2, "eu2": 1, "us1": 2})
+
+	// Constraints are ignored without topology.
+	planer = NewPlanner(&fedapi.ReplicaAllocationPreferences{
+		Clusters:                  map[string]fedapi.ClusterPreferences{"*": {Weight: 1}},
+		TopologySpreadConstraints: []fedapi.TopologySpreadConstraint{{TopologyKey: fedapi.TopologyKeyRegion, MinDomains: pint(5)}},
+	})
+	assert.NoError(t, planer.CheckTopology(map[string]int64{"eu1": 1}))
+}
+
+func TestPriorityTiers(t *testing.T) {
+	pref := map[string]fedapi.ClusterPreferences{
+		"onprem": {Weight: 1, Priority: 1},
+		"cloud1": {Weight: 1},
+		"cloud2": {Weight: 1},
+	}
+	clusters := []string{"onprem", "cloud1", "cloud2"}
+
+	// Lower tiers are only used once the higher tier is full.
+	doCheckWithExistingAndCapacity(t, true, pref, 10, clusters,
+		map[string]int64{},
+		map[string]int64{},
+		map[string]int64{"onprem": 10, "cloud1": 0, "cloud2": 0},
+		map[string]int64{})
+	doCheckWithExistingAndCapacity(t, true, pref, 10, clusters,
+		map[string]int64{"onprem": 6},
+		map[string]int64{"onprem": 6},
+		map[string]int64{"onprem": 6, "cloud1": 2, "cloud2": 2},
+		map[string]int64{"onprem": 4})
+
+	// Without rebalance replicas stay in lower tiers, but scale down starts there.
+	doCheckWithExistingAndCapacity(t, false, pref, 10, clusters,
+		map[string]int64{"onprem": 6, "cloud1": 2, "cloud2": 2},
+		map[string]int64{},
+		map[string]int64{"onprem": 6, "cloud1": 2, "cloud2": 2},
+		map[string]int64{})
+	doCheckWithExistingAndCapacity(t, false, pref, 7, clusters,
+		map[string]int64{"onprem": 6, "cloud1": 2, "cloud2": 2},
+		map[string]int64{},
+		map[string]int64{"onprem": 6, "cloud1": 1, "cloud2": 0},
+		map[string]int64{})
+}
+
+func TestDrainBack(t *testing.T) {
+	planer := NewPlanner(&fedapi.ReplicaAllocationPreferences{
+		Rebalance:            true,
+		MaxDrainBackReplicas: pint(3),
+		Clusters: map[string]fedapi.ClusterPreferences{
+			"onprem": {Weight: 1, Priority: 2},
+			"backup": {Weight: 1, Priority: 1},
+			"cloud":  {Weight: 1},
+		},
+	})
+	clusters := []string{"onprem", "backup", "cloud"}
+
+	// The cloud is drained first, at most 3 replicas at a time.
+	plan, _ := planer.Plan(10, clusters, map[string]int64{"onprem": 4, "backup": 3, "cloud": 3}, map[string]int64{}, "")
+	assert.EqualValues(t, map[string]int64{"onprem": 7, "backup": 3, "cloud": 0}, plan)
+	plan, _ = planer.Plan(10, clusters, map[string]int64{"onprem": 4, "backup": 3, "cloud": 2}, map[string]int64{}, "")
+	assert.EqualValues(t, map[string]int64{"onprem": 8, "backup": 2, "cloud": 0}, plan)
+
+	// Scaling down is not limited.
+	plan, _ = planer.Plan(4, clusters, map[string]int64{"onprem": 4, "backup": 3, "cloud": 3}, map[string]int64{}, "")
+	assert.EqualValues(t, map[string]int64{"onprem": 4, "backup": 0, "cloud": 0}, plan)
+}
+
+func TestRebalanceStep(t *testing.T) {
+	plan := map[string]int64{"A": 0, "B": 10}
+
+	// The target cluster is scaled up within the surge before the source is scaled down.
+	strategy := &fedapi.RebalanceStrategy{MaxSurge: pint(2)}
+	next, moved := RebalanceStep(strategy, 10, plan, map[string]int64{"A": 10}, map[string]int64{"A": 10}, -1)
+	assert.EqualValues(t, map[string]int64{"A": 10, "B": 2}, next)
+	assert.EqualValues(t, 2, moved)
+
+	// Once the new replicas are ready the source is scaled down.
+	next, moved = RebalanceStep(strategy, 10, plan, map[string]int64{"A": 10, "B": 2}, map[string]int64{"A": 10, "B": 2}, -1)
+	assert.EqualValues(t, map[string]int64{"A": 8, "B": 4}, next)
+	assert.EqualValues(t, 2, moved)
+
+	// Unready replicas do not count towards availability.
+	next, _ = RebalanceStep(strategy, 10, plan, map[string]int64{"A": 10, "B": 2}, map[string]int64{"A": 10, "B": 0}, -1)
+	assert.EqualValues(t, map[string]int64{"A": 10, "B": 2}, next)
+
+	// Moved replicas are limited, replicas added by scaling up are not.
+	strategy = &fedapi.RebalanceStrategy{MaxUnavailable: 1}
+	next, moved = RebalanceStep(strategy, 12, map[string]int64{"A": 2, "B": 10}, map[string]int64{"A": 10}, map[string]int64{"A": 10}, 3)
+	assert.EqualValues(t, map[string]int64{"A": 10, "B": 5}, next)
+	assert.EqualValues(t, 3, moved)
+
+	// Without surge and unavailability one replica is allowed to surge.
+	next, _ = RebalanceStep(&fedapi.RebalanceStrategy{MaxSurge: pint(0)}, 10, plan, map[string]int64{"A": 10}, map[string]int64{"A": 10}, -1)
+	assert.EqualValues(t, map[string]int64{"A": 10, "B": 1}, next)
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package planner
+
+import (
+	"math"
+	"sort"
+
+	fedapi "k8s.io/federation/apis/federation"
+)
+
+// RebalanceStep returns how many replicas each cluster should be asked to run next
+// to get from the current layout closer to the planned one within the limits of
+// the strategy, along with the number of replicas this moves between clusters.
+// Clusters that should lose replicas are only scaled down as far as the ready
+// replicas elsewhere allow. maxMoved limits the number of moved replicas; it is
+// ignored if negative.
+func RebalanceStep(strategy *fedapi.RebalanceStrategy, replicas int64, plan map[string]int64,
+	specReplicas map[string]int64, readyReplicas map[string]int64, maxMoved int64) (map[string]int64, int64) {
+
+	clusterSet := make(map[string]bool)
+	for cluster := range plan {
+		clusterSet[cluster] = true
+	}
+	for cluster := range specReplicas {
+		clusterSet[cluster] = true
+	}
+	clusters := make([]string, 0, len(clusterSet))
+	for cluster := range clusterSet {
+		clusters = append(clusters, cluster)
+	}
+	sort.Strings(clusters)
+
+	maxSurge := int64(math.MaxInt64)
+	if strategy.MaxSurge != nil {
+		maxSurge = *strategy.MaxSurge
+	}
+	maxUnavailable := strategy.MaxUnavailable
+	if maxSurge == 0 && maxUnavailable == 0 {
+		maxSurge = 1
+	}
+
+	next := make(map[string]int64, len(clusters))
+	totalSpec, totalReady := int64(0), int64(0)
+	for _, cluster := range clusters {
+		next[cluster] = specReplicas[cluster]
+		totalSpec += specReplicas[cluster]
+		totalReady += minInt64(readyReplicas[cluster], specReplicas[cluster])
+	}
+
+	// Scale down first. Replicas that are not ready can go right away, ready
+	// ones only while enough replicas stay ready across all clusters.
+	removableReady := maxInt64(0, totalReady-(replicas-maxUnavailable))
+	totalNext := totalSpec
+	for _, cluster := range clusters {
+		spec := specReplicas[cluster]
+		if spec <= plan[cluster] {
+			continue
+		}
+		notReady := spec - minInt64(readyReplicas[cluster], spec)
+		remove := minInt64(spec-plan[cluster], notReady+removableReady)
+		removableReady -= maxInt64(0, remove-notReady)
+		next[cluster] = spec - remove
+		totalNext -= remove
+	}
+
+	// Then scale up, within the surge. Replicas added because the workload
+	// grew are not moved from anywhere and do not count against maxMoved.
+	grown := maxInt64(0, replicas-totalSpec)
+	room := int64(math.MaxInt64)
+	if maxSurge != math.MaxInt64 {
+		room = maxInt64(0, replicas+maxSurge-totalNext)
+	}
+	if maxMoved >= 0 {
+		room = minInt64(room, grown+maxMoved)
+	}
+	added := int64(0)
+	for _, cluster := range clusters {
+		spec := specReplicas[cluster]
+		if spec >= plan[cluster] {
+			continue
+		}
+		add := minInt64(plan[cluster]-spec, room)
+		next[cluster] = spec + add
+		room -= add
+		added += add
+	}
+	return next, maxInt64(0, added-grown)
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package planner
+
+import (
+	"sort"
+)
+
+// tiers groups the clusters that have preferences by decreasing priority.
+// Clusters without preferences are returned separately.
+func (p *Planner) tiers(availableClusters []string) ([][]string, []string) {
+	clustersByPriority := make(map[int64][]string)
+	var priorities []int64
+	var unwanted []string
+	for _, cluster := range availableClusters {
+		pref, found := p.clusterPreferences(cluster)
+		if !found {
+			unwanted = append(unwanted, cluster)
+			continue
+		}
+		if _, found := clustersByPriority[pref.Priority]; !found {
+			priorities = append(priorities, pref.Priority)
+		}
+		clustersByPriority[pref.Priority] = append(clustersByPriority[pref.Priority], cluster)
+	}
+	sort.Slice(priorities, func(i, j int) bool { return priorities[i] > priorities[j] })
+
+	tiers := make([][]string, 0, len(priorities))
+	for _, priority := range priorities {
+		tiers = append(tiers, clustersByPriority[priority])
+	}
+	return tiers, unwanted
+}
+
+// plan fills the priority tiers one after another, each tier getting the
+// replicas the higher tiers could not take. If rebalance is disabled a tier
+// only takes replicas running in lower tiers when there are more replicas
+// than currently running.
+func (p *Planner) plan(replicasToDistribute int64, availableClusters []string, currentReplicaCount map[string]int64,
+	estimatedCapacity map[string]int64, replicaSetKey string) (map[string]int64, map[string]int64) {
+
+	tiers, unwanted := p.tiers(availableClusters)
+	if len(tiers) <= 1 {
+		return p.planWeighted(replicasToDistribute, availableClusters, currentReplicaCount, estimatedCapacity, replicaSetKey)
+	}
+
+	plan := make(map[string]int64, len(availableClusters))
+	overflow := make(map[string]int64)
+	for _, cluster := range unwanted {
+		plan[cluster] = 0
+	}
+
+	// Number of currently running replicas each tier keeps if rebalance is disabled.
+	kept := make([]int64, len(tiers))
+	if !p.preferences.Rebalance {
+		for i, tier := range tiers {
+			for _, cluster := range tier {
+				pref, _ := p.clusterPreferences(cluster)
+				count := currentReplicaCount[cluster]
+				if pref.MaxReplicas != nil {
+					count = minInt64(count, *pref.MaxReplicas)
+				}
+				if capacity, hasCapacity := estimatedCapacity[cluster]; hasCapacity {
+					count = minInt64(count, capacity)
+				}
+				kept[i] += count
+			}
+		}
+	}
+
+	remainingReplicas := replicasToDistribute
+	for i, tier := range tiers {
+		budget := remainingReplicas
+		if !p.preferences.Rebalance {
+			keptBelow := int64(0)
+			for _, count := range kept[i+1:] {
+				keptBelow += count
+			}
+			budget = maxInt64(remainingReplicas-keptBelow, minInt64(remainingReplicas, kept[i]))
+		}
+
+		tierPlan, tierOverflow := p.planWeighted(budget, tier, currentReplicaCount, estimatedCapacity, replicaSetKey)
+		for cluster, replicas := range tierPlan {
+			plan[cluster] = replicas
+			remainingReplicas -= replicas
+		}
+		for cluster, replicas := range tierOverflow {
+			overflow[cluster] = replicas
+		}
+	}
+
+	if !p.preferences.Rebalance {
+		// As in planWeighted, overflow is trimmed at the level of replicas
+		// that could not be placed in any tier.
+		for cluster, replicas := range overflow {
+			replicas = minInt64(replicas, remainingReplicas)
+			if replicas > 0 {
+				overflow[cluster] = replicas
+			} else {
+				delete(overflow, cluster)
+			}
+		}
+	}
+	return plan, overflow
+}
+
+type replicaChange struct {
+	clusterName string
+	priority    int64
+	replicas    int64
+}
+
+// byPriority sorts changes by decreasing priority and increasing cluster name.
+type byPriority []*replicaChange
+
+func (a byPriority) Len() int      { return len(a) }
+func (a byPriority) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+func (a byPriority) Less(i, j int) bool {
+	return a[i].priority > a[j].priority || (a[i].priority == a[j].priority && a[i].clusterName < a[j].clusterName)
+}
+
+// limitDrainBack keeps replicas in lower priority clusters if moving all of them
+// back to higher priority clusters would exceed MaxDrainBackReplicas. Replicas
+// in the lowest priority clusters are moved back first.
+func (p *Planner) limitDrainBack(plan map[string]int64, currentReplicaCount map[string]int64) {
+	if !p.preferences.Rebalance || p.preferences.MaxDrainBackReplicas == nil {
+		return
+	}
+
+	var gains, losses []*replicaChange
+	for cluster, planned := range plan {
+		pref, found := p.clusterPreferences(cluster)
+		if !found {
+			continue
+		}
+		current := currentReplicaCount[cluster]
+		if planned > current {
+			gains = append(gains, &replicaChange{clusterName: cluster, priority: pref.Priority, replicas: planned - current})
+		} else if planned < current {
+			losses = append(losses, &replicaChange{clusterName: cluster, priority: pref.Priority, replicas: current - planned})
+		}
+	}
+	sort.Sort(byPriority(gains))
+	sort.Sort(byPriority(losses))
+
+	// Pair losses with gains in clusters of higher priority. Going through both by
+	// decreasing priority finds the largest number of replicas moved back.
+	type move struct {
+		from, to string
+		replicas int64
+	}
+	var moves []move
+	moved := int64(0)
+	g := 0
+	for _, loss := range losses {
+		for loss.replicas > 0 && g < len(gains) && gains[g].priority > loss.priority {
+			replicas := minInt64(loss.replicas, gains[g].replicas)
+			moves = append(moves, move{from: loss.clusterName, to: gains[g].clusterName, replicas: replicas})
+			moved += replicas
+			loss.replicas -= replicas
+			gains[g].replicas -= replicas
+			if gains[g].replicas == 0 {
+				g++
+			}
+		}
+	}
+
+	// Undo the moves out of the highest priority clusters first.
+	excess := moved - *p.preferences.MaxDrainBackReplicas
+	for _, m := range moves {
+		if excess <= 0 {
+			break
+		}
+		replicas := minInt64(m.replicas, excess)
+		plan[m.from] += replicas
+		plan[m.to] -= replicas
+		excess -= replicas
+	}
+}
+
+func maxInt64(a int64, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
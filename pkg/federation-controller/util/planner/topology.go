@@ -0,0 +1,336 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package planner
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+
+	fedapi "k8s.io/federation/apis/federation"
+	federationapi "k8s.io/federation/apis/federation/v1beta1"
+)
+
+// ClusterTopology describes where the nodes of a cluster run.
+type ClusterTopology struct {
+	Region string
+	Zones  []string
+}
+
+// TopologyFromClusters returns the topology reported in the status of the given clusters.
+func TopologyFromClusters(clusters []*federationapi.Cluster) map[string]ClusterTopology {
+	topology := make(map[string]ClusterTopology, len(clusters))
+	for _, cluster := range clusters {
+		topology[cluster.Name] = ClusterTopology{
+			Region: cluster.Status.Region,
+			Zones:  cluster.Status.Zones,
+		}
+	}
+	return topology
+}
+
+// NewTopologyAwarePlanner returns a planner that honours the topology spread
+// constraints of the preferences, using the given cluster topology.
+func NewTopologyAwarePlanner(preferences *fedapi.ReplicaAllocationPreferences, topology map[string]ClusterTopology) *Planner {
+	return &Planner{
+		preferences: preferences,
+		topology:    topology,
+	}
+}
+
+// activeConstraints returns the topology spread constraints the planner can
+// enforce, region first, at most one per topology key. Negative limits are
+// invalid and ignored: the preferences are read from annotations that are not
+// necessarily validated.
+func (p *Planner) activeConstraints() []fedapi.TopologySpreadConstraint {
+	if p.topology == nil {
+		return nil
+	}
+	var region, zone *fedapi.TopologySpreadConstraint
+	for i := range p.preferences.TopologySpreadConstraints {
+		constraint := &p.preferences.TopologySpreadConstraints[i]
+		switch constraint.TopologyKey {
+		case fedapi.TopologyKeyRegion:
+			if region == nil {
+				region = constraint
+			}
+		case fedapi.TopologyKeyZone:
+			if zone == nil {
+				zone = constraint
+			}
+		}
+	}
+	var constraints []fedapi.TopologySpreadConstraint
+	for _, constraint := range []*fedapi.TopologySpreadConstraint{region, zone} {
+		if constraint == nil {
+			continue
+		}
+		constraints = append(constraints, fedapi.TopologySpreadConstraint{
+			TopologyKey:          constraint.TopologyKey,
+			MaxClustersPerDomain: nonNegative(constraint.MaxClustersPerDomain),
+			MaxSkew:              nonNegative(constraint.MaxSkew),
+			MinDomains:           nonNegative(constraint.MinDomains),
+		})
+	}
+	return constraints
+}
+
+// nonNegative returns the given limit, or nil if it is negative.
+func nonNegative(limit *int64) *int64 {
+	if limit == nil || *limit < 0 {
+		return nil
+	}
+	return limit
+}
+
+// domain returns the topology domain of the cluster for the given key. A
+// cluster spanning several zones forms a zone domain with every cluster that
+// spans the same set of zones.
+func (p *Planner) domain(key fedapi.TopologyKey, clusterName string) string {
+	topology := p.topology[clusterName]
+	if key == fedapi.TopologyKeyZone {
+		zones := append([]string(nil), topology.Zones...)
+		sort.Strings(zones)
+		return strings.Join(zones, ",")
+	}
+	return topology.Region
+}
+
+// clusterPreferences returns the preferences that apply to the cluster and
+// whether the cluster may get any replicas at all.
+func (p *Planner) clusterPreferences(clusterName string) (fedapi.ClusterPreferences, bool) {
+	if pref, found := p.preferences.Clusters[clusterName]; found {
+		return pref, true
+	}
+	pref, found := p.preferences.Clusters["*"]
+	return pref, found
+}
+
+// planSpread distributes the replicas among the domains of the first
+// constraint, enforcing it, and then among the clusters of every domain,
+// recursing for the remaining constraints.
+func (p *Planner) planSpread(constraints []fedapi.TopologySpreadConstraint, replicasToDistribute int64, availableClusters []string,
+	currentReplicaCount map[string]int64, estimatedCapacity map[string]int64, replicaSetKey string) (map[string]int64, map[string]int64) {
+
+	constraint := constraints[0]
+	plan := make(map[string]int64, len(availableClusters))
+	overflow := make(map[string]int64)
+
+	domains := make(map[string][]string)
+	for _, cluster := range availableClusters {
+		if _, found := p.clusterPreferences(cluster); !found {
+			plan[cluster] = 0
+			continue
+		}
+		domain := p.domain(constraint.TopologyKey, cluster)
+		domains[domain] = append(domains[domain], cluster)
+	}
+
+	if constraint.MaxClustersPerDomain != nil {
+		max := *constraint.MaxClustersPerDomain
+		for domain, clusters := range domains {
+			if int64(len(clusters)) <= max {
+				continue
+			}
+			p.sortForRetention(clusters, currentReplicaCount, replicaSetKey)
+			for _, cluster := range clusters[max:] {
+				plan[cluster] = 0
+			}
+			domains[domain] = clusters[:max]
+		}
+	}
+
+	domainNames := make([]string, 0, len(domains))
+	domainPrefs := make(map[string]fedapi.ClusterPreferences, len(domains))
+	domainCurrent := make(map[string]int64, len(domains))
+	domainCapacity := make(map[string]int64, len(domains))
+	for domain, clusters := range domains {
+		domainNames = append(domainNames, domain)
+		pref := fedapi.ClusterPreferences{}
+		bounded, capacityKnown := true, true
+		max, capacity := int64(0), int64(0)
+		for _, cluster := range clusters {
+			clusterPref, _ := p.clusterPreferences(cluster)
+			pref.Weight += clusterPref.Weight
+			pref.MinReplicas += clusterPref.MinReplicas
+			if clusterPref.MaxReplicas == nil {
+				bounded = false
+			} else {
+				max += *clusterPref.MaxReplicas
+			}
+			domainCurrent[domain] += currentReplicaCount[cluster]
+			if clusterCapacity, found := estimatedCapacity[cluster]; found {
+				capacity += clusterCapacity
+			} else {
+				capacityKnown = false
+			}
+		}
+		if bounded {
+			pref.MaxReplicas = &max
+		}
+		if capacityKnown {
+			domainCapacity[domain] = capacity
+		}
+		domainPrefs[domain] = pref
+	}
+	sort.Strings(domainNames)
+
+	domainPlanner := NewPlanner(&fedapi.ReplicaAllocationPreferences{
+		Rebalance: p.preferences.Rebalance,
+		Clusters:  domainPrefs,
+	})
+	shares, _ := domainPlanner.Plan(replicasToDistribute, domainNames, domainCurrent, domainCapacity, replicaSetKey)
+	enforceMinDomains(shares, domainNames, domainPrefs, constraint.MinDomains)
+	enforceMaxSkew(shares, domainNames, domainPrefs, constraint.MaxSkew)
+
+	for _, domain := range domainNames {
+		var domainPlan, domainOverflow map[string]int64
+		if len(constraints) > 1 {
+			domainPlan, domainOverflow = p.planSpread(constraints[1:], shares[domain], domains[domain], currentReplicaCount, estimatedCapacity, replicaSetKey)
+		} else {
+			domainPlan, domainOverflow = p.plan(shares[domain], domains[domain], currentReplicaCount, estimatedCapacity, replicaSetKey)
+		}
+		for cluster, replicas := range domainPlan {
+			plan[cluster] = replicas
+		}
+		for cluster, replicas := range domainOverflow {
+			overflow[cluster] = replicas
+		}
+	}
+	return plan, overflow
+}
+
+// sortForRetention orders clusters by the preference to keep them when a
+// domain has too many: clusters already running more replicas first, then
+// by decreasing weight and increasing hash.
+func (p *Planner) sortForRetention(clusters []string, currentReplicaCount map[string]int64, replicaSetKey string) {
+	hash := func(name string) uint32 {
+		hasher := fnv.New32()
+		hasher.Write([]byte(name))
+		hasher.Write([]byte(replicaSetKey))
+		return hasher.Sum32()
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		ci, cj := currentReplicaCount[clusters[i]], currentReplicaCount[clusters[j]]
+		if ci != cj {
+			return ci > cj
+		}
+		pi, _ := p.clusterPreferences(clusters[i])
+		pj, _ := p.clusterPreferences(clusters[j])
+		if pi.Weight != pj.Weight {
+			return pi.Weight > pj.Weight
+		}
+		return hash(clusters[i]) < hash(clusters[j])
+	})
+}
+
+// canGrow returns whether the domain may receive one more replica.
+func canGrow(domain string, shares map[string]int64, prefs map[string]fedapi.ClusterPreferences) bool {
+	pref := prefs[domain]
+	if pref.Weight <= 0 {
+		return false
+	}
+	return pref.MaxReplicas == nil || shares[domain] < *pref.MaxReplicas
+}
+
+// enforceMinDomains moves single replicas from the largest domains to empty
+// ones until at least minDomains domains have replicas, or no more moves are possible.
+func enforceMinDomains(shares map[string]int64, domains []string, prefs map[string]fedapi.ClusterPreferences, minDomains *int64) {
+	if minDomains == nil {
+		return
+	}
+	for {
+		used := int64(0)
+		for _, domain := range domains {
+			if shares[domain] > 0 {
+				used++
+			}
+		}
+		if used >= *minDomains {
+			return
+		}
+
+		donor, recipient := "", ""
+		for _, domain := range domains {
+			if shares[domain] > 1 && (donor == "" || shares[domain] > shares[donor]) {
+				donor = domain
+			}
+			if shares[domain] == 0 && canGrow(domain, shares, prefs) &&
+				(recipient == "" || prefs[domain].Weight > prefs[recipient].Weight) {
+				recipient = domain
+			}
+		}
+		if donor == "" || recipient == "" {
+			return
+		}
+		shares[donor]--
+		shares[recipient]++
+	}
+}
+
+// enforceMaxSkew moves single replicas from the largest domain to the
+// smallest one that can grow until the difference between them is at most
+// maxSkew. A difference of 1 is kept even for a maxSkew of 0, since moving a
+// replica would only swap the domains.
+func enforceMaxSkew(shares map[string]int64, domains []string, prefs map[string]fedapi.ClusterPreferences, maxSkew *int64) {
+	if maxSkew == nil || *maxSkew < 0 {
+		return
+	}
+	for {
+		largest, smallest := "", ""
+		for _, domain := range domains {
+			if prefs[domain].Weight <= 0 {
+				continue
+			}
+			if largest == "" || shares[domain] > shares[largest] {
+				largest = domain
+			}
+			if canGrow(domain, shares, prefs) && (smallest == "" || shares[domain] < shares[smallest]) {
+				smallest = domain
+			}
+		}
+		skew := shares[largest] - shares[smallest]
+		if largest == "" || smallest == "" || skew <= *maxSkew || skew <= 1 {
+			return
+		}
+		shares[largest]--
+		shares[smallest]++
+	}
+}
+
+// CheckTopology returns an error if the plan does not satisfy the topology
+// spread constraints that can not be enforced by moving replicas, namely the
+// minimum number of domains.
+func (p *Planner) CheckTopology(plan map[string]int64) error {
+	for _, constraint := range p.activeConstraints() {
+		if constraint.MinDomains == nil {
+			continue
+		}
+		used := make(map[string]bool)
+		for cluster, replicas := range plan {
+			if replicas > 0 {
+				used[p.domain(constraint.TopologyKey, cluster)] = true
+			}
+		}
+		if int64(len(used)) < *constraint.MinDomains {
+			return fmt.Errorf("replicas can only be placed in %d %s domains, at least %d are required",
+				len(used), constraint.TopologyKey, *constraint.MinDomains)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package propagationpolicy finds the propagation policies applying to
+// federated objects and turns them into the placement annotations the
+// controllers already understand.
+package propagationpolicy
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	pkgruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	federationapi "k8s.io/federation/apis/federation/v1beta1"
+	federationclientset "k8s.io/federation/client/clientset_generated/federation_clientset"
+	"k8s.io/kubernetes/pkg/controller"
+)
+
+// NewInformer returns an informer on the propagation policies of the federation.
+func NewInformer(client federationclientset.Interface, handler cache.ResourceEventHandler) (cache.Store, cache.Controller) {
+	return cache.NewInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (pkgruntime.Object, error) {
+				return client.Federation().PropagationPolicies().List(options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				return client.Federation().PropagationPolicies().Watch(options)
+			},
+		},
+		&federationapi.PropagationPolicy{},
+		controller.NoResyncPeriodFunc(),
+		handler,
+	)
+}
+
+// Matches returns whether the policy applies to the given object of the given
+// kind. Kinds are compared case-insensitively.
+func Matches(policy *federationapi.PropagationPolicy, kind string, objMeta metav1.Object) (bool, error) {
+	for _, selector := range policy.Spec.ResourceSelectors {
+		if !strings.EqualFold(selector.Kind, kind) {
+			continue
+		}
+		if len(selector.Namespaces) > 0 && !containsString(selector.Namespaces, objMeta.GetNamespace()) {
+			continue
+		}
+		if selector.LabelSelector != nil {
+			labelSelector, err := metav1.LabelSelectorAsSelector(selector.LabelSelector)
+			if err != nil {
+				return false, fmt.Errorf("invalid label selector in propagation policy %q: %v", policy.Name, err)
+			}
+			if !labelSelector.Matches(labels.Set(objMeta.GetLabels())) {
+				continue
+			}
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// Find returns the policy that applies to the given object of the given kind,
+// or nil if there is none. Of several applying policies the one with the
+// highest priority is returned, ties are broken by name.
+func Find(store cache.Store, kind string, obj pkgruntime.Object) (*federationapi.PropagationPolicy, error) {
+	objMeta, err := meta.Accessor(obj)
+	if err != nil {
+		return nil, err
+	}
+	var found *federationapi.PropagationPolicy
+	for _, item := range store.List() {
+		policy := item.(*federationapi.PropagationPolicy)
+		matches, err := Matches(policy, kind, objMeta)
+		if err != nil {
+			return nil, err
+		}
+		if !matches {
+			continue
+		}
+		if found == nil || policy.Spec.Priority > found.Spec.Priority ||
+			(policy.Spec.Priority == found.Spec.Priority && policy.Name < found.Name) {
+			found = policy
+		}
+	}
+	return found, nil
+}
+
+// Apply returns a copy of the given object whose annotations describe the
+// placement defined by the policy: the cluster selector, the cluster set and,
+// if preferencesAnnotation is not empty, the replica preferences. Annotations
+// already set on the object take precedence over the policy.
+func Apply(policy *federationapi.PropagationPolicy, obj pkgruntime.Object, preferencesAnnotation string) (pkgruntime.Object, error) {
+	placementObj := obj.DeepCopyObject()
+	objMeta, err := meta.Accessor(placementObj)
+	if err != nil {
+		return nil, err
+	}
+	annotations := objMeta.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+
+	if _, found := annotations[federationapi.FederationClusterSelectorAnnotation]; !found && len(policy.Spec.ClusterSelector) > 0 {
+		selector, err := json.Marshal(policy.Spec.ClusterSelector)
+		if err != nil {
+			return nil, err
+		}
+		annotations[federationapi.FederationClusterSelectorAnnotation] = string(selector)
+	}
+	if _, found := annotations[federationapi.FederationClusterSetAnnotation]; !found && policy.Spec.ClusterSet != "" {
+		annotations[federationapi.FederationClusterSetAnnotation] = policy.Spec.ClusterSet
+	}
+	if _, found := annotations[preferencesAnnotation]; !found && preferencesAnnotation != "" && policy.Spec.ReplicaPreferences != nil {
+		preferences, err := json.Marshal(policy.Spec.ReplicaPreferences)
+		if err != nil {
+			return nil, err
+		}
+		annotations[preferencesAnnotation] = string(preferences)
+	}
+
+	objMeta.SetAnnotations(annotations)
+	return placementObj, nil
+}
+
+// PlacementObject returns the object to take the placement of the given object
+// from: the object itself if no policy applies to it, or a copy with the
+// annotations of the applying policy otherwise.
+func PlacementObject(store cache.Store, kind string, obj pkgruntime.Object, preferencesAnnotation string) (pkgruntime.Object, error) {
+	policy, err := Find(store, kind, obj)
+	if err != nil || policy == nil {
+		return obj, err
+	}
+	return Apply(policy, obj, preferencesAnnotation)
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package propagationpolicy
+
+import (
+	"testing"
+
+	extensionsv1 "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	federationapi "k8s.io/federation/apis/federation/v1beta1"
+	"k8s.io/federation/pkg/federation-controller/util/replicapreferences"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const preferencesAnnotation = "federation.kubernetes.io/replica-set-preferences"
+
+func newPolicy(name string, priority int32, selector federationapi.ResourceSelector) *federationapi.PropagationPolicy {
+	return &federationapi.PropagationPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: federationapi.PropagationPolicySpec{
+			ResourceSelectors: []federationapi.ResourceSelector{selector},
+			Priority:          priority,
+			ClusterSelector: []federationapi.ClusterSelectorRequirement{
+				{Key: "environment", Operator: "in", Values: []string{name}},
+			},
+		},
+	}
+}
+
+func newReplicaSet(namespace string, labels, annotations map[string]string) *extensionsv1.ReplicaSet {
+	return &extensionsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "frontend",
+			Namespace:   namespace,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+	}
+}
+
+func TestFind(t *testing.T) {
+	store := cache.NewStore(cache.MetaNamespaceKeyFunc)
+	store.Add(newPolicy("all-replicasets", 0, federationapi.ResourceSelector{Kind: "ReplicaSet"}))
+	store.Add(newPolicy("deployments", 10, federationapi.ResourceSelector{Kind: "Deployment"}))
+	store.Add(newPolicy("prod", 5, federationapi.ResourceSelector{
+		Kind:       "replicaset",
+		Namespaces: []string{"prod"},
+	}))
+	store.Add(newPolicy("frontend", 5, federationapi.ResourceSelector{
+		Kind:          "ReplicaSet",
+		LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "frontend"}},
+	}))
+
+	tests := []struct {
+		name     string
+		obj      *extensionsv1.ReplicaSet
+		expected string
+	}{
+		{"only policy for the kind", newReplicaSet("default", nil, nil), "all-replicasets"},
+		{"higher priority in namespace", newReplicaSet("prod", nil, nil), "prod"},
+		{"higher priority by label", newReplicaSet("default", map[string]string{"app": "frontend"}, nil), "frontend"},
+		{"equal priority ordered by name", newReplicaSet("prod", map[string]string{"app": "frontend"}, nil), "frontend"},
+	}
+	for _, test := range tests {
+		policy, err := Find(store, "replicaset", test.obj)
+		require.NoError(t, err, test.name)
+		require.NotNil(t, policy, test.name)
+		assert.Equal(t, test.expected, policy.Name, test.name)
+	}
+
+	policy, err := Find(store, "job", newReplicaSet("default", nil, nil))
+	require.NoError(t, err)
+	assert.Nil(t, policy)
+}
+
+func TestApply(t *testing.T) {
+	policy := newPolicy("prod", 0, federationapi.ResourceSelector{Kind: "ReplicaSet"})
+	maxReplicas := int64(3)
+	policy.Spec.ReplicaPreferences = &federationapi.ReplicaAllocationPreferences{
+		Rebalance: true,
+		Clusters: map[string]federationapi.ClusterPreferences{
+			"*": {Weight: 1, MaxReplicas: &maxReplicas},
+		},
+	}
+	policy.Spec.ClusterSet = "prod-eu"
+
+	obj := newReplicaSet("default", nil, nil)
+	placementObj, err := Apply(policy, obj, preferencesAnnotation)
+	require.NoError(t, err)
+	assert.Empty(t, obj.Annotations, "the given object must not be modified")
+
+	annotations := placementObj.(*extensionsv1.ReplicaSet).Annotations
+	assert.Equal(t, `[{"key":"environment","operator":"in","values":["prod"]}]`, annotations[federationapi.FederationClusterSelectorAnnotation])
+	assert.Equal(t, "prod-eu", annotations[federationapi.FederationClusterSetAnnotation])
+	prefs, err := replicapreferences.GetAllocationPreferences(placementObj, preferencesAnnotation)
+	require.NoError(t, err)
+	require.NotNil(t, prefs)
+	assert.True(t, prefs.Rebalance)
+	assert.Equal(t, int64(1), prefs.Clusters["*"].Weight)
+	assert.Equal(t, maxReplicas, *prefs.Clusters["*"].MaxReplicas)
+
+	// Annotations on the object override the policy.
+	obj = newReplicaSet("default", nil, map[string]string{
+		federationapi.FederationClusterSelectorAnnotation: `[]`,
+		federationapi.FederationClusterSetAnnotation:      "canary",
+	})
+	placementObj, err = Apply(policy, obj, "")
+	require.NoError(t, err)
+	annotations = placementObj.(*extensionsv1.ReplicaSet).Annotations
+	assert.Equal(t, `[]`, annotations[federationapi.FederationClusterSelectorAnnotation])
+	assert.Equal(t, "canary", annotations[federationapi.FederationClusterSetAnnotation])
+	_, found := annotations[preferencesAnnotation]
+	assert.False(t, found)
+}
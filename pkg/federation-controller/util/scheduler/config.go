@@ -0,0 +1,168 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// Config lists the scheduler profiles known to the controllers.
+type Config struct {
+	Profiles []ProfileConfig `json:"profiles"`
+}
+
+// ProfileConfig describes a single scheduler profile.
+type ProfileConfig struct {
+	// Name is the value of the scheduler profile annotation selecting this
+	// profile. A profile named "default" replaces the built-in default.
+	Name string `json:"name"`
+	// Filters are the names of the filter plugins to run, in order.
+	// +optional
+	Filters []string `json:"filters,omitempty"`
+	// Scores are the score plugins to run.
+	// +optional
+	Scores []ScorePluginConfig `json:"scores,omitempty"`
+	// Extenders are called after the built-in plugins.
+	// +optional
+	Extenders []ExtenderConfig `json:"extenders,omitempty"`
+}
+
+// ScorePluginConfig names a score plugin and the weight of its scores.
+type ScorePluginConfig struct {
+	Name string `json:"name"`
+	// Weight multiplies the plugin scores. 1 if unset.
+	// +optional
+	Weight int64 `json:"weight,omitempty"`
+}
+
+// ExtenderConfig describes how to reach a scheduler extender.
+type ExtenderConfig struct {
+	// URLPrefix is the base URL of the extender, e.g. "http://127.0.0.1:8888/scheduler".
+	URLPrefix string `json:"urlPrefix"`
+	// FilterVerb is appended to URLPrefix for filter calls. No filter calls
+	// are made if empty.
+	// +optional
+	FilterVerb string `json:"filterVerb,omitempty"`
+	// PrioritizeVerb is appended to URLPrefix for prioritize calls. No
+	// prioritize calls are made if empty.
+	// +optional
+	PrioritizeVerb string `json:"prioritizeVerb,omitempty"`
+	// Weight multiplies the extender scores. 1 if unset.
+	// +optional
+	Weight int64 `json:"weight,omitempty"`
+	// HTTPTimeout bounds every call to the extender.
+	// +optional
+	HTTPTimeout metav1.Duration `json:"httpTimeout,omitempty"`
+	// Ignorable lets scheduling proceed without the extender when it fails.
+	// +optional
+	Ignorable bool `json:"ignorable,omitempty"`
+}
+
+// LoadConfig decodes a yaml or json scheduler configuration.
+func LoadConfig(r io.Reader) (*Config, error) {
+	var config Config
+	if err := yaml.NewYAMLOrJSONDecoder(r, 4096).Decode(&config); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// NewFrameworkFromFile builds a framework from the scheduler configuration
+// in the given file. The default framework is returned if path is empty.
+func NewFrameworkFromFile(path string) (*Framework, error) {
+	if len(path) == 0 {
+		return NewDefaultFramework(), nil
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	config, err := LoadConfig(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load scheduler configuration %q: %v", path, err)
+	}
+	return NewFramework(config)
+}
+
+// NewFramework builds a framework with the profiles of config on top of the
+// built-in default profile.
+func NewFramework(config *Config) (*Framework, error) {
+	framework := NewDefaultFramework()
+	for i := range config.Profiles {
+		profileConfig := &config.Profiles[i]
+		if len(profileConfig.Name) == 0 {
+			return nil, fmt.Errorf("scheduler profile %d has no name", i)
+		}
+		profile, err := newProfile(profileConfig)
+		if err != nil {
+			return nil, fmt.Errorf("scheduler profile %q: %v", profileConfig.Name, err)
+		}
+		framework.profiles[profile.name] = profile
+	}
+	return framework, nil
+}
+
+func newProfile(config *ProfileConfig) (*Profile, error) {
+	profile := &Profile{name: config.Name}
+	for _, name := range config.Filters {
+		filter, found := filterPlugins[name]
+		if !found {
+			return nil, fmt.Errorf("unknown filter plugin %q", name)
+		}
+		profile.filters = append(profile.filters, filter)
+	}
+	for _, scoreConfig := range config.Scores {
+		scorer, found := scorePlugins[scoreConfig.Name]
+		if !found {
+			return nil, fmt.Errorf("unknown score plugin %q", scoreConfig.Name)
+		}
+		weight, err := pluginWeight(scoreConfig.Weight)
+		if err != nil {
+			return nil, err
+		}
+		profile.scores = append(profile.scores, weightedScorePlugin{ScorePlugin: scorer, weight: weight})
+	}
+	for i := range config.Extenders {
+		extender, err := newHTTPExtender(&config.Extenders[i])
+		if err != nil {
+			return nil, err
+		}
+		weight, err := pluginWeight(config.Extenders[i].Weight)
+		if err != nil {
+			return nil, err
+		}
+		profile.extenders = append(profile.extenders, weightedExtender{Extender: extender, weight: weight})
+	}
+	return profile, nil
+}
+
+func pluginWeight(weight int64) (int64, error) {
+	if weight < 0 {
+		return 0, fmt.Errorf("weight must not be negative")
+	}
+	if weight == 0 {
+		return 1, nil
+	}
+	return weight, nil
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	federationapi "k8s.io/federation/apis/federation/v1beta1"
+)
+
+const defaultExtenderTimeout = 5 * time.Second
+
+// ExtenderArgs is the body posted to a scheduler extender.
+type ExtenderArgs struct {
+	// Object is the federated workload being scheduled.
+	Object json.RawMessage `json:"object"`
+	// Clusters are the clusters that are still feasible.
+	Clusters []federationapi.Cluster `json:"clusters"`
+	// CurrentReplicas is the number of running replicas per cluster.
+	CurrentReplicas map[string]int64 `json:"currentReplicas,omitempty"`
+}
+
+// ExtenderFilterResult is the response of the filter verb of an extender.
+type ExtenderFilterResult struct {
+	// ClusterNames are the clusters that remain feasible.
+	ClusterNames []string `json:"clusterNames"`
+	// FailedClusters maps the rejected clusters to the reason of rejection.
+	FailedClusters map[string]string `json:"failedClusters,omitempty"`
+	// Error is set if the extender failed.
+	Error string `json:"error,omitempty"`
+}
+
+// ExtenderPriorityResult is the response of the prioritize verb of an extender.
+type ExtenderPriorityResult struct {
+	// Scores maps cluster names to a score in the range [0, MaxScore].
+	Scores map[string]int64 `json:"scores"`
+	// Error is set if the extender failed.
+	Error string `json:"error,omitempty"`
+}
+
+// httpExtender calls an out-of-process scheduler over HTTP.
+type httpExtender struct {
+	urlPrefix      string
+	filterVerb     string
+	prioritizeVerb string
+	ignorable      bool
+	client         *http.Client
+}
+
+func newHTTPExtender(config *ExtenderConfig) (*httpExtender, error) {
+	if len(config.URLPrefix) == 0 {
+		return nil, fmt.Errorf("scheduler extender urlPrefix must not be empty")
+	}
+	timeout := config.HTTPTimeout.Duration
+	if timeout == 0 {
+		timeout = defaultExtenderTimeout
+	}
+	return &httpExtender{
+		urlPrefix:      strings.TrimRight(config.URLPrefix, "/"),
+		filterVerb:     config.FilterVerb,
+		prioritizeVerb: config.PrioritizeVerb,
+		ignorable:      config.Ignorable,
+		client:         &http.Client{Timeout: timeout},
+	}, nil
+}
+
+func (e *httpExtender) Name() string {
+	return e.urlPrefix
+}
+
+func (e *httpExtender) IsIgnorable() bool {
+	return e.ignorable
+}
+
+func (e *httpExtender) Filter(ctx *Context, clusters []*federationapi.Cluster) ([]*federationapi.Cluster, map[string]string, error) {
+	if len(e.filterVerb) == 0 {
+		return clusters, nil, nil
+	}
+	var result ExtenderFilterResult
+	if err := e.send(e.filterVerb, ctx, clusters, &result); err != nil {
+		return nil, nil, err
+	}
+	if len(result.Error) > 0 {
+		return nil, nil, fmt.Errorf("scheduler extender %q: %s", e.Name(), result.Error)
+	}
+
+	byName := make(map[string]*federationapi.Cluster, len(clusters))
+	for _, cluster := range clusters {
+		byName[cluster.Name] = cluster
+	}
+	remaining := make([]*federationapi.Cluster, 0, len(result.ClusterNames))
+	for _, name := range result.ClusterNames {
+		// An extender may only narrow the list it was given.
+		if cluster, found := byName[name]; found {
+			remaining = append(remaining, cluster)
+		}
+	}
+	return remaining, result.FailedClusters, nil
+}
+
+func (e *httpExtender) Prioritize(ctx *Context, clusters []*federationapi.Cluster) (map[string]int64, error) {
+	if len(e.prioritizeVerb) == 0 {
+		return nil, nil
+	}
+	var result ExtenderPriorityResult
+	if err := e.send(e.prioritizeVerb, ctx, clusters, &result); err != nil {
+		return nil, err
+	}
+	if len(result.Error) > 0 {
+		return nil, fmt.Errorf("scheduler extender %q: %s", e.Name(), result.Error)
+	}
+	return result.Scores, nil
+}
+
+func (e *httpExtender) send(verb string, ctx *Context, clusters []*federationapi.Cluster, result interface{}) error {
+	obj, err := json.Marshal(ctx.Object)
+	if err != nil {
+		return err
+	}
+	args := ExtenderArgs{
+		Object:          obj,
+		CurrentReplicas: ctx.CurrentReplicas,
+	}
+	for _, cluster := range clusters {
+		args.Clusters = append(args.Clusters, *cluster)
+	}
+	body, err := json.Marshal(&args)
+	if err != nil {
+		return err
+	}
+
+	resp, err := e.client.Post(e.urlPrefix+"/"+verb, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("scheduler extender %q returned HTTP %d for %q", e.Name(), resp.StatusCode, verb)
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
@@ -0,0 +1,313 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package scheduler implements a pluggable framework that decides which
+// federated clusters may receive replicas of a workload and how strongly each
+// of them is preferred. Its output is fed to the planner as cluster weights.
+package scheduler
+
+import (
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	pkgruntime "k8s.io/apimachinery/pkg/runtime"
+	fedapi "k8s.io/federation/apis/federation"
+	federationapi "k8s.io/federation/apis/federation/v1beta1"
+
+	"github.com/golang/glog"
+)
+
+const (
+	// SchedulerProfileAnnotation is set on a federated workload to pick the
+	// scheduler profile used to place its replicas.
+	SchedulerProfileAnnotation = "federation.kubernetes.io/scheduler-profile"
+
+	// DefaultProfileName is the profile used when an object does not ask for one.
+	DefaultProfileName = "default"
+
+	// MaxScore is the highest score a ScorePlugin or an Extender may return.
+	MaxScore int64 = 100
+)
+
+// Context carries everything known about a single scheduling attempt.
+type Context struct {
+	// Object is the federated workload being scheduled.
+	Object pkgruntime.Object
+	// Key is the namespace/name key of Object.
+	Key string
+	// Clusters holds the clusters that are still feasible. During filtering
+	// it holds every candidate cluster, during scoring only those that passed
+	// filtering.
+	Clusters []*federationapi.Cluster
+	// CurrentReplicas is the number of running replicas of Object per cluster.
+	// +optional
+	CurrentReplicas map[string]int64
+	// EstimatedCapacity is the number of replicas of Object a cluster is
+	// estimated to be able to run, for clusters that have unschedulable pods.
+	// +optional
+	EstimatedCapacity map[string]int64
+}
+
+// Plugin is the parent type of every scheduler plugin.
+type Plugin interface {
+	Name() string
+}
+
+// FilterPlugin decides whether a cluster may receive replicas of an object.
+type FilterPlugin interface {
+	Plugin
+	// Filter returns nil if cluster is feasible for ctx.Object, or an error
+	// describing why it is not.
+	Filter(ctx *Context, cluster *federationapi.Cluster) error
+}
+
+// ScorePlugin ranks the clusters that passed filtering.
+type ScorePlugin interface {
+	Plugin
+	// Score returns a value in the range [0, MaxScore]; higher is better.
+	Score(ctx *Context, cluster *federationapi.Cluster) (int64, error)
+}
+
+// Extender is an out-of-process scheduler that filters and ranks the whole
+// list of feasible clusters in one call.
+type Extender interface {
+	Name() string
+	// Filter returns the clusters that remain feasible, and the reasons the
+	// others were rejected, keyed by cluster name.
+	Filter(ctx *Context, clusters []*federationapi.Cluster) ([]*federationapi.Cluster, map[string]string, error)
+	// Prioritize returns a score in the range [0, MaxScore] per cluster name.
+	Prioritize(ctx *Context, clusters []*federationapi.Cluster) (map[string]int64, error)
+	// IsIgnorable returns true if scheduling should proceed when the extender
+	// cannot be reached.
+	IsIgnorable() bool
+}
+
+type weightedScorePlugin struct {
+	ScorePlugin
+	weight int64
+}
+
+type weightedExtender struct {
+	Extender
+	weight int64
+}
+
+// Profile is a named combination of filter plugins, score plugins and extenders.
+type Profile struct {
+	name      string
+	filters   []FilterPlugin
+	scores    []weightedScorePlugin
+	extenders []weightedExtender
+}
+
+// Name returns the name of the profile.
+func (p *Profile) Name() string {
+	return p.name
+}
+
+// Result is the outcome of Profile.Schedule.
+type Result struct {
+	// Feasible contains the names of the clusters that passed filtering, sorted.
+	Feasible []string
+	// Infeasible contains the reason every other cluster was rejected.
+	Infeasible map[string]string
+	// Scores holds the weighted score of every feasible cluster. It is empty
+	// if the profile has no score plugins or extenders.
+	Scores map[string]int64
+}
+
+// Schedule runs the profile's filters and scorers for ctx.Object over ctx.Clusters.
+func (p *Profile) Schedule(ctx *Context) (*Result, error) {
+	result := &Result{
+		Infeasible: make(map[string]string),
+		Scores:     make(map[string]int64),
+	}
+
+	feasible := make([]*federationapi.Cluster, 0, len(ctx.Clusters))
+	for _, cluster := range ctx.Clusters {
+		rejected := false
+		for _, filter := range p.filters {
+			if err := filter.Filter(ctx, cluster); err != nil {
+				result.Infeasible[cluster.Name] = fmt.Sprintf("%s: %v", filter.Name(), err)
+				rejected = true
+				break
+			}
+		}
+		if !rejected {
+			feasible = append(feasible, cluster)
+		}
+	}
+
+	for _, extender := range p.extenders {
+		if len(feasible) == 0 {
+			break
+		}
+		remaining, failed, err := extender.Filter(ctx, feasible)
+		if err != nil {
+			if extender.IsIgnorable() {
+				glog.Warningf("Skipping scheduler extender %q for %q: %v", extender.Name(), ctx.Key, err)
+				continue
+			}
+			return nil, err
+		}
+		for name, reason := range failed {
+			result.Infeasible[name] = fmt.Sprintf("%s: %s", extender.Name(), reason)
+		}
+		// Clusters the extender dropped without a reason are infeasible as
+		// well, so that their replicas are moved away.
+		kept := make(map[string]bool, len(remaining))
+		for _, cluster := range remaining {
+			kept[cluster.Name] = true
+		}
+		for _, cluster := range feasible {
+			if _, found := result.Infeasible[cluster.Name]; !kept[cluster.Name] && !found {
+				result.Infeasible[cluster.Name] = fmt.Sprintf("%s: cluster was not returned by the extender", extender.Name())
+			}
+		}
+		feasible = remaining
+	}
+
+	scoreCtx := *ctx
+	scoreCtx.Clusters = feasible
+	for _, scorer := range p.scores {
+		for _, cluster := range feasible {
+			score, err := scorer.Score(&scoreCtx, cluster)
+			if err != nil {
+				return nil, fmt.Errorf("score plugin %q failed for cluster %q: %v", scorer.Name(), cluster.Name, err)
+			}
+			result.Scores[cluster.Name] += clampScore(score) * scorer.weight
+		}
+	}
+	for _, extender := range p.extenders {
+		if len(feasible) == 0 {
+			break
+		}
+		scores, err := extender.Prioritize(&scoreCtx, feasible)
+		if err != nil {
+			if extender.IsIgnorable() {
+				glog.Warningf("Skipping scheduler extender %q for %q: %v", extender.Name(), ctx.Key, err)
+				continue
+			}
+			return nil, err
+		}
+		for _, cluster := range feasible {
+			result.Scores[cluster.Name] += clampScore(scores[cluster.Name]) * extender.weight
+		}
+	}
+
+	for _, cluster := range feasible {
+		result.Feasible = append(result.Feasible, cluster.Name)
+	}
+	sort.Strings(result.Feasible)
+	return result, nil
+}
+
+// Reject marks a feasible cluster as infeasible for the given reason.
+func (r *Result) Reject(clusterName, reason string) {
+	for i, name := range r.Feasible {
+		if name == clusterName {
+			r.Feasible = append(r.Feasible[:i:i], r.Feasible[i+1:]...)
+			r.Infeasible[clusterName] = reason
+			delete(r.Scores, clusterName)
+			return
+		}
+	}
+}
+
+// Preferences returns the replica allocation preferences the planner should
+// use for the feasible clusters. Explicit preferences set by the user win;
+// otherwise the cluster scores become planner weights. A feasible cluster
+// scored 0 still gets the lowest weight, since only filtering excludes
+// clusters. If nothing was scored, replicas are spread evenly, which is the
+// historical default.
+func (r *Result) Preferences(explicit *fedapi.ReplicaAllocationPreferences) *fedapi.ReplicaAllocationPreferences {
+	if explicit != nil {
+		return explicit
+	}
+
+	scored := false
+	for _, score := range r.Scores {
+		if score > 0 {
+			scored = true
+			break
+		}
+	}
+	if !scored {
+		return &fedapi.ReplicaAllocationPreferences{
+			Clusters: map[string]fedapi.ClusterPreferences{
+				"*": {Weight: 1},
+			},
+		}
+	}
+
+	pref := &fedapi.ReplicaAllocationPreferences{
+		Clusters: make(map[string]fedapi.ClusterPreferences, len(r.Feasible)),
+	}
+	for _, name := range r.Feasible {
+		weight := r.Scores[name]
+		if weight < 1 {
+			weight = 1
+		}
+		pref.Clusters[name] = fedapi.ClusterPreferences{Weight: weight}
+	}
+	return pref
+}
+
+// Framework holds the configured scheduler profiles.
+type Framework struct {
+	profiles map[string]*Profile
+}
+
+// NewDefaultFramework returns a framework that only knows the default
+// profile, which neither filters nor scores clusters.
+func NewDefaultFramework() *Framework {
+	return &Framework{
+		profiles: map[string]*Profile{
+			DefaultProfileName: {name: DefaultProfileName},
+		},
+	}
+}
+
+// ProfileFor returns the profile selected by obj's scheduler profile annotation,
+// falling back to the default profile if the annotation is missing or names
+// an unknown profile.
+func (f *Framework) ProfileFor(obj pkgruntime.Object) *Profile {
+	if f == nil {
+		return &Profile{name: DefaultProfileName}
+	}
+	name := DefaultProfileName
+	if accessor, err := meta.Accessor(obj); err == nil {
+		if value, found := accessor.GetAnnotations()[SchedulerProfileAnnotation]; found && len(value) > 0 {
+			name = value
+		}
+	}
+	if profile, found := f.profiles[name]; found {
+		return profile
+	}
+	glog.Warningf("Unknown scheduler profile %q requested, using %q", name, DefaultProfileName)
+	return f.profiles[DefaultProfileName]
+}
+
+func clampScore(score int64) int64 {
+	if score < 0 {
+		return 0
+	}
+	if score > MaxScore {
+		return MaxScore
+	}
+	return score
+}
@@ -0,0 +1,237 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	extensionsv1 "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fedapi "k8s.io/federation/apis/federation"
+	federationapi "k8s.io/federation/apis/federation/v1beta1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCluster(name, region string, labels, annotations map[string]string) *federationapi.Cluster {
+	return &federationapi.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Status: federationapi.ClusterStatus{Region: region},
+	}
+}
+
+func newReplicaSet(annotations map[string]string) *extensionsv1.ReplicaSet {
+	return &extensionsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "rs",
+			Namespace:   metav1.NamespaceDefault,
+			Annotations: annotations,
+		},
+	}
+}
+
+func mustFramework(t *testing.T, config string) *Framework {
+	cfg, err := LoadConfig(strings.NewReader(config))
+	require.NoError(t, err)
+	framework, err := NewFramework(cfg)
+	require.NoError(t, err)
+	return framework
+}
+
+func TestDefaultProfileKeepsEvenSpread(t *testing.T) {
+	framework := NewDefaultFramework()
+	clusters := []*federationapi.Cluster{newCluster("b", "", nil, nil), newCluster("a", "", nil, nil)}
+	obj := newReplicaSet(nil)
+
+	profile := framework.ProfileFor(obj)
+	assert.Equal(t, DefaultProfileName, profile.Name())
+	result, err := profile.Schedule(&Context{Object: obj, Clusters: clusters})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, result.Feasible)
+	assert.Equal(t, &fedapi.ReplicaAllocationPreferences{
+		Clusters: map[string]fedapi.ClusterPreferences{"*": {Weight: 1}},
+	}, result.Preferences(nil))
+
+	explicit := &fedapi.ReplicaAllocationPreferences{Rebalance: true}
+	assert.Equal(t, explicit, result.Preferences(explicit))
+}
+
+func TestFilters(t *testing.T) {
+	framework := mustFramework(t, `
+profiles:
+- name: strict
+  filters: ["ClusterLabels", "ClusterTaints"]
+`)
+	clusters := []*federationapi.Cluster{
+		newCluster("prod", "", map[string]string{"env": "prod"}, nil),
+		newCluster("dev", "", map[string]string{"env": "dev"}, nil),
+		newCluster("tainted", "", map[string]string{"env": "prod"}, map[string]string{
+			ClusterTaintsAnnotation: `[{"key":"dedicated","value":"gpu","effect":"NoSchedule"}]`,
+		}),
+	}
+
+	obj := newReplicaSet(map[string]string{
+		SchedulerProfileAnnotation:                        "strict",
+		federationapi.FederationClusterSelectorAnnotation: `[{"key":"env","operator":"=","values":["prod"]}]`,
+	})
+	result, err := framework.ProfileFor(obj).Schedule(&Context{Object: obj, Clusters: clusters})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"prod"}, result.Feasible)
+	assert.Contains(t, result.Infeasible, "dev")
+	assert.Contains(t, result.Infeasible, "tainted")
+
+	obj.Annotations[ClusterTolerationsAnnotation] = `[{"key":"dedicated","operator":"Exists"}]`
+	result, err = framework.ProfileFor(obj).Schedule(&Context{Object: obj, Clusters: clusters})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"prod", "tainted"}, result.Feasible)
+}
+
+func TestScores(t *testing.T) {
+	framework := mustFramework(t, `
+profiles:
+- name: spread
+  scores:
+  - name: RegionSpread
+- name: cheap
+  scores:
+  - name: Cost
+    weight: 2
+`)
+	clusters := []*federationapi.Cluster{
+		newCluster("eu1", "eu", nil, map[string]string{ClusterCostAnnotation: "1"}),
+		newCluster("eu2", "eu", nil, map[string]string{ClusterCostAnnotation: "4"}),
+		newCluster("us1", "us", nil, nil),
+	}
+
+	obj := newReplicaSet(map[string]string{SchedulerProfileAnnotation: "spread"})
+	result, err := framework.ProfileFor(obj).Schedule(&Context{Object: obj, Clusters: clusters})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"eu1": 50, "eu2": 50, "us1": 100}, result.Scores)
+	assert.Equal(t, int64(100), result.Preferences(nil).Clusters["us1"].Weight)
+
+	obj = newReplicaSet(map[string]string{SchedulerProfileAnnotation: "cheap"})
+	result, err = framework.ProfileFor(obj).Schedule(&Context{Object: obj, Clusters: clusters})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"eu1": 200, "eu2": 50, "us1": 200}, result.Scores)
+}
+
+func TestReject(t *testing.T) {
+	result := &Result{
+		Feasible:   []string{"a", "b", "c"},
+		Infeasible: map[string]string{},
+		Scores:     map[string]int64{"a": 10, "b": 20, "c": 30},
+	}
+	result.Reject("b", "cluster is drained")
+	result.Reject("unknown", "cluster is drained")
+	assert.Equal(t, []string{"a", "c"}, result.Feasible)
+	assert.Equal(t, map[string]string{"b": "cluster is drained"}, result.Infeasible)
+	assert.Equal(t, map[string]int64{"a": 10, "c": 30}, result.Scores)
+}
+
+func TestCapacityScore(t *testing.T) {
+	ctx := &Context{
+		CurrentReplicas:   map[string]int64{"a": 2, "b": 4},
+		EstimatedCapacity: map[string]int64{"a": 4, "b": 4},
+	}
+	for name, expected := range map[string]int64{"a": 50, "b": 0, "c": MaxScore} {
+		score, err := capacity{}.Score(ctx, newCluster(name, "", nil, nil))
+		require.NoError(t, err)
+		assert.Equal(t, expected, score, name)
+	}
+}
+
+func TestExtender(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var args ExtenderArgs
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&args))
+		switch r.URL.Path {
+		case "/scheduler/filter":
+			json.NewEncoder(w).Encode(&ExtenderFilterResult{
+				ClusterNames:   []string{"a", "unknown"},
+				FailedClusters: map[string]string{"b": "no quota"},
+			})
+		case "/scheduler/prioritize":
+			json.NewEncoder(w).Encode(&ExtenderPriorityResult{Scores: map[string]int64{"a": 7}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	framework := mustFramework(t, `
+profiles:
+- name: external
+  extenders:
+  - urlPrefix: `+server.URL+`/scheduler
+    filterVerb: filter
+    prioritizeVerb: prioritize
+    weight: 3
+- name: broken
+  extenders:
+  - urlPrefix: `+server.URL+`/missing
+    filterVerb: filter
+    ignorable: true
+`)
+	clusters := []*federationapi.Cluster{newCluster("a", "", nil, nil), newCluster("b", "", nil, nil), newCluster("c", "", nil, nil)}
+
+	obj := newReplicaSet(map[string]string{SchedulerProfileAnnotation: "external"})
+	result, err := framework.ProfileFor(obj).Schedule(&Context{Object: obj, Clusters: clusters})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a"}, result.Feasible)
+	assert.Equal(t, map[string]int64{"a": 21}, result.Scores)
+	assert.Contains(t, result.Infeasible["b"], "no quota")
+	assert.Contains(t, result.Infeasible["c"], "not returned by the extender")
+
+	obj = newReplicaSet(map[string]string{SchedulerProfileAnnotation: "broken"})
+	result, err = framework.ProfileFor(obj).Schedule(&Context{Object: obj, Clusters: clusters})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, result.Feasible)
+}
+
+func TestPreferencesKeepZeroScoredClusters(t *testing.T) {
+	result := &Result{
+		Feasible:   []string{"a", "b"},
+		Infeasible: map[string]string{},
+		Scores:     map[string]int64{"a": 30, "b": 0},
+	}
+	pref := result.Preferences(nil)
+	assert.Equal(t, int64(30), pref.Clusters["a"].Weight)
+	assert.Equal(t, int64(1), pref.Clusters["b"].Weight)
+}
+
+func TestInvalidConfig(t *testing.T) {
+	for _, config := range []string{
+		`profiles: [{filters: ["ClusterLabels"]}]`,
+		`profiles: [{name: p, filters: ["Nope"]}]`,
+		`profiles: [{name: p, scores: [{name: Cost, weight: -1}]}]`,
+		`profiles: [{name: p, extenders: [{filterVerb: filter}]}]`,
+	} {
+		cfg, err := LoadConfig(strings.NewReader(config))
+		require.NoError(t, err)
+		_, err = NewFramework(cfg)
+		assert.Error(t, err, config)
+	}
+}
@@ -0,0 +1,246 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	pkgruntime "k8s.io/apimachinery/pkg/runtime"
+	federationapi "k8s.io/federation/apis/federation/v1beta1"
+	"k8s.io/federation/pkg/federation-controller/util/clusterselector"
+)
+
+const (
+	// ClusterTaintsAnnotation is set on a Cluster to a json-serialized list of
+	// taints. Clusters with a NoSchedule or NoExecute taint only receive
+	// replicas of workloads that tolerate it.
+	ClusterTaintsAnnotation = "federation.kubernetes.io/cluster-taints"
+
+	// ClusterTolerationsAnnotation is set on a federated workload to a
+	// json-serialized list of tolerations of cluster taints.
+	ClusterTolerationsAnnotation = "federation.kubernetes.io/cluster-tolerations"
+
+	// ClusterCostAnnotation is set on a Cluster to a positive number expressing
+	// the relative cost of running a replica in it.
+	ClusterCostAnnotation = "federation.kubernetes.io/cluster-cost"
+)
+
+// Names of the built-in plugins.
+const (
+	ClusterLabelsPluginName = "ClusterLabels"
+	ClusterTaintsPluginName = "ClusterTaints"
+	RegionSpreadPluginName  = "RegionSpread"
+	CapacityPluginName      = "Capacity"
+	CostPluginName          = "Cost"
+)
+
+// filterPlugins and scorePlugins map the names usable in a profile
+// configuration to the built-in plugins.
+var (
+	filterPlugins = map[string]FilterPlugin{
+		ClusterLabelsPluginName: clusterLabels{},
+		ClusterTaintsPluginName: clusterTaints{},
+	}
+	scorePlugins = map[string]ScorePlugin{
+		ClusterTaintsPluginName: clusterTaints{},
+		RegionSpreadPluginName:  regionSpread{},
+		CapacityPluginName:      capacity{},
+		CostPluginName:          cost{},
+	}
+)
+
+// clusterLabels rejects clusters that do not match the cluster selector
+// annotation of the object.
+type clusterLabels struct{}
+
+func (clusterLabels) Name() string {
+	return ClusterLabelsPluginName
+}
+
+func (clusterLabels) Filter(ctx *Context, cluster *federationapi.Cluster) error {
+	accessor, err := meta.Accessor(ctx.Object)
+	if err != nil {
+		return err
+	}
+	send, err := clusterselector.SendToCluster(cluster.Labels, accessor.GetAnnotations())
+	if err != nil {
+		return err
+	}
+	if !send {
+		return fmt.Errorf("cluster labels do not match the cluster selector")
+	}
+	return nil
+}
+
+// clusterTaints rejects clusters with NoSchedule or NoExecute taints the
+// object does not tolerate, and scores clusters down for each untolerated
+// PreferNoSchedule taint.
+type clusterTaints struct{}
+
+func (clusterTaints) Name() string {
+	return ClusterTaintsPluginName
+}
+
+func (clusterTaints) Filter(ctx *Context, cluster *federationapi.Cluster) error {
+	untolerated, err := untoleratedTaints(ctx.Object, cluster)
+	if err != nil {
+		return err
+	}
+	for _, taint := range untolerated {
+		if taint.Effect == apiv1.TaintEffectNoSchedule || taint.Effect == apiv1.TaintEffectNoExecute {
+			return fmt.Errorf("cluster has untolerated taint %s", taint.ToString())
+		}
+	}
+	return nil
+}
+
+func (clusterTaints) Score(ctx *Context, cluster *federationapi.Cluster) (int64, error) {
+	untolerated, err := untoleratedTaints(ctx.Object, cluster)
+	if err != nil {
+		return 0, err
+	}
+	score := MaxScore
+	for _, taint := range untolerated {
+		if taint.Effect == apiv1.TaintEffectPreferNoSchedule {
+			score = score / 2
+		}
+	}
+	return score, nil
+}
+
+func untoleratedTaints(obj pkgruntime.Object, cluster *federationapi.Cluster) ([]apiv1.Taint, error) {
+	value, found := cluster.Annotations[ClusterTaintsAnnotation]
+	if !found {
+		return nil, nil
+	}
+	var taints []apiv1.Taint
+	if err := json.Unmarshal([]byte(value), &taints); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %v", ClusterTaintsAnnotation, err)
+	}
+
+	var tolerations []apiv1.Toleration
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return nil, err
+	}
+	if value, found := accessor.GetAnnotations()[ClusterTolerationsAnnotation]; found {
+		if err := json.Unmarshal([]byte(value), &tolerations); err != nil {
+			return nil, fmt.Errorf("invalid %s annotation: %v", ClusterTolerationsAnnotation, err)
+		}
+	}
+
+	var untolerated []apiv1.Taint
+	for i := range taints {
+		tolerated := false
+		for j := range tolerations {
+			if tolerations[j].ToleratesTaint(&taints[i]) {
+				tolerated = true
+				break
+			}
+		}
+		if !tolerated {
+			untolerated = append(untolerated, taints[i])
+		}
+	}
+	return untolerated, nil
+}
+
+// regionSpread scores clusters so that every region receives the same total
+// weight, regardless of how many feasible clusters it has. Clusters that do
+// not report a region form a region of their own.
+type regionSpread struct{}
+
+func (regionSpread) Name() string {
+	return RegionSpreadPluginName
+}
+
+func (regionSpread) Score(ctx *Context, cluster *federationapi.Cluster) (int64, error) {
+	inRegion := int64(0)
+	for _, other := range ctx.Clusters {
+		if other.Status.Region == cluster.Status.Region {
+			inRegion++
+		}
+	}
+	if inRegion == 0 {
+		return MaxScore, nil
+	}
+	return MaxScore / inRegion, nil
+}
+
+// capacity favours clusters that have room for more replicas. Clusters without
+// unschedulable replicas score MaxScore; the others score in proportion to the
+// part of their estimated capacity that is not used yet.
+type capacity struct{}
+
+func (capacity) Name() string {
+	return CapacityPluginName
+}
+
+func (capacity) Score(ctx *Context, cluster *federationapi.Cluster) (int64, error) {
+	estimated, found := ctx.EstimatedCapacity[cluster.Name]
+	if !found {
+		return MaxScore, nil
+	}
+	free := estimated - ctx.CurrentReplicas[cluster.Name]
+	if estimated <= 0 || free <= 0 {
+		return 0, nil
+	}
+	return MaxScore * free / estimated, nil
+}
+
+// cost favours cheaper clusters. The cheapest feasible cluster, and every
+// cluster without a cost annotation, scores MaxScore; the others score in
+// inverse proportion to their cost.
+type cost struct{}
+
+func (cost) Name() string {
+	return CostPluginName
+}
+
+func (cost) Score(ctx *Context, cluster *federationapi.Cluster) (int64, error) {
+	own, found, err := clusterCost(cluster)
+	if err != nil || !found {
+		return MaxScore, err
+	}
+	cheapest := own
+	for _, other := range ctx.Clusters {
+		value, found, err := clusterCost(other)
+		if err != nil {
+			return 0, err
+		}
+		if found && value < cheapest {
+			cheapest = value
+		}
+	}
+	return int64(float64(MaxScore) * cheapest / own), nil
+}
+
+func clusterCost(cluster *federationapi.Cluster) (float64, bool, error) {
+	value, found := cluster.Annotations[ClusterCostAnnotation]
+	if !found {
+		return 0, false, nil
+	}
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil || parsed <= 0 {
+		return 0, false, fmt.Errorf("invalid %s annotation %q on cluster %q", ClusterCostAnnotation, value, cluster.Name)
+	}
+	return parsed, true, nil
+}
@@ -443,3 +443,9 @@ func NewInt32(val int32) *int32 {
 	*p = val
 	return p
 }
+
+func NewInt64(val int64) *int64 {
+	p := new(int64)
+	*p = val
+	return p
+}
@@ -16,8 +16,6 @@ limitations under the License.
 
 // TODO(madhusdancs):
 // 1. Make printSuccess prepend protocol/scheme to the IPs/hostnames.
-// 2. Separate etcd container from API server pod as a first step towards enabling HA.
-// 3. Make API server and controller manager replicas customizable via the HA work.
 package init
 
 import (
@@ -34,6 +32,7 @@ import (
 	"k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/uuid"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -45,12 +44,15 @@ import (
 	"k8s.io/federation/pkg/dnsprovider/providers/coredns"
 	"k8s.io/federation/pkg/kubefed/util"
 	kubeconfigutil "k8s.io/kubernetes/cmd/kubeadm/app/util/kubeconfig"
+	"k8s.io/kubernetes/pkg/apis/apps"
 	api "k8s.io/kubernetes/pkg/apis/core"
 	"k8s.io/kubernetes/pkg/apis/extensions"
+	"k8s.io/kubernetes/pkg/apis/policy"
 	"k8s.io/kubernetes/pkg/apis/rbac"
 	client "k8s.io/kubernetes/pkg/client/clientset_generated/internalclientset"
 	"k8s.io/kubernetes/pkg/kubectl/cmd/templates"
 	cmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+	kubeletapis "k8s.io/kubernetes/pkg/kubelet/apis"
 
 	"github.com/golang/glog"
 	"github.com/spf13/cobra"
@@ -65,6 +67,7 @@ const (
 	HostClusterLocalDNSZoneName = "cluster.local."
 	APIServerNameSuffix         = "apiserver"
 	CMNameSuffix                = "controller-manager"
+	EtcdNameSuffix              = "etcd"
 	CredentialSuffix            = "credentials"
 	KubeconfigNameSuffix        = "kubeconfig"
 
@@ -93,6 +96,9 @@ const (
 	// to bind to port < 1000.  The apiserver's service will still
 	// expose on port 443.
 	apiServerSecurePort = 8443
+
+	etcdClientPort = 2379
+	etcdPeerPort   = 2380
 )
 
 var (
@@ -126,6 +132,11 @@ var (
 		"app":    "federated-cluster",
 		"module": "federation-controller-manager",
 	}
+
+	etcdPodLabels = map[string]string{
+		"app":    "federated-cluster",
+		"module": "federation-etcd",
+	}
 )
 
 type initFederation struct {
@@ -141,6 +152,10 @@ type initFederationOptions struct {
 	dnsProvider                      string
 	dnsProviderConfig                string
 	etcdServers                      string
+	etcdCAFile                       string
+	etcdCertFile                     string
+	etcdKeyFile                      string
+	etcdClusterSize                  int32
 	etcdImage                        string
 	etcdPVCapacity                   string
 	etcdPVStorageClass               string
@@ -159,6 +174,9 @@ type initFederationOptions struct {
 	apiServerEnableTokenAuth         bool
 	nodeSelector                     map[string]string
 	nodeSelectorString               string
+	apiServerReplicas                int32
+	controllerManagerReplicas        int32
+	output                           string
 }
 
 func (o *initFederationOptions) Bind(flags *pflag.FlagSet, defaultServerImage, defaultEtcdImage string) {
@@ -169,6 +187,10 @@ func (o *initFederationOptions) Bind(flags *pflag.FlagSet, defaultServerImage, d
 	flags.StringVar(&o.dnsProvider, "dns-provider", "", "Dns provider to be used for this deployment.")
 	flags.StringVar(&o.dnsProviderConfig, "dns-provider-config", "", "Config file path on local file system for configuring DNS provider.")
 	flags.StringVar(&o.etcdServers, "etcd-servers", "", "External pre-deployed etcd server to be used to store federation state.")
+	flags.StringVar(&o.etcdCAFile, "etcd-cafile", "", "SSL Certificate Authority file used to secure the communication with the servers given by --etcd-servers.")
+	flags.StringVar(&o.etcdCertFile, "etcd-certfile", "", "SSL certification file used by the federation API server to authenticate to the servers given by --etcd-servers.")
+	flags.StringVar(&o.etcdKeyFile, "etcd-keyfile", "", "SSL key file used by the federation API server to authenticate to the servers given by --etcd-servers.")
+	flags.Int32Var(&o.etcdClusterSize, "etcd-cluster-size", 0, "Number of members of an etcd cluster to deploy in a StatefulSet for the federation API server, e.g. 3. If 0, etcd runs in the pod of the federation API server.")
 	flags.StringVar(&o.etcdImage, "etcd-image", defaultEtcdImage, "Image to use for etcd server.")
 	flags.StringVar(&o.etcdPVCapacity, "etcd-pv-capacity", "10Gi", "Size of persistent volume claim to be used for etcd.")
 	flags.StringVar(&o.etcdPVStorageClass, "etcd-pv-storage-class", "", "The storage class of the persistent volume claim used for etcd.   Must be provided if a default storage class is not enabled for the host cluster.")
@@ -182,6 +204,9 @@ func (o *initFederationOptions) Bind(flags *pflag.FlagSet, defaultServerImage, d
 	flags.BoolVar(&o.apiServerEnableHTTPBasicAuth, "apiserver-enable-basic-auth", false, "Enables HTTP Basic authentication for the federation-apiserver. Defaults to false.")
 	flags.BoolVar(&o.apiServerEnableTokenAuth, "apiserver-enable-token-auth", false, "Enables token authentication for the federation-apiserver. Defaults to false.")
 	flags.StringVar(&o.nodeSelectorString, "node-selector", "", "comma separated list of nodeSelector arguments: Example \"arg1=value1,arg2=value2...\"")
+	flags.Int32Var(&o.apiServerReplicas, "apiserver-replicas", 1, "Number of federation API server replicas. More than one replica requires --etcd-servers or --etcd-cluster-size.")
+	flags.Int32Var(&o.controllerManagerReplicas, "controllermanager-replicas", 1, "Number of federation controller manager replicas. Leader election is enabled when there is more than one replica.")
+	flags.StringVarP(&o.output, "output", "o", "", "Output format. One of: yaml. If set, the manifests of the federation control plane, including the generated credentials, are printed instead of being created in the host cluster. The kubeconfig is still updated unless --dry-run is set. Requires '"+apiserverServiceTypeFlag+"=NodePort' and --"+apiserverPortFlag+".")
 }
 
 // NewCmdInit defines the `init` command that bootstraps a federation
@@ -219,6 +244,10 @@ type credentials struct {
 	password        string
 	token           string
 	certEntKeyPairs *entityKeyPairs
+	// Credentials of the federation API server for the external etcd servers.
+	etcdCA   []byte
+	etcdCert []byte
+	etcdKey  []byte
 }
 
 // Complete ensures that options are valid and marshals them if necessary.
@@ -277,6 +306,66 @@ func (i *initFederation) Complete(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	err = i.options.validateHA()
+	if err != nil {
+		return err
+	}
+	return i.options.validateOutput()
+}
+
+// validateOutput validates the options for rendering the manifests of the
+// federation control plane.
+func (o *initFederationOptions) validateOutput() error {
+	if o.output == "" {
+		return nil
+	}
+	if o.output != util.OutputFormatYAML {
+		return fmt.Errorf("invalid --output: %s, the only supported output format is %s", o.output, util.OutputFormatYAML)
+	}
+	// The address of the federation API server is part of its certificate
+	// and of the kubeconfig, so it has to be known before anything is created.
+	if o.apiServerServiceType != v1.ServiceTypeNodePort || o.apiServerNodePortPort == 0 {
+		return fmt.Errorf("--output requires '%s=NodePort' and %s, the address of the federation API server must be known in advance", apiserverServiceTypeFlag, apiserverPortFlag)
+	}
+	return nil
+}
+
+// validateHA validates the options for the replicas of the federation control
+// plane components and for their etcd.
+func (o *initFederationOptions) validateHA() error {
+	if o.apiServerReplicas < 1 {
+		return fmt.Errorf("--apiserver-replicas must be at least 1")
+	}
+	if o.controllerManagerReplicas < 1 {
+		return fmt.Errorf("--controllermanager-replicas must be at least 1")
+	}
+	if o.etcdClusterSize < 0 || (o.etcdClusterSize > 0 && o.etcdClusterSize%2 == 0) {
+		return fmt.Errorf("--etcd-cluster-size must be an odd number")
+	}
+	if o.etcdClusterSize > 0 && o.etcdServers != "" {
+		return fmt.Errorf("--etcd-cluster-size can not be used with --etcd-servers")
+	}
+	if o.apiServerReplicas > 1 && o.etcdServers == "" && o.etcdClusterSize == 0 {
+		return fmt.Errorf("--apiserver-replicas greater than 1 requires --etcd-servers or --etcd-cluster-size, the replicas can not share the etcd running in the pod of an API server")
+	}
+
+	if o.etcdCAFile == "" && o.etcdCertFile == "" && o.etcdKeyFile == "" {
+		return nil
+	}
+	if o.etcdServers == "" {
+		return fmt.Errorf("--etcd-cafile, --etcd-certfile and --etcd-keyfile are only valid with --etcd-servers")
+	}
+	if (o.etcdCertFile == "") != (o.etcdKeyFile == "") {
+		return fmt.Errorf("--etcd-certfile and --etcd-keyfile must be given together")
+	}
+	for _, file := range []string{o.etcdCAFile, o.etcdCertFile, o.etcdKeyFile} {
+		if file == "" {
+			continue
+		}
+		if _, err := os.Stat(file); err != nil {
+			return fmt.Errorf("error reading etcd credentials file %s, err: %v", file, err)
+		}
+	}
 	return nil
 }
 
@@ -284,6 +373,16 @@ func (i *initFederation) Complete(cmd *cobra.Command, args []string) error {
 // See the design doc in https://github.com/kubernetes/kubernetes/pull/34484
 // for details.
 func (i *initFederation) Run(cmdOut io.Writer, config util.AdminConfig) error {
+	// When the manifests are rendered, nothing is created in the host
+	// cluster and the manifests are the only output.
+	render := i.options.output != ""
+	dryRun := i.options.dryRun || render
+	manifestOut := cmdOut
+	if render {
+		cmdOut = ioutil.Discard
+	}
+	objects := []runtime.Object{}
+
 	var hostFactory cmdutil.Factory
 	useRBAC := true
 	if i.commonOptions.CredentialsKubeconfig != "" {
@@ -326,50 +425,78 @@ func (i *initFederation) Run(cmdOut io.Writer, config util.AdminConfig) error {
 
 	fmt.Fprintf(cmdOut, "Creating a namespace %s for federation system components...", i.commonOptions.FederationSystemNamespace)
 	glog.V(4).Infof("Creating a namespace %s for federation system components", i.commonOptions.FederationSystemNamespace)
-	_, err = createNamespace(hostClientset, i.commonOptions.Name, i.commonOptions.FederationSystemNamespace, i.options.dryRun)
+	ns, err := createNamespace(hostClientset, i.commonOptions.Name, i.commonOptions.FederationSystemNamespace, dryRun)
 	if err != nil {
 		return err
 	}
+	objects = append(objects, ns)
 
 	fmt.Fprintln(cmdOut, " done")
 
 	fmt.Fprint(cmdOut, "Creating federation control plane service...")
 	glog.V(4).Info("Creating federation control plane service")
-	svc, ips, hostnames, err := createService(cmdOut, hostClientset, i.commonOptions.FederationSystemNamespace, serverName, i.commonOptions.Name, i.options.apiServerAdvertiseAddress, i.options.apiServerNodePortPortPtr, i.options.apiServerServiceType, i.options.dryRun)
+	svc, ips, hostnames, err := createService(cmdOut, hostClientset, i.commonOptions.FederationSystemNamespace, serverName, i.commonOptions.Name, i.options.apiServerAdvertiseAddress, i.options.apiServerNodePortPortPtr, i.options.apiServerServiceType, dryRun)
 	if err != nil {
 		return err
 	}
+	objects = append(objects, svc)
+	if render {
+		ips, err = nodePortAddresses(hostClientset, i.options.apiServerAdvertiseAddress)
+		if err != nil {
+			return err
+		}
+	}
 	fmt.Fprintln(cmdOut, " done")
 	glog.V(4).Infof("Created service named %s with IP addresses %v, hostnames %v", svc.Name, ips, hostnames)
 
 	fmt.Fprint(cmdOut, "Creating federation control plane objects (credentials, persistent volume claim)...")
 	glog.V(4).Info("Generating TLS certificates and credentials for communicating with the federation API server")
-	credentials, err := generateCredentials(i.commonOptions.FederationSystemNamespace, i.commonOptions.Name, svc.Name, HostClusterLocalDNSZoneName, ips, hostnames, i.options.apiServerEnableHTTPBasicAuth, i.options.apiServerEnableTokenAuth, i.options.dryRun)
+	credentials, err := generateCredentials(i.commonOptions.FederationSystemNamespace, i.commonOptions.Name, svc.Name, HostClusterLocalDNSZoneName, ips, hostnames, i.options.apiServerEnableHTTPBasicAuth, i.options.apiServerEnableTokenAuth, dryRun)
+	if err != nil {
+		return err
+	}
+
+	credentials.etcdCA, credentials.etcdCert, credentials.etcdKey, err = readEtcdCredentials(i.options.etcdCAFile, i.options.etcdCertFile, i.options.etcdKeyFile)
 	if err != nil {
 		return err
 	}
 
 	// Create the secret containing the credentials.
-	_, err = createAPIServerCredentialsSecret(hostClientset, i.commonOptions.FederationSystemNamespace, serverCredName, i.commonOptions.Name, credentials, i.options.dryRun)
+	credentialsSecret, err := createAPIServerCredentialsSecret(hostClientset, i.commonOptions.FederationSystemNamespace, serverCredName, i.commonOptions.Name, credentials, dryRun)
 	if err != nil {
 		return err
 	}
+	objects = append(objects, credentialsSecret)
 	glog.V(4).Info("Certificates and credentials generated")
 
 	glog.V(4).Info("Creating an entry in the kubeconfig file with the certificate and credential data")
-	_, err = createControllerManagerKubeconfigSecret(hostClientset, i.commonOptions.FederationSystemNamespace, i.commonOptions.Name, svc.Name, cmKubeconfigName, credentials.certEntKeyPairs, i.options.dryRun)
+	cmKubeconfigSecret, err := createControllerManagerKubeconfigSecret(hostClientset, i.commonOptions.FederationSystemNamespace, i.commonOptions.Name, svc.Name, cmKubeconfigName, credentials.certEntKeyPairs, dryRun)
 	if err != nil {
 		return err
 	}
+	objects = append(objects, cmKubeconfigSecret)
 	glog.V(4).Info("Credentials secret successfully created")
 
+	etcdServers := i.options.etcdServers
+	if i.options.etcdClusterSize > 0 {
+		glog.V(4).Infof("Creating an etcd cluster of %d members to store the federation API server's state", i.options.etcdClusterSize)
+		var etcdObjects []runtime.Object
+		etcdObjects, etcdServers, err = createEtcdCluster(hostClientset, i.commonOptions.FederationSystemNamespace, EtcdNameSuffix, i.commonOptions.Name, i.options.etcdImage, i.options.etcdClusterSize, i.options.etcdPersistentStorage, i.options.etcdPVCapacity, i.options.etcdPVStorageClass, dryRun, i.options.nodeSelector, i.options.imagePullSecrets)
+		if err != nil {
+			return err
+		}
+		objects = append(objects, etcdObjects...)
+		glog.V(4).Info("Etcd cluster created")
+	}
+
 	var pvc *api.PersistentVolumeClaim
-	if i.options.etcdServers == "" && i.options.etcdPersistentStorage {
+	if etcdServers == "" && i.options.etcdPersistentStorage {
 		glog.V(4).Info("Creating a persistent volume and a claim to store the federation API server's state, including etcd data")
-		pvc, err = createPVC(hostClientset, i.commonOptions.FederationSystemNamespace, svc.Name, i.commonOptions.Name, i.options.etcdPVCapacity, i.options.etcdPVStorageClass, i.options.dryRun)
+		pvc, err = createPVC(hostClientset, i.commonOptions.FederationSystemNamespace, svc.Name, i.commonOptions.Name, i.options.etcdPVCapacity, i.options.etcdPVStorageClass, dryRun)
 		if err != nil {
 			return err
 		}
+		objects = append(objects, pvc)
 		glog.V(4).Info("Persistent volume and claim created")
 		fmt.Fprintln(cmdOut, " done")
 	}
@@ -382,47 +509,70 @@ func (i *initFederation) Run(cmdOut io.Writer, config util.AdminConfig) error {
 		advertiseAddress = ips[0]
 	}
 
-	fmt.Fprint(cmdOut, "Creating federation component deployments...")
-	glog.V(4).Info("Creating federation control plane components")
-	_, err = createAPIServer(hostClientset, i.commonOptions.FederationSystemNamespace, serverName, i.commonOptions.Name, i.options.serverImage, i.options.etcdImage, advertiseAddress, serverCredName, i.options.etcdServers, i.options.apiServerEnableHTTPBasicAuth, i.options.apiServerEnableTokenAuth, i.options.apiServerOverrides, pvc, i.options.dryRun, i.options.nodeSelector, i.options.imagePullPolicy, i.options.imagePullSecrets)
-	if err != nil {
-		return err
-	}
-	glog.V(4).Info("Successfully created federation API server")
-
 	sa := &api.ServiceAccount{}
 	sa.Name = ""
 	// Create a service account and related RBAC roles if the host cluster has RBAC support.
+	// Both the API server, that proxies requests to member clusters, and the controller
+	// manager read the credentials of the clusters from secrets in the host cluster.
 	// TODO: We must evaluate creating a separate service account even when RBAC support is missing
 	if useRBAC {
 		glog.V(4).Info("Creating service account for federation controller manager in the host cluster")
-		sa, err = createControllerManagerSA(rbacVersionedClientset, i.commonOptions.FederationSystemNamespace, i.commonOptions.Name, i.options.dryRun)
+		sa, err = createControllerManagerSA(rbacVersionedClientset, i.commonOptions.FederationSystemNamespace, i.commonOptions.Name, dryRun)
 		if err != nil {
 			return err
 		}
+		objects = append(objects, sa)
 		glog.V(4).Info("Successfully created federation controller manager service account")
 
 		glog.V(4).Info("Creating RBAC role and role bindings for the federation controller manager's service account")
-		_, _, err = createRoleBindings(rbacVersionedClientset, i.commonOptions.FederationSystemNamespace, sa.Name, i.commonOptions.Name, i.options.dryRun)
+		role, roleBinding, err := createRoleBindings(rbacVersionedClientset, i.commonOptions.FederationSystemNamespace, sa.Name, i.commonOptions.Name, dryRun)
 		if err != nil {
 			return err
 		}
+		objects = append(objects, role, roleBinding)
 		glog.V(4).Info("Successfully created RBAC role and role bindings")
 	}
 
+	fmt.Fprint(cmdOut, "Creating federation component deployments...")
+	glog.V(4).Info("Creating federation control plane components")
+	server, err := createAPIServer(hostClientset, i.commonOptions.FederationSystemNamespace, serverName, i.commonOptions.Name, i.options.serverImage, i.options.etcdImage, advertiseAddress, serverCredName, etcdServers, sa.Name, i.options.apiServerReplicas, credentials.etcdCA != nil, credentials.etcdCert != nil, i.options.apiServerEnableHTTPBasicAuth, i.options.apiServerEnableTokenAuth, i.options.apiServerOverrides, pvc, dryRun, i.options.nodeSelector, i.options.imagePullPolicy, i.options.imagePullSecrets)
+	if err != nil {
+		return err
+	}
+	objects = append(objects, server)
+	if i.options.apiServerReplicas > 1 {
+		pdb, err := createPodDisruptionBudget(hostClientset, i.commonOptions.FederationSystemNamespace, serverName, i.commonOptions.Name, apiserverPodLabels, 1, dryRun)
+		if err != nil {
+			return err
+		}
+		objects = append(objects, pdb)
+	}
+	glog.V(4).Info("Successfully created federation API server")
+
 	glog.V(4).Info("Creating a DNS provider config secret")
-	dnsProviderSecret, err := createDNSProviderConfigSecret(hostClientset, i.commonOptions.FederationSystemNamespace, dnsProviderSecretName, i.commonOptions.Name, dnsProviderConfigBytes, i.options.dryRun)
+	dnsProviderSecret, err := createDNSProviderConfigSecret(hostClientset, i.commonOptions.FederationSystemNamespace, dnsProviderSecretName, i.commonOptions.Name, dnsProviderConfigBytes, dryRun)
 	if err != nil {
 		return err
 	}
+	if dnsProviderSecret != nil {
+		objects = append(objects, dnsProviderSecret)
+	}
 	glog.V(4).Info("Successfully created DNS provider config secret")
 
 	glog.V(4).Info("Creating federation controller manager deployment")
 
-	_, err = createControllerManager(hostClientset, i.commonOptions.FederationSystemNamespace, i.commonOptions.Name, svc.Name, cmName, i.options.serverImage, cmKubeconfigName, i.options.dnsZoneName, i.options.dnsProvider, i.options.dnsProviderConfig, sa.Name, dnsProviderSecret, i.options.controllerManagerOverrides, i.options.dryRun, i.options.nodeSelector, i.options.imagePullPolicy, i.options.imagePullSecrets)
+	cm, err := createControllerManager(hostClientset, i.commonOptions.FederationSystemNamespace, i.commonOptions.Name, svc.Name, cmName, i.options.serverImage, cmKubeconfigName, i.options.dnsZoneName, i.options.dnsProvider, i.options.dnsProviderConfig, sa.Name, i.options.controllerManagerReplicas, dnsProviderSecret, i.options.controllerManagerOverrides, dryRun, i.options.nodeSelector, i.options.imagePullPolicy, i.options.imagePullSecrets)
 	if err != nil {
 		return err
 	}
+	objects = append(objects, cm)
+	if i.options.controllerManagerReplicas > 1 {
+		pdb, err := createPodDisruptionBudget(hostClientset, i.commonOptions.FederationSystemNamespace, cmName, i.commonOptions.Name, controllerManagerPodLabels, 1, dryRun)
+		if err != nil {
+			return err
+		}
+		objects = append(objects, pdb)
+	}
 	glog.V(4).Info("Successfully created federation controller manager deployment")
 	fmt.Fprintln(cmdOut, " done")
 
@@ -449,15 +599,23 @@ func (i *initFederation) Run(cmdOut io.Writer, config util.AdminConfig) error {
 	fmt.Fprintln(cmdOut, " done")
 	glog.V(4).Info("Successfully updated kubeconfig")
 
-	if !i.options.dryRun {
+	if render {
+		glog.V(4).Info("Writing the manifests of the federation control plane")
+		return util.WriteManifests(manifestOut, fmt.Sprintf("Federation control plane %s, apply to the host cluster %s.", i.commonOptions.Name, i.commonOptions.Host), objects...)
+	}
+
+	if !dryRun {
 		fmt.Fprint(cmdOut, "Waiting for federation control plane to come up...")
 		glog.V(4).Info("Waiting for federation control plane to come up")
 		fedPods := []string{serverName, cmName}
-		err = waitForPods(cmdOut, hostClientset, fedPods, i.commonOptions.FederationSystemNamespace)
+		if i.options.etcdClusterSize > 0 {
+			fedPods = append(fedPods, EtcdNameSuffix)
+		}
+		err = waitForPods(cmdOut, hostClientset, fedPods, i.commonOptions.FederationSystemNamespace, 0)
 		if err != nil {
 			return err
 		}
-		err = waitSrvHealthy(cmdOut, config, i.commonOptions.Name, i.commonOptions.Kubeconfig)
+		err = waitSrvHealthy(cmdOut, config, i.commonOptions.Name, i.commonOptions.Kubeconfig, 0)
 		if err != nil {
 			return err
 		}
@@ -529,11 +687,7 @@ func createService(cmdOut io.Writer, clientset client.Interface, namespace, svcN
 	if apiserverServiceType == v1.ServiceTypeLoadBalancer {
 		ips, hostnames, err = waitForLoadBalancerAddress(cmdOut, clientset, svc, dryRun)
 	} else {
-		if apiserverAdvertiseAddress != "" {
-			ips = append(ips, apiserverAdvertiseAddress)
-		} else {
-			ips, err = getClusterNodeIPs(clientset)
-		}
+		ips, err = nodePortAddresses(clientset, apiserverAdvertiseAddress)
 	}
 	if err != nil {
 		return svc, nil, nil, err
@@ -542,6 +696,15 @@ func createService(cmdOut io.Writer, clientset client.Interface, namespace, svcN
 	return svc, ips, hostnames, err
 }
 
+// nodePortAddresses returns the addresses at which the NodePort service of
+// the federation API server is reached.
+func nodePortAddresses(clientset client.Interface, apiserverAdvertiseAddress string) ([]string, error) {
+	if apiserverAdvertiseAddress != "" {
+		return []string{apiserverAdvertiseAddress}, nil
+	}
+	return getClusterNodeIPs(clientset)
+}
+
 func getClusterNodeIPs(clientset client.Interface) ([]string, error) {
 	preferredAddressTypes := []api.NodeAddressType{
 		api.NodeExternalIP,
@@ -660,6 +823,13 @@ func createAPIServerCredentialsSecret(clientset client.Interface, namespace, cre
 	if credentials.token != "" {
 		data["token.csv"] = authFileContents(credentials.username, credentials.token)
 	}
+	if credentials.etcdCA != nil {
+		data["etcd-ca.crt"] = credentials.etcdCA
+	}
+	if credentials.etcdCert != nil {
+		data["etcd-client.crt"] = credentials.etcdCert
+		data["etcd-client.key"] = credentials.etcdKey
+	}
 
 	secret := &api.Secret{
 		ObjectMeta: metav1.ObjectMeta{
@@ -730,7 +900,7 @@ func createPVC(clientset client.Interface, namespace, svcName, federationName, e
 	return clientset.Core().PersistentVolumeClaims(namespace).Create(pvc)
 }
 
-func createAPIServer(clientset client.Interface, namespace, name, federationName, serverImage, etcdImage, advertiseAddress, credentialsName, etcdServers string, hasHTTPBasicAuthFile, hasTokenAuthFile bool, argOverrides map[string]string, pvc *api.PersistentVolumeClaim, dryRun bool, nodeSelector map[string]string, imagePullPolicy, imagePullSecrets string) (*extensions.Deployment, error) {
+func createAPIServer(clientset client.Interface, namespace, name, federationName, serverImage, etcdImage, advertiseAddress, credentialsName, etcdServers, saName string, replicas int32, hasEtcdCAFile, hasEtcdCertFile, hasHTTPBasicAuthFile, hasTokenAuthFile bool, argOverrides map[string]string, pvc *api.PersistentVolumeClaim, dryRun bool, nodeSelector map[string]string, imagePullPolicy, imagePullSecrets string) (*extensions.Deployment, error) {
 	command := []string{
 		"/fcp",
 		"federation-apiserver",
@@ -748,6 +918,13 @@ func createAPIServer(clientset client.Interface, namespace, name, federationName
 	} else {
 		argsMap["--etcd-servers"] = "http://localhost:2379"
 	}
+	if hasEtcdCAFile {
+		argsMap["--etcd-cafile"] = "/etc/federation/apiserver/etcd-ca.crt"
+	}
+	if hasEtcdCertFile {
+		argsMap["--etcd-certfile"] = "/etc/federation/apiserver/etcd-client.crt"
+		argsMap["--etcd-keyfile"] = "/etc/federation/apiserver/etcd-client.key"
+	}
 
 	if advertiseAddress != "" {
 		argsMap["--advertise-address"] = advertiseAddress
@@ -770,7 +947,7 @@ func createAPIServer(clientset client.Interface, namespace, name, federationName
 			Annotations: map[string]string{federation.FederationNameAnnotation: federationName},
 		},
 		Spec: extensions.DeploymentSpec{
-			Replicas: 1,
+			Replicas: replicas,
 			Template: api.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:        name,
@@ -801,6 +978,16 @@ func createAPIServer(clientset client.Interface, namespace, name, federationName
 									ReadOnly:  true,
 								},
 							},
+							Env: []api.EnvVar{
+								{
+									Name: "POD_NAMESPACE",
+									ValueFrom: &api.EnvVarSource{
+										FieldRef: &api.ObjectFieldSelector{
+											FieldPath: "metadata.namespace",
+										},
+									},
+								},
+							},
 						},
 					},
 					NodeSelector: nodeSelector,
@@ -824,6 +1011,14 @@ func createAPIServer(clientset client.Interface, namespace, name, federationName
 		},
 	}
 
+	if saName != "" {
+		dep.Spec.Template.Spec.ServiceAccountName = saName
+	}
+
+	if replicas > 1 {
+		dep.Spec.Template.Spec.Affinity = podAntiAffinity(apiserverPodLabels)
+	}
+
 	if etcdServers == "" {
 		etcdContainer := api.Container{
 			Name:  "etcd",
@@ -886,8 +1081,9 @@ func createControllerManagerSA(clientset client.Interface, namespace, federation
 func createRoleBindings(clientset client.Interface, namespace, saName, federationName string, dryRun bool) (*rbac.Role, *rbac.RoleBinding, error) {
 	roleName := "federation-system:federation-controller-manager"
 	role := &rbac.Role{
-		// a role to use for bootstrapping the federation-controller-manager so it can access
-		// secrets in the host cluster to access other clusters.
+		// a role to use for bootstrapping the federation-controller-manager and the
+		// federation-apiserver so they can access secrets in the host cluster to access
+		// other clusters.
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        roleName,
 			Namespace:   namespace,
@@ -919,7 +1115,7 @@ func createRoleBindings(clientset client.Interface, namespace, saName, federatio
 	return newRole, newRolebinding, err
 }
 
-func createControllerManager(clientset client.Interface, namespace, name, svcName, cmName, image, kubeconfigName, dnsZoneName, dnsProvider, dnsProviderConfig, saName string, dnsProviderSecret *api.Secret, argOverrides map[string]string, dryRun bool, nodeSelector map[string]string, imagePullPolicy, imagePullSecrets string) (*extensions.Deployment, error) {
+func createControllerManager(clientset client.Interface, namespace, name, svcName, cmName, image, kubeconfigName, dnsZoneName, dnsProvider, dnsProviderConfig, saName string, replicas int32, dnsProviderSecret *api.Secret, argOverrides map[string]string, dryRun bool, nodeSelector map[string]string, imagePullPolicy, imagePullSecrets string) (*extensions.Deployment, error) {
 	command := []string{
 		"/fcp",
 		"federation-controller-manager",
@@ -932,6 +1128,10 @@ func createControllerManager(clientset client.Interface, namespace, name, svcNam
 	argsMap["--dns-provider"] = dnsProvider
 	argsMap["--federation-name"] = name
 	argsMap["--zone-name"] = dnsZoneName
+	if replicas > 1 {
+		// Only one of the replicas runs the controllers at a time.
+		argsMap["--leader-elect"] = "true"
+	}
 
 	args := argMapsToArgStrings(argsMap, argOverrides)
 	command = append(command, args...)
@@ -956,7 +1156,7 @@ func createControllerManager(clientset client.Interface, namespace, name, svcNam
 			},
 		},
 		Spec: extensions.DeploymentSpec{
-			Replicas: 1,
+			Replicas: replicas,
 			Template: api.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:        cmName,
@@ -1014,6 +1214,10 @@ func createControllerManager(clientset client.Interface, namespace, name, svcNam
 		dep.Spec.Template.Spec.ServiceAccountName = saName
 	}
 
+	if replicas > 1 {
+		dep.Spec.Template.Spec.Affinity = podAntiAffinity(controllerManagerPodLabels)
+	}
+
 	if dnsProviderSecret != nil {
 		dep = addDNSProviderConfig(dep, dnsProviderSecret.Name)
 		if dnsProvider == util.FedDNSProviderCoreDNS {
@@ -1032,6 +1236,212 @@ func createControllerManager(clientset client.Interface, namespace, name, svcNam
 	return clientset.Extensions().Deployments(namespace).Create(dep)
 }
 
+// readEtcdCredentials reads the files holding the credentials of the federation
+// API server for external etcd servers. Files that are not given are skipped.
+func readEtcdCredentials(caFile, certFile, keyFile string) (ca, cert, key []byte, err error) {
+	if caFile != "" {
+		if ca, err = ioutil.ReadFile(caFile); err != nil {
+			return nil, nil, nil, fmt.Errorf("error reading file provided to --etcd-cafile flag, err: %v", err)
+		}
+	}
+	if certFile != "" {
+		if cert, err = ioutil.ReadFile(certFile); err != nil {
+			return nil, nil, nil, fmt.Errorf("error reading file provided to --etcd-certfile flag, err: %v", err)
+		}
+		if key, err = ioutil.ReadFile(keyFile); err != nil {
+			return nil, nil, nil, fmt.Errorf("error reading file provided to --etcd-keyfile flag, err: %v", err)
+		}
+	}
+	return ca, cert, key, nil
+}
+
+// etcdMemberURL returns the URL of the given port of an etcd member of the
+// StatefulSet, resolved through its headless service.
+func etcdMemberURL(namespace, name string, member int32, port int) string {
+	return fmt.Sprintf("http://%s-%d.%s.%s:%d", name, member, name, namespace, port)
+}
+
+// createEtcdCluster deploys an etcd cluster of the given size in a StatefulSet,
+// with a headless service to reach its members and a pod disruption budget
+// that preserves its quorum. It returns the objects it creates and the client
+// URLs of the members.
+func createEtcdCluster(clientset client.Interface, namespace, name, federationName, image string, size int32, persistentStorage bool, pvCapacity, pvStorageClass string, dryRun bool, nodeSelector map[string]string, imagePullSecrets string) ([]runtime.Object, string, error) {
+	svc := &api.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   namespace,
+			Labels:      componentLabel,
+			Annotations: map[string]string{federation.FederationNameAnnotation: federationName},
+		},
+		Spec: api.ServiceSpec{
+			ClusterIP: api.ClusterIPNone,
+			Selector:  etcdPodLabels,
+			Ports: []api.ServicePort{
+				{Name: "client", Protocol: "TCP", Port: etcdClientPort},
+				{Name: "peer", Protocol: "TCP", Port: etcdPeerPort},
+			},
+			// The members must resolve each other before they are ready.
+			PublishNotReadyAddresses: true,
+		},
+	}
+
+	peers := []string{}
+	servers := []string{}
+	for member := int32(0); member < size; member++ {
+		peers = append(peers, fmt.Sprintf("%s-%d=%s", name, member, etcdMemberURL(namespace, name, member, etcdPeerPort)))
+		servers = append(servers, etcdMemberURL(namespace, name, member, etcdClientPort))
+	}
+	memberURL := fmt.Sprintf("http://$(POD_NAME).%s.%s", name, namespace)
+
+	statefulSet := &apps.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   namespace,
+			Labels:      componentLabel,
+			Annotations: map[string]string{federation.FederationNameAnnotation: federationName},
+		},
+		Spec: apps.StatefulSetSpec{
+			Replicas:            size,
+			Selector:            &metav1.LabelSelector{MatchLabels: etcdPodLabels},
+			ServiceName:         name,
+			PodManagementPolicy: apps.ParallelPodManagement,
+			Template: api.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      etcdPodLabels,
+					Annotations: map[string]string{federation.FederationNameAnnotation: federationName},
+				},
+				Spec: api.PodSpec{
+					Containers: []api.Container{
+						{
+							Name:  "etcd",
+							Image: image,
+							Command: []string{
+								"/usr/local/bin/etcd",
+								"--name=$(POD_NAME)",
+								"--data-dir=/var/etcd/data",
+								fmt.Sprintf("--listen-client-urls=http://0.0.0.0:%d", etcdClientPort),
+								fmt.Sprintf("--listen-peer-urls=http://0.0.0.0:%d", etcdPeerPort),
+								fmt.Sprintf("--advertise-client-urls=%s:%d", memberURL, etcdClientPort),
+								fmt.Sprintf("--initial-advertise-peer-urls=%s:%d", memberURL, etcdPeerPort),
+								fmt.Sprintf("--initial-cluster=%s", strings.Join(peers, ",")),
+								"--initial-cluster-state=new",
+							},
+							Ports: []api.ContainerPort{
+								{Name: "client", ContainerPort: etcdClientPort},
+								{Name: "peer", ContainerPort: etcdPeerPort},
+							},
+							Env: []api.EnvVar{
+								{
+									Name: "POD_NAME",
+									ValueFrom: &api.EnvVarSource{
+										FieldRef: &api.ObjectFieldSelector{
+											FieldPath: "metadata.name",
+										},
+									},
+								},
+							},
+							VolumeMounts: []api.VolumeMount{
+								{
+									Name:      "etcddata",
+									MountPath: "/var/etcd",
+								},
+							},
+						},
+					},
+					Affinity:     podAntiAffinity(etcdPodLabels),
+					NodeSelector: nodeSelector,
+					ImagePullSecrets: []api.LocalObjectReference{
+						{
+							Name: imagePullSecrets,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if persistentStorage {
+		// Each member gets its own claim, built from the claim template.
+		pvc, err := createPVC(clientset, namespace, name, federationName, pvCapacity, pvStorageClass, true)
+		if err != nil {
+			return nil, "", err
+		}
+		pvc.Name = "etcddata"
+		pvc.Namespace = ""
+		statefulSet.Spec.VolumeClaimTemplates = []api.PersistentVolumeClaim{*pvc}
+	} else {
+		statefulSet.Spec.Template.Spec.Volumes = []api.Volume{
+			{
+				Name: "etcddata",
+				VolumeSource: api.VolumeSource{
+					EmptyDir: &api.EmptyDirVolumeSource{},
+				},
+			},
+		}
+	}
+
+	if !dryRun {
+		if _, err := clientset.Core().Services(namespace).Create(svc); err != nil {
+			return nil, "", err
+		}
+		if _, err := clientset.Apps().StatefulSets(namespace).Create(statefulSet); err != nil {
+			return nil, "", err
+		}
+	}
+	objects := []runtime.Object{svc, statefulSet}
+	// The cluster keeps its quorum as long as a majority of the members are available.
+	if size > 1 {
+		pdb, err := createPodDisruptionBudget(clientset, namespace, name, federationName, etcdPodLabels, size/2, dryRun)
+		if err != nil {
+			return nil, "", err
+		}
+		objects = append(objects, pdb)
+	}
+	return objects, strings.Join(servers, ","), nil
+}
+
+// createPodDisruptionBudget limits the number of replicas of a federation
+// control plane component that can be voluntarily disrupted at a time.
+func createPodDisruptionBudget(clientset client.Interface, namespace, name, federationName string, podLabels map[string]string, maxUnavailable int32, dryRun bool) (*policy.PodDisruptionBudget, error) {
+	unavailable := intstr.FromInt(int(maxUnavailable))
+	pdb := &policy.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   namespace,
+			Labels:      componentLabel,
+			Annotations: map[string]string{federation.FederationNameAnnotation: federationName},
+		},
+		Spec: policy.PodDisruptionBudgetSpec{
+			Selector:       &metav1.LabelSelector{MatchLabels: podLabels},
+			MaxUnavailable: &unavailable,
+		},
+	}
+
+	if dryRun {
+		return pdb, nil
+	}
+
+	return clientset.Policy().PodDisruptionBudgets(namespace).Create(pdb)
+}
+
+// podAntiAffinity makes the scheduler prefer to spread the pods with the given
+// labels on different nodes, so a node failure takes out a single replica.
+func podAntiAffinity(podLabels map[string]string) *api.Affinity {
+	return &api.Affinity{
+		PodAntiAffinity: &api.PodAntiAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []api.WeightedPodAffinityTerm{
+				{
+					Weight: 100,
+					PodAffinityTerm: api.PodAffinityTerm{
+						LabelSelector: &metav1.LabelSelector{MatchLabels: podLabels},
+						TopologyKey:   kubeletapis.LabelHostname,
+					},
+				},
+			},
+		},
+	}
+}
+
 func marshallOverrides(overrideArgString string) (map[string]string, error) {
 	if overrideArgString == "" {
 		return nil, nil
@@ -1067,8 +1477,17 @@ func argMapsToArgStrings(argsMap, overrides map[string]string) []string {
 	return args
 }
 
-func waitForPods(cmdOut io.Writer, clientset client.Interface, fedPods []string, namespace string) error {
-	err := wait.PollInfinite(podWaitInterval, func() (bool, error) {
+// poll runs the condition every podWaitInterval until it is done. It gives
+// up after the timeout, unless the timeout is 0.
+func poll(timeout time.Duration, condition wait.ConditionFunc) error {
+	if timeout == 0 {
+		return wait.PollInfinite(podWaitInterval, condition)
+	}
+	return wait.Poll(podWaitInterval, timeout, condition)
+}
+
+func waitForPods(cmdOut io.Writer, clientset client.Interface, fedPods []string, namespace string, timeout time.Duration) error {
+	err := poll(timeout, func() (bool, error) {
 		fmt.Fprint(cmdOut, ".")
 		podCheck := len(fedPods)
 		podList, err := clientset.Core().Pods(namespace).List(metav1.ListOptions{})
@@ -1091,13 +1510,13 @@ func waitForPods(cmdOut io.Writer, clientset client.Interface, fedPods []string,
 	return err
 }
 
-func waitSrvHealthy(cmdOut io.Writer, config util.AdminConfig, context, kubeconfig string) error {
+func waitSrvHealthy(cmdOut io.Writer, config util.AdminConfig, context, kubeconfig string, timeout time.Duration) error {
 	fedClientSet, err := config.FederationClientset(context, kubeconfig)
 	if err != nil {
 		return err
 	}
 	fedDiscoveryClient := fedClientSet.Discovery()
-	err = wait.PollInfinite(podWaitInterval, func() (bool, error) {
+	err = poll(timeout, func() (bool, error) {
 		fmt.Fprint(cmdOut, ".")
 		body, err := fedDiscoveryClient.RESTClient().Get().AbsPath("/healthz").Do().Raw()
 		if err != nil {
@@ -1195,15 +1614,10 @@ func createDNSProviderConfigSecret(clientset client.Interface, namespace, name,
 		},
 	}
 
-	var secret *api.Secret
-	var err error
-	if !dryRun {
-		secret, err = clientset.Core().Secrets(namespace).Create(secretSpec)
-		if err != nil {
-			return nil, err
-		}
+	if dryRun {
+		return secretSpec, nil
 	}
-	return secret, nil
+	return clientset.Core().Secrets(namespace).Create(secretSpec)
 }
 
 func addDNSProviderConfig(dep *extensions.Deployment, secretName string) *extensions.Deployment {
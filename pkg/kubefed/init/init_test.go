@@ -433,6 +433,108 @@ func TestMarshallAndMergeOverrides(t *testing.T) {
 // end-to-end over HTTPS.
 // TODO(madhusudancs): Consider using a deterministic random number generator
 // for generating certificates in tests.
+func TestValidateHA(t *testing.T) {
+	testCases := []struct {
+		options     initFederationOptions
+		expectedErr string
+	}{
+		{
+			options: initFederationOptions{apiServerReplicas: 1, controllerManagerReplicas: 1},
+		},
+		{
+			options: initFederationOptions{apiServerReplicas: 3, controllerManagerReplicas: 2, etcdClusterSize: 3},
+		},
+		{
+			options: initFederationOptions{apiServerReplicas: 3, controllerManagerReplicas: 1, etcdServers: "https://etcd:2379"},
+		},
+		{
+			options:     initFederationOptions{apiServerReplicas: 0, controllerManagerReplicas: 1},
+			expectedErr: "--apiserver-replicas must be at least 1",
+		},
+		{
+			options:     initFederationOptions{apiServerReplicas: 2, controllerManagerReplicas: 1},
+			expectedErr: "--apiserver-replicas greater than 1 requires --etcd-servers or --etcd-cluster-size, the replicas can not share the etcd running in the pod of an API server",
+		},
+		{
+			options:     initFederationOptions{apiServerReplicas: 1, controllerManagerReplicas: 1, etcdClusterSize: 2},
+			expectedErr: "--etcd-cluster-size must be an odd number",
+		},
+		{
+			options:     initFederationOptions{apiServerReplicas: 1, controllerManagerReplicas: 1, etcdClusterSize: 3, etcdServers: "https://etcd:2379"},
+			expectedErr: "--etcd-cluster-size can not be used with --etcd-servers",
+		},
+		{
+			options:     initFederationOptions{apiServerReplicas: 1, controllerManagerReplicas: 1, etcdCAFile: "ca.crt"},
+			expectedErr: "--etcd-cafile, --etcd-certfile and --etcd-keyfile are only valid with --etcd-servers",
+		},
+		{
+			options:     initFederationOptions{apiServerReplicas: 1, controllerManagerReplicas: 1, etcdServers: "https://etcd:2379", etcdCertFile: "client.crt"},
+			expectedErr: "--etcd-certfile and --etcd-keyfile must be given together",
+		},
+	}
+
+	for i, tc := range testCases {
+		err := tc.options.validateHA()
+		if tc.expectedErr == "" && err != nil {
+			t.Errorf("[%d] unexpected error: %v", i, err)
+		}
+		if tc.expectedErr != "" && (err == nil || err.Error() != tc.expectedErr) {
+			t.Errorf("[%d] expected error %q, got: %v", i, tc.expectedErr, err)
+		}
+	}
+}
+
+func TestCreateEtcdCluster(t *testing.T) {
+	objects, servers, err := createEtcdCluster(nil, "federation-system", EtcdNameSuffix, "myfed", "etcd:latest", 3, true, "10Gi", "", true, nil, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "http://etcd-0.etcd.federation-system:2379,http://etcd-1.etcd.federation-system:2379,http://etcd-2.etcd.federation-system:2379"
+	if servers != expected {
+		t.Errorf("expected etcd servers %q, got %q", expected, servers)
+	}
+	// The headless service, the StatefulSet and the pod disruption budget.
+	if len(objects) != 3 {
+		t.Errorf("expected 3 objects, got %d", len(objects))
+	}
+}
+
+func TestValidateOutput(t *testing.T) {
+	testCases := []struct {
+		options     initFederationOptions
+		expectedErr string
+	}{
+		{
+			options: initFederationOptions{apiServerServiceType: v1.ServiceTypeLoadBalancer},
+		},
+		{
+			options: initFederationOptions{output: "yaml", apiServerServiceType: v1.ServiceTypeNodePort, apiServerNodePortPort: 32111},
+		},
+		{
+			options:     initFederationOptions{output: "json", apiServerServiceType: v1.ServiceTypeNodePort, apiServerNodePortPort: 32111},
+			expectedErr: "invalid --output: json, the only supported output format is yaml",
+		},
+		{
+			options:     initFederationOptions{output: "yaml", apiServerServiceType: v1.ServiceTypeLoadBalancer},
+			expectedErr: "--output requires 'api-server-service-type=NodePort' and api-server-port, the address of the federation API server must be known in advance",
+		},
+		{
+			options:     initFederationOptions{output: "yaml", apiServerServiceType: v1.ServiceTypeNodePort},
+			expectedErr: "--output requires 'api-server-service-type=NodePort' and api-server-port, the address of the federation API server must be known in advance",
+		},
+	}
+
+	for i, tc := range testCases {
+		err := tc.options.validateOutput()
+		if tc.expectedErr == "" && err != nil {
+			t.Errorf("[%d] unexpected error: %v", i, err)
+		}
+		if tc.expectedErr != "" && (err == nil || err.Error() != tc.expectedErr) {
+			t.Errorf("[%d] expected error %q, got: %v", i, tc.expectedErr, err)
+		}
+	}
+}
+
 func TestCertsTLS(t *testing.T) {
 	params := []certParams{
 		{
@@ -984,6 +1086,16 @@ func fakeInitHostFactory(apiserverServiceType v1.ServiceType, federationName, na
 									ReadOnly:  true,
 								},
 							},
+							Env: []v1.EnvVar{
+								{
+									Name: "POD_NAMESPACE",
+									ValueFrom: &v1.EnvVarSource{
+										FieldRef: &v1.ObjectFieldSelector{
+											FieldPath: "metadata.namespace",
+										},
+									},
+								},
+							},
 						},
 						{
 							Name:  "etcd",
@@ -1015,6 +1127,10 @@ func fakeInitHostFactory(apiserverServiceType v1.ServiceType, federationName, na
 			},
 		},
 	}
+	if isRBACAPIAvailable && (kubeconfigForCredentials == "") {
+		apiserver.Spec.Template.Spec.ServiceAccountName = "federation-controller-manager"
+		apiserver.Spec.Template.Spec.DeprecatedServiceAccount = "federation-controller-manager"
+	}
 	if etcdPersistence == "true" {
 		dataVolumeName := "etcddata"
 		etcdVolume := v1.Volume{
@@ -0,0 +1,658 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package init
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	restclient "k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/federation/apis/federation"
+	"k8s.io/federation/pkg/kubefed/util"
+	"k8s.io/kubernetes/pkg/api/legacyscheme"
+	api "k8s.io/kubernetes/pkg/apis/core"
+	"k8s.io/kubernetes/pkg/apis/extensions"
+	client "k8s.io/kubernetes/pkg/client/clientset_generated/internalclientset"
+	"k8s.io/kubernetes/pkg/kubectl/cmd/templates"
+	cmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+	"k8s.io/kubernetes/pkg/util/version"
+
+	"github.com/golang/glog"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const (
+	// Directory in the etcd data volume the etcd backups are written to.
+	etcdBackupDir = "/var/etcd/backup"
+	// Port the federation controller manager serves its health checks on,
+	// unless overridden with --port.
+	controllerManagerPort = 10253
+)
+
+var (
+	upgrade_long = templates.LongDesc(`
+		Upgrade upgrades a federation control plane that was
+		initialized by kubefed init.
+
+        The federation API server and controller manager are rolled,
+        one after the other, to the given images and arguments. The
+        versions are checked for skew and the federation's etcd data
+        is backed up first. A component that does not become healthy
+        is rolled back, except for a federation API server whose etcd
+        was upgraded with --etcd-image: its etcd data must be restored
+        from the backup first.`)
+	upgrade_example = templates.Examples(`
+		# Upgrade the federation control plane of the federation
+		# named foo in the host cluster whose local kubeconfig
+		# context is bar to the given image.
+		kubefed upgrade foo --host-cluster-context=bar --image=gcr.io/k8s-jkns-e2e-gce-federation/fcp-amd64:v1.10.0`)
+)
+
+// etcdTopology is the way the etcd of a federation API server is deployed.
+type etcdTopology string
+
+const (
+	// etcd runs in the pods of the federation API server.
+	etcdSidecar etcdTopology = "sidecar"
+	// etcd runs in a StatefulSet created by kubefed init.
+	etcdStatefulSet etcdTopology = "statefulset"
+	// etcd was deployed outside of the federation control plane.
+	etcdExternal etcdTopology = "external"
+)
+
+type upgradeFederation struct {
+	commonOptions util.SubcommandOptions
+	options       upgradeFederationOptions
+}
+
+type upgradeFederationOptions struct {
+	serverImage                      string
+	etcdImage                        string
+	apiServerOverridesString         string
+	apiServerOverrides               map[string]string
+	controllerManagerOverridesString string
+	controllerManagerOverrides       map[string]string
+	skipEtcdBackup                   bool
+	timeout                          time.Duration
+	dryRun                           bool
+}
+
+func (o *upgradeFederationOptions) Bind(flags *pflag.FlagSet) {
+	flags.StringVar(&o.serverImage, "image", "", "Image to upgrade the federation API server and controller manager binaries to. The deployed images are kept if empty.")
+	flags.StringVar(&o.etcdImage, "etcd-image", "", "Image to upgrade the etcd server running in the pods of the federation API server to. The etcd image is not changed if empty.")
+	flags.StringVar(&o.apiServerOverridesString, "apiserver-arg-overrides", "", "comma separated list of federation-apiserver arguments to override: Example \"--arg1=value1,--arg2=value2...\"")
+	flags.StringVar(&o.controllerManagerOverridesString, "controllermanager-arg-overrides", "", "comma separated list of federation-controller-manager arguments to override: Example \"--arg1=value1,--arg2=value2...\"")
+	flags.BoolVar(&o.skipEtcdBackup, "skip-etcd-backup", false, "Upgrade without taking a snapshot of the federation's etcd data first.")
+	flags.DurationVar(&o.timeout, "timeout", 5*time.Minute, "Time to wait for an upgraded component to become healthy before it is rolled back.")
+	flags.BoolVar(&o.dryRun, "dry-run", false, "dry run without sending commands to server.")
+}
+
+// NewCmdUpgrade defines the `upgrade` command that upgrades a federation
+// control plane in place.
+func NewCmdUpgrade(cmdOut io.Writer, config util.AdminConfig) *cobra.Command {
+	opts := &upgradeFederation{}
+
+	cmd := &cobra.Command{
+		Use:     "upgrade FEDERATION_NAME --host-cluster-context=HOST_CONTEXT",
+		Short:   "Upgrade a federation control plane",
+		Long:    upgrade_long,
+		Example: upgrade_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(opts.Complete(cmd, args))
+			cmdutil.CheckErr(opts.Run(cmdOut, config))
+		},
+	}
+
+	flags := cmd.Flags()
+	opts.commonOptions.Bind(flags)
+	opts.options.Bind(flags)
+
+	return cmd
+}
+
+// Complete ensures that options are valid and marshals them if necessary.
+func (u *upgradeFederation) Complete(cmd *cobra.Command, args []string) error {
+	err := u.commonOptions.SetName(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	if u.options.timeout <= 0 {
+		return fmt.Errorf("--timeout must be positive")
+	}
+
+	u.options.apiServerOverrides, err = marshallOverrides(u.options.apiServerOverridesString)
+	if err != nil {
+		return fmt.Errorf("error marshalling --apiserver-arg-overrides: %v", err)
+	}
+	u.options.controllerManagerOverrides, err = marshallOverrides(u.options.controllerManagerOverridesString)
+	if err != nil {
+		return fmt.Errorf("error marshalling --controllermanager-arg-overrides: %v", err)
+	}
+	return nil
+}
+
+// Run upgrades a federation control plane. The federation API server is
+// upgraded before the controller manager, so that the controller manager
+// never runs against an older API server.
+func (u *upgradeFederation) Run(cmdOut io.Writer, config util.AdminConfig) error {
+	var hostFactory cmdutil.Factory
+	if u.commonOptions.CredentialsKubeconfig != "" {
+		hostFactory = config.ClusterFactory(u.commonOptions.Host, u.commonOptions.CredentialsKubeconfig)
+	} else {
+		hostFactory = config.ClusterFactory(u.commonOptions.Host, u.commonOptions.Kubeconfig)
+	}
+	hostClientset, err := hostFactory.ClientSet()
+	if err != nil {
+		return err
+	}
+	namespace := u.commonOptions.FederationSystemNamespace
+
+	fmt.Fprint(cmdOut, "Reading federation control plane deployments...")
+	server, err := getFederationDeployment(hostClientset, namespace, APIServerNameSuffix, u.commonOptions.Name)
+	if err != nil {
+		return err
+	}
+	cm, err := getFederationDeployment(hostClientset, namespace, CMNameSuffix, u.commonOptions.Name)
+	if err != nil {
+		return err
+	}
+	topology, err := getEtcdTopology(hostClientset, namespace, server)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(cmdOut, " done")
+
+	newServer, err := upgradedDeployment(server, "apiserver", u.options.serverImage, u.options.apiServerOverrides)
+	if err != nil {
+		return err
+	}
+	if u.options.etcdImage != "" {
+		if topology != etcdSidecar {
+			return fmt.Errorf("--etcd-image can only upgrade the etcd running in the pods of the federation API server, the federation uses %s etcd", topology)
+		}
+		etcd := findContainer(&newServer.Spec.Template.Spec, "etcd")
+		etcd.Image = u.options.etcdImage
+	}
+	if topology == etcdSidecar {
+		// The new pods can neither share the etcd data volume with the old
+		// pods nor run a second etcd on the same data.
+		newServer.Spec.Strategy = extensions.DeploymentStrategy{Type: extensions.RecreateDeploymentStrategyType}
+	}
+	newCM, err := upgradedDeployment(cm, "controller-manager", u.options.serverImage, u.options.controllerManagerOverrides)
+	if err != nil {
+		return err
+	}
+
+	if apiequality.Semantic.DeepEqual(server.Spec.Template, newServer.Spec.Template) && apiequality.Semantic.DeepEqual(cm.Spec.Template, newCM.Spec.Template) {
+		_, err = fmt.Fprintln(cmdOut, "Federation control plane is up to date, nothing to upgrade")
+		return err
+	}
+
+	fmt.Fprint(cmdOut, "Running preflight checks...")
+	glog.V(4).Info("Running preflight checks for the federation control plane upgrade")
+	err = preflightChecks(cmdOut, server, newServer, cm, newCM, topology)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(cmdOut, " done")
+
+	if u.options.dryRun {
+		_, err = fmt.Fprintln(cmdOut, "Federation control plane can be upgraded (dry run)")
+		glog.V(4).Info("Federation control plane can be upgraded (dry run)")
+		return err
+	}
+
+	// The snapshot of the etcd data taken before the upgrade, if any.
+	snapshot := ""
+	if !u.options.skipEtcdBackup {
+		fmt.Fprint(cmdOut, "Backing up etcd...")
+		glog.V(4).Info("Taking a snapshot of the federation's etcd data")
+		hostConfig, err := hostFactory.ClientConfig()
+		if err != nil {
+			return err
+		}
+		snapshot, err = backupEtcd(cmdOut, hostConfig, hostClientset, namespace, topology)
+		if err != nil {
+			return fmt.Errorf("error backing up etcd, pass --skip-etcd-backup to upgrade without a backup: %v", err)
+		}
+	}
+
+	// Once the new etcd has started on the data it may have migrated it to a
+	// format the previous etcd can not read, so the API server is not rolled
+	// back automatically.
+	etcdUpgraded := etcdImageChanged(server, newServer)
+	// The deployments that were updated, in their state before the upgrade.
+	updated := []*extensions.Deployment{}
+	rollback := func(cause error) error {
+		fmt.Fprintln(cmdOut, " failed")
+		fmt.Fprint(cmdOut, "Rolling back federation control plane...")
+		glog.V(4).Infof("Rolling back federation control plane: %v", cause)
+		serverKept := false
+		for i := len(updated) - 1; i >= 0; i-- {
+			if updated[i].Name == server.Name && etcdUpgraded {
+				serverKept = true
+				continue
+			}
+			err := rollbackDeployment(cmdOut, hostClientset, namespace, updated[i], u.options.timeout)
+			if err != nil {
+				return fmt.Errorf("error upgrading federation control plane: %v, rolling back %s failed: %v", cause, updated[i].Name, err)
+			}
+		}
+		if serverKept {
+			fmt.Fprintln(cmdOut, " incomplete")
+			return etcdRollbackError(cause, server.Name, snapshot)
+		}
+		fmt.Fprintln(cmdOut, " done")
+		return fmt.Errorf("error upgrading federation control plane, the upgrade was rolled back: %v", cause)
+	}
+
+	steps := []struct {
+		current, upgraded *extensions.Deployment
+		// waitHealthy waits until the upgraded component is healthy.
+		waitHealthy func() error
+	}{
+		{server, newServer, func() error {
+			err := waitSrvHealthy(cmdOut, config, u.commonOptions.Name, u.commonOptions.Kubeconfig, u.options.timeout)
+			if err != nil {
+				return fmt.Errorf("federation API server is not healthy: %v", err)
+			}
+			return nil
+		}},
+		{cm, newCM, func() error {
+			err := waitCMHealthy(cmdOut, hostClientset, namespace, newCM, u.options.timeout)
+			if err != nil {
+				return fmt.Errorf("federation controller manager is not healthy: %v", err)
+			}
+			return nil
+		}},
+	}
+	for _, step := range steps {
+		if apiequality.Semantic.DeepEqual(step.current.Spec.Template, step.upgraded.Spec.Template) {
+			continue
+		}
+		fmt.Fprintf(cmdOut, "Upgrading %s...", step.current.Name)
+		glog.V(4).Infof("Upgrading deployment %s", step.current.Name)
+		dep, err := hostClientset.Extensions().Deployments(namespace).Update(step.upgraded)
+		if err != nil {
+			return rollback(err)
+		}
+		updated = append(updated, step.current)
+
+		err = waitForRollout(cmdOut, hostClientset, namespace, dep.Name, dep.Generation, u.options.timeout)
+		if err != nil {
+			return rollback(fmt.Errorf("%s did not roll out: %v", dep.Name, err))
+		}
+		err = waitForPods(cmdOut, hostClientset, []string{dep.Name}, namespace, u.options.timeout)
+		if err != nil {
+			return rollback(fmt.Errorf("%s pods are not running: %v", dep.Name, err))
+		}
+		err = step.waitHealthy()
+		if err != nil {
+			return rollback(err)
+		}
+		fmt.Fprintln(cmdOut, " done")
+		glog.V(4).Infof("Successfully upgraded deployment %s", dep.Name)
+	}
+
+	_, err = fmt.Fprintln(cmdOut, "Federation control plane upgraded")
+	return err
+}
+
+// getFederationDeployment returns the named deployment of the federation
+// control plane.
+func getFederationDeployment(clientset client.Interface, namespace, name, federationName string) (*extensions.Deployment, error) {
+	dep, err := clientset.Extensions().Deployments(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if dep.Annotations[federation.FederationNameAnnotation] != federationName {
+		return nil, fmt.Errorf("deployment %s/%s does not belong to the federation %s", namespace, name, federationName)
+	}
+	return dep, nil
+}
+
+// getEtcdTopology returns how the etcd of the given federation API server
+// is deployed.
+func getEtcdTopology(clientset client.Interface, namespace string, server *extensions.Deployment) (etcdTopology, error) {
+	if findContainer(&server.Spec.Template.Spec, "etcd") != nil {
+		return etcdSidecar, nil
+	}
+	_, err := clientset.Apps().StatefulSets(namespace).Get(EtcdNameSuffix, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return etcdExternal, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return etcdStatefulSet, nil
+}
+
+func findContainer(spec *api.PodSpec, name string) *api.Container {
+	for i := range spec.Containers {
+		if spec.Containers[i].Name == name {
+			return &spec.Containers[i]
+		}
+	}
+	return nil
+}
+
+// upgradedDeployment returns a copy of the given deployment with the image
+// of the named container replaced, unless empty, and the arguments
+// overridden.
+func upgradedDeployment(dep *extensions.Deployment, containerName, image string, argOverrides map[string]string) (*extensions.Deployment, error) {
+	upgraded := dep.DeepCopy()
+	container := findContainer(&upgraded.Spec.Template.Spec, containerName)
+	if container == nil {
+		return nil, fmt.Errorf("deployment %s has no %s container", dep.Name, containerName)
+	}
+	// The command is the binary and the component followed by the arguments.
+	if len(container.Command) < 2 {
+		return nil, fmt.Errorf("unexpected command %v of the %s container", container.Command, containerName)
+	}
+	argsMap := map[string]string{}
+	for _, arg := range container.Command[2:] {
+		splitArg := strings.SplitN(arg, "=", 2)
+		if len(splitArg) != 2 {
+			return nil, fmt.Errorf("unexpected argument %q of the %s container", arg, containerName)
+		}
+		argsMap[splitArg[0]] = splitArg[1]
+	}
+
+	command := append([]string{}, container.Command[:2]...)
+	container.Command = append(command, argMapsToArgStrings(argsMap, argOverrides)...)
+	if image != "" {
+		container.Image = image
+	}
+	return upgraded, nil
+}
+
+// preflightChecks verifies that the federation control plane is healthy
+// and can be upgraded to the given deployments without losing data.
+func preflightChecks(cmdOut io.Writer, server, newServer, cm, newCM *extensions.Deployment, topology etcdTopology) error {
+	for _, dep := range []*extensions.Deployment{server, cm} {
+		if dep.Status.ObservedGeneration < dep.Generation || dep.Status.UpdatedReplicas != dep.Spec.Replicas || dep.Status.AvailableReplicas != dep.Spec.Replicas {
+			return fmt.Errorf("deployment %s is not fully available, %d of %d replicas are available", dep.Name, dep.Status.AvailableReplicas, dep.Spec.Replicas)
+		}
+	}
+
+	err := checkVersionSkew(cmdOut, "federation API server", findContainer(&server.Spec.Template.Spec, "apiserver").Image, findContainer(&newServer.Spec.Template.Spec, "apiserver").Image)
+	if err != nil {
+		return err
+	}
+	err = checkVersionSkew(cmdOut, "federation controller manager", findContainer(&cm.Spec.Template.Spec, "controller-manager").Image, findContainer(&newCM.Spec.Template.Spec, "controller-manager").Image)
+	if err != nil {
+		return err
+	}
+
+	if topology != etcdSidecar {
+		return nil
+	}
+	err = checkVersionSkew(cmdOut, "etcd", findContainer(&server.Spec.Template.Spec, "etcd").Image, findContainer(&newServer.Spec.Template.Spec, "etcd").Image)
+	if err != nil {
+		return err
+	}
+	// The etcd data survives a restart of the pod only on a volume claim.
+	for _, volume := range server.Spec.Template.Spec.Volumes {
+		if volume.PersistentVolumeClaim != nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("the etcd of the federation API server does not use persistent storage, upgrading would lose the federation's state")
+}
+
+// checkVersionSkew verifies that the versions in the image tags allow an
+// upgrade from one image to the other. Downgrades, major version changes,
+// that need a migration of the stored data, and skipping minor versions are
+// rejected. Images without a version tag can not be checked.
+func checkVersionSkew(cmdOut io.Writer, component, fromImage, toImage string) error {
+	if fromImage == toImage {
+		return nil
+	}
+	from, err := imageVersion(fromImage)
+	if err != nil {
+		fmt.Fprintf(cmdOut, "\nWarning: skipping the version skew check of %s: %v\n", component, err)
+		return nil
+	}
+	to, err := imageVersion(toImage)
+	if err != nil {
+		fmt.Fprintf(cmdOut, "\nWarning: skipping the version skew check of %s: %v\n", component, err)
+		return nil
+	}
+
+	switch {
+	case to.LessThan(from):
+		return fmt.Errorf("can not downgrade %s from %s to %s", component, from, to)
+	case to.Major() != from.Major():
+		return fmt.Errorf("can not upgrade %s from %s to %s, the major version changes", component, from, to)
+	case to.Minor() > from.Minor()+1:
+		return fmt.Errorf("can not upgrade %s from %s to %s, upgrade one minor version at a time", component, from, to)
+	}
+	return nil
+}
+
+// imageVersion returns the version in the tag of the given image.
+func imageVersion(image string) (*version.Version, error) {
+	if strings.Contains(image, "@") {
+		return nil, fmt.Errorf("image %s is referenced by digest", image)
+	}
+	i := strings.LastIndex(image, ":")
+	if i < 0 || strings.Contains(image[i:], "/") {
+		return nil, fmt.Errorf("image %s has no tag", image)
+	}
+	return version.ParseGeneric(image[i+1:])
+}
+
+// backupEtcd takes a snapshot of the etcd data of the federation and returns
+// its path, empty if no snapshot was taken. The snapshot is written next to
+// the data, on the volume of the etcd member.
+func backupEtcd(cmdOut io.Writer, config *restclient.Config, clientset client.Interface, namespace string, topology etcdTopology) (string, error) {
+	var podName string
+	switch topology {
+	case etcdExternal:
+		_, err := fmt.Fprintln(cmdOut, " skipped, the federation uses external etcd servers")
+		return "", err
+	case etcdStatefulSet:
+		podName = fmt.Sprintf("%s-0", EtcdNameSuffix)
+	case etcdSidecar:
+		pods, err := clientset.Core().Pods(namespace).List(metav1.ListOptions{LabelSelector: labels.SelectorFromSet(apiserverPodLabels).String()})
+		if err != nil {
+			return "", err
+		}
+		for _, pod := range pods.Items {
+			if pod.Status.Phase == api.PodRunning {
+				podName = pod.Name
+				break
+			}
+		}
+		if podName == "" {
+			return "", fmt.Errorf("no running federation API server pod")
+		}
+	}
+
+	snapshot := fmt.Sprintf("%s/snapshot-%s.db", etcdBackupDir, time.Now().UTC().Format("20060102150405"))
+	command := []string{
+		"/bin/sh",
+		"-c",
+		fmt.Sprintf("mkdir -p %s && ETCDCTL_API=3 /usr/local/bin/etcdctl --endpoints=http://127.0.0.1:%d snapshot save %s", etcdBackupDir, etcdClientPort, snapshot),
+	}
+	var stderr bytes.Buffer
+	err := execInContainer(config, clientset, namespace, podName, "etcd", command, &stderr)
+	if err != nil {
+		return "", fmt.Errorf("%v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	glog.V(4).Infof("Saved etcd snapshot %s in pod %s", snapshot, podName)
+	_, err = fmt.Fprintf(cmdOut, " done, saved %s in pod %s\n", snapshot, podName)
+	return snapshot, err
+}
+
+// etcdImageChanged returns whether the etcd running in the pods of the
+// federation API server is upgraded to another image.
+func etcdImageChanged(server, newServer *extensions.Deployment) bool {
+	etcd := findContainer(&server.Spec.Template.Spec, "etcd")
+	newEtcd := findContainer(&newServer.Spec.Template.Spec, "etcd")
+	return etcd != nil && newEtcd != nil && etcd.Image != newEtcd.Image
+}
+
+// etcdRollbackError returns the error of an upgrade whose federation API
+// server deployment was not rolled back because its etcd was upgraded,
+// telling how to restore the etcd data taken before the upgrade.
+func etcdRollbackError(cause error, serverName, snapshot string) error {
+	restore := "no etcd snapshot was taken before the upgrade"
+	if snapshot != "" {
+		restore = fmt.Sprintf("restore the etcd snapshot %s from the etcd data volume with 'etcdctl snapshot restore' before restoring the previous images of %s", snapshot, serverName)
+	}
+	return fmt.Errorf("error upgrading federation control plane: %v, %s was not rolled back since its etcd was upgraded and the previous etcd may not read the upgraded data: %s", cause, serverName, restore)
+}
+
+func execInContainer(config *restclient.Config, clientset client.Interface, namespace, podName, containerName string, command []string, stderr io.Writer) error {
+	req := clientset.Core().RESTClient().Post().
+		Resource("pods").
+		Name(podName).
+		Namespace(namespace).
+		SubResource("exec").
+		Param("container", containerName)
+	req.VersionedParams(&api.PodExecOptions{
+		Container: containerName,
+		Command:   command,
+		Stdout:    true,
+		Stderr:    true,
+	}, legacyscheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(config, "POST", req.URL())
+	if err != nil {
+		return err
+	}
+	var stdout bytes.Buffer
+	err = executor.Stream(remotecommand.StreamOptions{
+		Stdout: &stdout,
+		Stderr: stderr,
+	})
+	glog.V(4).Infof("Output of %v in %s/%s: %s", command, podName, containerName, stdout.String())
+	return err
+}
+
+// waitForRollout waits until the given generation of a deployment is
+// rolled out and all of its replicas are available.
+func waitForRollout(cmdOut io.Writer, clientset client.Interface, namespace, name string, generation int64, timeout time.Duration) error {
+	return poll(timeout, func() (bool, error) {
+		fmt.Fprint(cmdOut, ".")
+		dep, err := clientset.Extensions().Deployments(namespace).Get(name, metav1.GetOptions{})
+		if err != nil {
+			return false, nil
+		}
+		return dep.Status.ObservedGeneration >= generation &&
+			dep.Status.UpdatedReplicas == dep.Spec.Replicas &&
+			dep.Status.AvailableReplicas == dep.Spec.Replicas &&
+			dep.Status.Replicas == dep.Spec.Replicas, nil
+	})
+}
+
+// rollbackDeployment restores the pod template of the given deployment and
+// waits for it to roll out, and then restores its strategy. The pod template
+// is rolled back with the strategy of the upgrade, so that the pods of a
+// federation API server with an etcd sidecar are still recreated rather than
+// run next to each other on the same etcd data.
+func rollbackDeployment(cmdOut io.Writer, clientset client.Interface, namespace string, original *extensions.Deployment, timeout time.Duration) error {
+	dep, err := clientset.Extensions().Deployments(namespace).Get(original.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	dep.Spec.Template = original.Spec.Template
+	dep, err = clientset.Extensions().Deployments(namespace).Update(dep)
+	if err != nil {
+		return err
+	}
+	err = waitForRollout(cmdOut, clientset, namespace, dep.Name, dep.Generation, timeout)
+	if err != nil {
+		return err
+	}
+	if apiequality.Semantic.DeepEqual(dep.Spec.Strategy, original.Spec.Strategy) {
+		return nil
+	}
+
+	// Changing the strategy alone does not roll out new pods.
+	dep, err = clientset.Extensions().Deployments(namespace).Get(original.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	dep.Spec.Strategy = original.Spec.Strategy
+	_, err = clientset.Extensions().Deployments(namespace).Update(dep)
+	return err
+}
+
+// waitCMHealthy waits until all the pods of the given federation controller
+// manager deployment pass their health checks, which are reached through the
+// pod proxy of the host cluster.
+func waitCMHealthy(cmdOut io.Writer, clientset client.Interface, namespace string, dep *extensions.Deployment, timeout time.Duration) error {
+	port := controllerManagerPort
+	container := findContainer(&dep.Spec.Template.Spec, "controller-manager")
+	if container == nil {
+		return fmt.Errorf("deployment %s has no controller-manager container", dep.Name)
+	}
+	for _, arg := range container.Command {
+		if strings.HasPrefix(arg, "--port=") {
+			p, err := strconv.Atoi(strings.TrimPrefix(arg, "--port="))
+			if err != nil {
+				return fmt.Errorf("invalid argument %q of the controller-manager container", arg)
+			}
+			port = p
+		}
+	}
+	selector := labels.SelectorFromSet(dep.Spec.Template.Labels).String()
+
+	return poll(timeout, func() (bool, error) {
+		fmt.Fprint(cmdOut, ".")
+		pods, err := clientset.Core().Pods(namespace).List(metav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			return false, nil
+		}
+		var healthy int32
+		for _, pod := range pods.Items {
+			if pod.DeletionTimestamp != nil {
+				continue
+			}
+			if pod.Status.Phase != api.PodRunning {
+				return false, nil
+			}
+			body, err := clientset.Core().RESTClient().Get().
+				Namespace(namespace).
+				Resource("pods").
+				Name(fmt.Sprintf("%s:%d", pod.Name, port)).
+				SubResource("proxy").
+				Suffix("healthz").
+				Do().
+				Raw()
+			if err != nil || !strings.EqualFold(string(body), "ok") {
+				glog.V(4).Infof("Controller manager pod %s is not healthy yet: %v", pod.Name, err)
+				return false, nil
+			}
+			healthy++
+		}
+		return healthy == dep.Spec.Replicas, nil
+	})
+}
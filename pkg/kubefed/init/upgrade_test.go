@@ -0,0 +1,265 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package init
+
+import (
+	"errors"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	core "k8s.io/client-go/testing"
+	api "k8s.io/kubernetes/pkg/apis/core"
+	"k8s.io/kubernetes/pkg/apis/extensions"
+	"k8s.io/kubernetes/pkg/client/clientset_generated/internalclientset/fake"
+)
+
+func newTestAPIServer(t *testing.T, pvc *api.PersistentVolumeClaim) *extensions.Deployment {
+	dep, err := createAPIServer(nil, "federation-system", APIServerNameSuffix, "myfed", "fcp:v1.9.0", "etcd:3.1.10", "", "apiserver-credentials", "", "", 1, false, false, false, false, nil, pvc, true, nil, "IfNotPresent", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	dep.Generation = 1
+	dep.Status = extensions.DeploymentStatus{ObservedGeneration: 1, Replicas: 1, UpdatedReplicas: 1, AvailableReplicas: 1}
+	return dep
+}
+
+func TestUpgradedDeployment(t *testing.T) {
+	server := newTestAPIServer(t, nil)
+
+	upgraded, err := upgradedDeployment(server, "apiserver", "fcp:v1.10.0", map[string]string{"--v": "4", "--bind-address": "127.0.0.1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	container := findContainer(&upgraded.Spec.Template.Spec, "apiserver")
+	if container.Image != "fcp:v1.10.0" {
+		t.Errorf("expected image fcp:v1.10.0, got %s", container.Image)
+	}
+	expectedCommand := []string{
+		"/fcp",
+		"federation-apiserver",
+		"--bind-address=127.0.0.1",
+		"--client-ca-file=/etc/federation/apiserver/ca.crt",
+		"--enable-admission-plugins=NamespaceLifecycle",
+		"--etcd-servers=http://localhost:2379",
+		"--secure-port=8443",
+		"--tls-cert-file=/etc/federation/apiserver/server.crt",
+		"--tls-private-key-file=/etc/federation/apiserver/server.key",
+		"--v=4",
+	}
+	if !reflect.DeepEqual(container.Command, expectedCommand) {
+		t.Errorf("expected command %v, got %v", expectedCommand, container.Command)
+	}
+	if etcd := findContainer(&upgraded.Spec.Template.Spec, "etcd"); etcd.Image != "etcd:3.1.10" {
+		t.Errorf("expected the etcd image to be unchanged, got %s", etcd.Image)
+	}
+	if findContainer(&server.Spec.Template.Spec, "apiserver").Image != "fcp:v1.9.0" {
+		t.Errorf("expected the original deployment to be unchanged")
+	}
+
+	kept, err := upgradedDeployment(server, "apiserver", "", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if image := findContainer(&kept.Spec.Template.Spec, "apiserver").Image; image != "fcp:v1.9.0" {
+		t.Errorf("expected the deployed image fcp:v1.9.0 to be kept, got %s", image)
+	}
+
+	_, err = upgradedDeployment(server, "controller-manager", "fcp:v1.10.0", nil)
+	if err == nil {
+		t.Errorf("expected an error for a missing container")
+	}
+}
+
+func TestCheckVersionSkew(t *testing.T) {
+	testCases := []struct {
+		from, to    string
+		expectedErr string
+	}{
+		{from: "fcp:v1.9.0", to: "fcp:v1.9.0"},
+		{from: "fcp:v1.9.0", to: "fcp:v1.9.3"},
+		{from: "fcp:v1.9.3", to: "registry:5000/fcp:v1.10.0"},
+		{from: "etcd:3.1.10", to: "etcd:3.2.14"},
+		// Versions that can not be parsed are not checked.
+		{from: "fcp:latest", to: "fcp:v1.10.0"},
+		{from: "registry:5000/fcp", to: "fcp:v1.10.0"},
+		{from: "fcp@sha256:0123", to: "fcp:v1.10.0"},
+		{
+			from:        "fcp:v1.10.0",
+			to:          "fcp:v1.9.3",
+			expectedErr: "can not downgrade component from 1.10.0 to 1.9.3",
+		},
+		{
+			from:        "fcp:v1.8.0",
+			to:          "fcp:v1.10.0",
+			expectedErr: "can not upgrade component from 1.8.0 to 1.10.0, upgrade one minor version at a time",
+		},
+		{
+			from:        "etcd:2.2.1",
+			to:          "etcd:3.0.17",
+			expectedErr: "can not upgrade component from 2.2.1 to 3.0.17, the major version changes",
+		},
+	}
+
+	for i, tc := range testCases {
+		err := checkVersionSkew(ioutil.Discard, "component", tc.from, tc.to)
+		if tc.expectedErr == "" && err != nil {
+			t.Errorf("[%d] unexpected error: %v", i, err)
+		}
+		if tc.expectedErr != "" && (err == nil || err.Error() != tc.expectedErr) {
+			t.Errorf("[%d] expected error %q, got: %v", i, tc.expectedErr, err)
+		}
+	}
+}
+
+func TestPreflightChecks(t *testing.T) {
+	pvc := &api.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "apiserver-etcd-claim"}}
+	cm := &extensions.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: CMNameSuffix, Generation: 1},
+		Spec: extensions.DeploymentSpec{
+			Replicas: 1,
+			Template: api.PodTemplateSpec{
+				Spec: api.PodSpec{
+					Containers: []api.Container{
+						{
+							Name:    "controller-manager",
+							Image:   "fcp:v1.9.0",
+							Command: []string{"/fcp", "federation-controller-manager", "--federation-name=myfed"},
+						},
+					},
+				},
+			},
+		},
+		Status: extensions.DeploymentStatus{ObservedGeneration: 1, Replicas: 1, UpdatedReplicas: 1, AvailableReplicas: 1},
+	}
+	unavailable := cm.DeepCopy()
+	unavailable.Status.AvailableReplicas = 0
+
+	testCases := []struct {
+		server      *extensions.Deployment
+		cm          *extensions.Deployment
+		expectedErr string
+	}{
+		{
+			server: newTestAPIServer(t, pvc),
+			cm:     cm,
+		},
+		{
+			server:      newTestAPIServer(t, nil),
+			cm:          cm,
+			expectedErr: "the etcd of the federation API server does not use persistent storage, upgrading would lose the federation's state",
+		},
+		{
+			server:      newTestAPIServer(t, pvc),
+			cm:          unavailable,
+			expectedErr: "deployment controller-manager is not fully available, 0 of 1 replicas are available",
+		},
+	}
+
+	for i, tc := range testCases {
+		newServer, err := upgradedDeployment(tc.server, "apiserver", "fcp:v1.10.0", nil)
+		if err != nil {
+			t.Fatalf("[%d] unexpected error: %v", i, err)
+		}
+		newCM, err := upgradedDeployment(tc.cm, "controller-manager", "fcp:v1.10.0", nil)
+		if err != nil {
+			t.Fatalf("[%d] unexpected error: %v", i, err)
+		}
+
+		err = preflightChecks(ioutil.Discard, tc.server, newServer, tc.cm, newCM, etcdSidecar)
+		if tc.expectedErr == "" && err != nil {
+			t.Errorf("[%d] unexpected error: %v", i, err)
+		}
+		if tc.expectedErr != "" && (err == nil || err.Error() != tc.expectedErr) {
+			t.Errorf("[%d] expected error %q, got: %v", i, tc.expectedErr, err)
+		}
+	}
+}
+
+func TestEtcdImageChanged(t *testing.T) {
+	server := newTestAPIServer(t, nil)
+
+	upgraded, err := upgradedDeployment(server, "apiserver", "fcp:v1.10.0", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if etcdImageChanged(server, upgraded) {
+		t.Errorf("expected the etcd image to be unchanged")
+	}
+	findContainer(&upgraded.Spec.Template.Spec, "etcd").Image = "etcd:3.2.14"
+	if !etcdImageChanged(server, upgraded) {
+		t.Errorf("expected the etcd image to be changed")
+	}
+}
+
+func TestEtcdRollbackError(t *testing.T) {
+	cause := errors.New("federation API server is not healthy")
+	tests := []struct {
+		snapshot string
+		expected string
+	}{
+		{
+			"/var/etcd/backup/snapshot-20180301120000.db",
+			"restore the etcd snapshot /var/etcd/backup/snapshot-20180301120000.db from the etcd data volume",
+		},
+		{"", "no etcd snapshot was taken before the upgrade"},
+	}
+	for _, test := range tests {
+		err := etcdRollbackError(cause, APIServerNameSuffix, test.snapshot)
+		if !strings.Contains(err.Error(), test.expected) || !strings.Contains(err.Error(), cause.Error()) {
+			t.Errorf("expected an error mentioning %q and the cause, got %v", test.expected, err)
+		}
+	}
+}
+
+func TestRollbackDeployment(t *testing.T) {
+	original := newTestAPIServer(t, nil)
+	original.Spec.Strategy = extensions.DeploymentStrategy{Type: extensions.RollingUpdateDeploymentStrategyType}
+	upgraded, err := upgradedDeployment(original, "apiserver", "fcp:v1.10.0", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	upgraded.Spec.Strategy = extensions.DeploymentStrategy{Type: extensions.RecreateDeploymentStrategyType}
+
+	clientset := fake.NewSimpleClientset(upgraded)
+	err = rollbackDeployment(ioutil.Discard, clientset, "federation-system", original, time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var updates []*extensions.Deployment
+	for _, action := range clientset.Actions() {
+		if action.GetVerb() == "update" {
+			updates = append(updates, action.(core.UpdateAction).GetObject().(*extensions.Deployment))
+		}
+	}
+	if len(updates) != 2 {
+		t.Fatalf("expected 2 updates, got %d", len(updates))
+	}
+	if image := findContainer(&updates[0].Spec.Template.Spec, "apiserver").Image; image != "fcp:v1.9.0" {
+		t.Errorf("expected the pod template to be rolled back to fcp:v1.9.0, got %s", image)
+	}
+	if updates[0].Spec.Strategy.Type != extensions.RecreateDeploymentStrategyType {
+		t.Errorf("expected the pod template to be rolled back with the Recreate strategy, got %s", updates[0].Spec.Strategy.Type)
+	}
+	if updates[1].Spec.Strategy.Type != extensions.RollingUpdateDeploymentStrategyType {
+		t.Errorf("expected the strategy to be restored, got %s", updates[1].Spec.Strategy.Type)
+	}
+}
@@ -27,6 +27,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/apiserver/pkg/storage/names"
+	"k8s.io/client-go/tools/clientcmd"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 	"k8s.io/federation/apis/federation"
 	"k8s.io/federation/pkg/kubefed/util"
@@ -60,7 +61,13 @@ var (
 		Join adds a cluster to a federation.
 
         Current context is assumed to be a federation API
-        server. Please use the --context flag otherwise.`)
+        server. Please use the --context flag otherwise.
+
+        With --output=yaml the objects are printed instead of
+        being created, grouped by the cluster they have to be
+        applied to. The credentials of the cluster are then taken
+        from the kubeconfig instead of a service account, whose
+        token is only known once it is created.`)
 	join_example = templates.Examples(`
 		# Join a cluster to a federation by specifying the
 		# cluster name and the context name of the federation
@@ -80,6 +87,7 @@ type joinFederationOptions struct {
 	clusterContext string
 	secretName     string
 	dryRun         bool
+	render         bool
 }
 
 func (o *joinFederationOptions) Bind(flags *pflag.FlagSet) {
@@ -124,6 +132,7 @@ func (j *joinFederation) Complete(cmd *cobra.Command, args []string, config util
 	}
 
 	j.options.dryRun = cmdutil.GetDryRunFlag(cmd)
+	j.options.render = cmdutil.GetFlagString(cmd, "output") == util.OutputFormatYAML
 
 	if j.options.clusterContext == "" {
 		j.options.clusterContext = j.commonOptions.Name
@@ -180,7 +189,8 @@ func (j *joinFederation) joningClusterFactory(config util.AdminConfig) cmdutil.F
 // Run is the implementation of the `join federation` command.
 func (j *joinFederation) Run(f cmdutil.Factory, cmdOut io.Writer, config util.AdminConfig, cmd *cobra.Command) error {
 	clusterContext := j.options.clusterContext
-	dryRun := j.options.dryRun
+	// Nothing is created when the objects are rendered.
+	dryRun := j.options.dryRun || j.options.render
 	federationNamespace := j.commonOptions.FederationSystemNamespace
 	host := j.commonOptions.Host
 	kubeconfig := j.commonOptions.Kubeconfig
@@ -211,7 +221,7 @@ func (j *joinFederation) Run(f cmdutil.Factory, cmdOut io.Writer, config util.Ad
 	}
 
 	glog.V(2).Info("Creating federation system namespace in joining cluster")
-	_, err = createFederationSystemNamespace(joiningClusterClientset, federationNamespace, federationName, joiningClusterName, dryRun)
+	federationNS, err := createFederationSystemNamespace(joiningClusterClientset, federationNamespace, federationName, joiningClusterName, dryRun)
 	if err != nil {
 		glog.V(2).Infof("Error creating federation system namespace in joining cluster: %v", err)
 		return err
@@ -228,14 +238,15 @@ func (j *joinFederation) Run(f cmdutil.Factory, cmdOut io.Writer, config util.Ad
 
 	serviceAccountName := ""
 	clusterRoleName := ""
+	var secret runtime.Object
 	// Check for RBAC in the joining cluster. If it supports RBAC, then create
-	// a service account and use its credentials; otherwise, use the credentials
-	// from the local kubeconfig.
+	// a service account and use its credentials; otherwise, or when the objects
+	// are rendered, use the credentials from the local kubeconfig.
 	glog.V(2).Info("Creating cluster credentials secret")
 
 	useRBAC := false
 	var rbacClientset client.Interface
-	if j.commonOptions.CredentialsKubeconfig == "" {
+	if j.commonOptions.CredentialsKubeconfig == "" && !j.options.render {
 		rbacClientset, err = util.GetVersionedClientForRBACOrFail(joiningClusterFactory)
 		if err != nil {
 			if _, ok := err.(*util.NoRBACAPIError); !ok {
@@ -268,7 +279,7 @@ func (j *joinFederation) Run(f cmdutil.Factory, cmdOut io.Writer, config util.Ad
 		//    don't have to print the created secret in the default case.
 		// Having said that, secret generation machinery could be altered to
 		// suit our needs, but it is far less invasive and readable this way.
-		_, err = createSecret(hostClientset, clientConfig, federationNamespace, federationName, joiningClusterName, clusterContext, secretName, dryRun)
+		secret, err = createSecret(hostClientset, clientConfig, po.LoadingRules, federationNamespace, federationName, joiningClusterName, clusterContext, secretName, dryRun)
 		if err != nil {
 			glog.V(2).Infof("Failed creating the cluster credentials secret: %v", err)
 			return err
@@ -284,6 +295,10 @@ func (j *joinFederation) Run(f cmdutil.Factory, cmdOut io.Writer, config util.Ad
 	}
 	glog.V(2).Info("Created a generator for the cluster API object")
 
+	if j.options.render {
+		return j.render(cmdOut, hostClientset, config, federationName, generator, federationNS, secret)
+	}
+
 	glog.V(2).Info("Running create cluster command against the federation API server")
 	err = kubectlcmd.RunCreateSubcommand(f, cmd, cmdOut, &kubectlcmd.CreateSubcommandOptions{
 		Name:                joiningClusterName,
@@ -311,6 +326,29 @@ func (j *joinFederation) Run(f cmdutil.Factory, cmdOut io.Writer, config util.Ad
 	return err
 }
 
+// render writes the objects that join creates as manifests, grouped by the
+// cluster they have to be applied to.
+func (j *joinFederation) render(cmdOut io.Writer, hostClientset client.Interface, config util.AdminConfig, federationName string, generator kubectl.StructuredGenerator, federationNS *api.Namespace, secret runtime.Object) error {
+	cluster, err := generator.StructuredGenerate()
+	if err != nil {
+		return err
+	}
+	configMap, err := createConfigMap(hostClientset, config, j.commonOptions.FederationSystemNamespace, federationName, j.commonOptions.Name, j.options.clusterContext, j.commonOptions.Kubeconfig, true)
+	if err != nil {
+		return err
+	}
+
+	err = util.WriteManifests(cmdOut, fmt.Sprintf("Apply to the joining cluster %s, context %s.", j.commonOptions.Name, j.options.clusterContext), federationNS, configMap)
+	if err != nil {
+		return err
+	}
+	err = util.WriteManifests(cmdOut, fmt.Sprintf("Apply to the host cluster, context %s.", j.commonOptions.Host), secret)
+	if err != nil {
+		return err
+	}
+	return util.WriteManifests(cmdOut, fmt.Sprintf("Apply to the federation %s.", federationName), cluster)
+}
+
 // minifyConfig is a wrapper around `clientcmdapi.MinifyConfig()` that
 // sets the current context to the given context before calling
 // `clientcmdapi.MinifyConfig()`.
@@ -329,8 +367,9 @@ func minifyConfig(clientConfig *clientcmdapi.Config, context string) (*clientcmd
 }
 
 // createSecret extracts the kubeconfig for a given cluster and populates
-// a secret with that kubeconfig.
-func createSecret(clientset internalclientset.Interface, clientConfig *clientcmdapi.Config, namespace, federationName, joiningClusterName, contextName, secretName string, dryRun bool) (runtime.Object, error) {
+// a secret with that kubeconfig. Exec credential plugins of the kubeconfig
+// loaded with the loading rules are kept.
+func createSecret(clientset internalclientset.Interface, clientConfig *clientcmdapi.Config, loadingRules *clientcmd.ClientConfigLoadingRules, namespace, federationName, joiningClusterName, contextName, secretName string, dryRun bool) (runtime.Object, error) {
 	// Minify the kubeconfig to ensure that there is only information
 	// relevant to the cluster we are registering.
 	newClientConfig, err := minifyConfig(clientConfig, contextName)
@@ -347,7 +386,12 @@ func createSecret(clientset internalclientset.Interface, clientConfig *clientcmd
 		return nil, err
 	}
 
-	return util.CreateKubeconfigSecret(clientset, newClientConfig, namespace, secretName, federationName, joiningClusterName, dryRun)
+	configBytes, err := util.WriteKubeconfig(newClientConfig, loadingRules, contextName)
+	if err != nil {
+		glog.V(2).Infof("Failed to serialize the kubeconfig for the given context %q: %v", contextName, err)
+		return nil, err
+	}
+	return util.CreateKubeconfigDataSecret(clientset, configBytes, namespace, secretName, federationName, joiningClusterName, dryRun)
 }
 
 // createConfigMap creates a configmap with name kube-dns in the joining cluster
@@ -64,10 +64,26 @@ func NewKubeFedCommand(f cmdutil.Factory, in io.Reader, out, err io.Writer, defa
 			Message: "Basic Commands:",
 			Commands: []*cobra.Command{
 				kubefedinit.NewCmdInit(out, util.NewAdminConfig(clientcmd.NewDefaultPathOptions()), defaultServerImage, defaultEtcdImage),
+				kubefedinit.NewCmdUpgrade(out, util.NewAdminConfig(clientcmd.NewDefaultPathOptions())),
 				NewCmdJoin(f, out, util.NewAdminConfig(clientcmd.NewDefaultPathOptions())),
 				NewCmdUnjoin(f, out, err, util.NewAdminConfig(clientcmd.NewDefaultPathOptions())),
 			},
 		},
+		{
+			Message: "Cluster Management Commands:",
+			Commands: []*cobra.Command{
+				NewCmdRotateCredentials(f, out, util.NewAdminConfig(clientcmd.NewDefaultPathOptions())),
+				NewCmdCordon(f, out),
+				NewCmdDrain(f, out),
+				NewCmdUncordon(f, out),
+			},
+		},
+		{
+			Message: "Scheduling Commands:",
+			Commands: []*cobra.Command{
+				NewCmdPlan(out),
+			},
+		},
 	}
 	groups.Add(cmds)
 
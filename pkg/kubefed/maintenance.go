@@ -0,0 +1,154 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubefed
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"k8s.io/apimachinery/pkg/types"
+	federationapi "k8s.io/federation/apis/federation/v1beta1"
+	kubectlcmd "k8s.io/kubernetes/pkg/kubectl/cmd"
+	"k8s.io/kubernetes/pkg/kubectl/cmd/templates"
+	cmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	cordon_long = templates.LongDesc(`
+		Cordon a member cluster.
+
+        No new federated objects or replicas are placed in a cordoned
+        cluster. The objects and replicas it runs are kept, and the cluster
+        stays joined to the federation.
+
+        Current context is assumed to be a federation API
+        server. Please use the --context flag otherwise.`)
+	cordon_example = templates.Examples(`
+		# Stop placing new objects and replicas in cluster foo.
+		kubefed cordon foo`)
+
+	drain_long = templates.LongDesc(`
+		Drain a member cluster.
+
+        A drained cluster is cordoned, and the replicas of federated
+        replica sets, deployments and jobs are moved to the other
+        clusters. Replicas of replica sets and deployments are removed
+        from the drained cluster only as they become ready elsewhere.
+        DNS records of federated services stop pointing to the cluster.
+        The federated objects are kept in the cluster with no replicas,
+        and the cluster stays joined to the federation.
+
+        Current context is assumed to be a federation API
+        server. Please use the --context flag otherwise.`)
+	drain_example = templates.Examples(`
+		# Move the replicas of federated workloads out of cluster foo,
+		# e.g. before it is upgraded.
+		kubefed drain foo`)
+
+	uncordon_long = templates.LongDesc(`
+		Uncordon a cordoned or drained member cluster.
+
+        Federated objects and replicas are placed in the cluster again.
+
+        Current context is assumed to be a federation API
+        server. Please use the --context flag otherwise.`)
+	uncordon_example = templates.Examples(`
+		# Place objects and replicas in cluster foo again.
+		kubefed uncordon foo`)
+)
+
+// NewCmdCordon defines the `cordon` command that stops placing new objects
+// and replicas in a member cluster.
+func NewCmdCordon(f cmdutil.Factory, cmdOut io.Writer) *cobra.Command {
+	return newCmdMaintenance(f, cmdOut, "cordon", "Stop placing new objects and replicas in a member cluster", cordon_long, cordon_example, federationapi.ClusterCordoned)
+}
+
+// NewCmdDrain defines the `drain` command that moves the replicas of
+// federated workloads out of a member cluster.
+func NewCmdDrain(f cmdutil.Factory, cmdOut io.Writer) *cobra.Command {
+	return newCmdMaintenance(f, cmdOut, "drain", "Move the replicas of federated workloads out of a member cluster", drain_long, drain_example, federationapi.ClusterDrained)
+}
+
+// NewCmdUncordon defines the `uncordon` command that takes a member cluster
+// out of maintenance.
+func NewCmdUncordon(f cmdutil.Factory, cmdOut io.Writer) *cobra.Command {
+	return newCmdMaintenance(f, cmdOut, "uncordon", "Place objects and replicas in a member cluster again", uncordon_long, uncordon_example, "")
+}
+
+func newCmdMaintenance(f cmdutil.Factory, cmdOut io.Writer, use, short, long, example string, maintenance federationapi.ClusterMaintenance) *cobra.Command {
+	return &cobra.Command{
+		Use:     use + " CLUSTER_NAME",
+		Short:   short,
+		Long:    long,
+		Example: example,
+		Run: func(cmd *cobra.Command, args []string) {
+			name, err := kubectlcmd.NameFromCommandArgs(cmd, args)
+			cmdutil.CheckErr(err)
+			cmdutil.CheckErr(setClusterMaintenance(f, cmdOut, name, maintenance))
+		},
+	}
+}
+
+// setClusterMaintenance sets the maintenance mode of the named cluster.
+func setClusterMaintenance(f cmdutil.Factory, cmdOut io.Writer, name string, maintenance federationapi.ClusterMaintenance) error {
+	cluster, rh, err := getCluster(f, name)
+	if err != nil {
+		return err
+	}
+	if cluster == nil {
+		return fmt.Errorf("cluster %q not found in federation", name)
+	}
+	if cluster.Spec.Maintenance == maintenance {
+		fmt.Fprintf(cmdOut, "Cluster %q is already %s\n", name, maintenanceDescription(maintenance))
+		return nil
+	}
+
+	patch, err := maintenancePatch(maintenance)
+	if err != nil {
+		return err
+	}
+	if _, err := rh.Patch("", name, types.MergePatchType, patch); err != nil {
+		return fmt.Errorf("failed to update the maintenance mode of cluster %q: %v", name, err)
+	}
+	fmt.Fprintf(cmdOut, "Cluster %q %s\n", name, maintenanceDescription(maintenance))
+	return nil
+}
+
+// maintenancePatch returns the merge patch setting the maintenance mode of a
+// cluster. An empty mode removes the field.
+func maintenancePatch(maintenance federationapi.ClusterMaintenance) ([]byte, error) {
+	var value interface{}
+	if maintenance != "" {
+		value = maintenance
+	}
+	return json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{"maintenance": value},
+	})
+}
+
+func maintenanceDescription(maintenance federationapi.ClusterMaintenance) string {
+	switch maintenance {
+	case federationapi.ClusterCordoned:
+		return "cordoned"
+	case federationapi.ClusterDrained:
+		return "drained"
+	}
+	return "uncordoned"
+}
@@ -0,0 +1,40 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubefed
+
+import (
+	"testing"
+
+	federationapi "k8s.io/federation/apis/federation/v1beta1"
+)
+
+func TestMaintenancePatch(t *testing.T) {
+	tests := map[federationapi.ClusterMaintenance]string{
+		federationapi.ClusterCordoned: `{"spec":{"maintenance":"Cordoned"}}`,
+		federationapi.ClusterDrained:  `{"spec":{"maintenance":"Drained"}}`,
+		"":                            `{"spec":{"maintenance":null}}`,
+	}
+	for maintenance, expected := range tests {
+		patch, err := maintenancePatch(maintenance)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if string(patch) != expected {
+			t.Errorf("expected patch %s for %q, got %s", expected, maintenance, patch)
+		}
+	}
+}
@@ -0,0 +1,320 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubefed
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	extensionsv1 "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/cache"
+	federationapi "k8s.io/federation/apis/federation/v1beta1"
+	"k8s.io/federation/pkg/federatedtypes"
+	synccontroller "k8s.io/federation/pkg/federation-controller/sync"
+	"k8s.io/federation/pkg/federation-controller/util/scheduler"
+	"k8s.io/kubernetes/pkg/kubectl/cmd/templates"
+	cmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+	"k8s.io/kubernetes/pkg/printers"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var (
+	plan_long = templates.LongDesc(`
+		Simulate how federated workloads are placed in the member clusters.
+
+        The placement is computed offline, from a snapshot of the clusters
+        and the current state of the workloads in them, with the same
+        scheduling code the federation controller manager runs. Nothing is
+        read from or written to a federation.
+
+        Propagation policies and cluster sets given with the workloads are
+        applied to them as in the federation.`)
+	plan_example = templates.Examples(`
+		# Show how the replicas of the replica sets and deployments in
+		# frontend.yaml would be distributed among the clusters described
+		# in clusters.yaml.
+		kubefed plan --clusters=clusters.yaml -f frontend.yaml
+
+		# A cluster snapshot looks like this.
+		clusters:
+		- name: us-east
+		  labels: {provider: aws}
+		  region: us-east-1
+		  zones: [us-east-1a, us-east-1b]
+		  workloads:
+		    default/frontend: {replicas: 6, readyReplicas: 4, unschedulableReplicas: 2}
+		- name: europe
+		  region: europe-west1`)
+)
+
+// planClusters is the content of the cluster snapshot file.
+type planClusters struct {
+	Clusters []planCluster `json:"clusters"`
+}
+
+// planCluster is the state of a member cluster as seen by the scheduler.
+type planCluster struct {
+	Name        string            `json:"name"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	Region      string            `json:"region,omitempty"`
+	Zones       []string          `json:"zones,omitempty"`
+	// Workloads maps the namespace/name of federated workloads to their
+	// state in the cluster.
+	Workloads map[string]federatedtypes.ClusterReplicaSnapshot `json:"workloads,omitempty"`
+}
+
+// planCodecs decode the workloads, propagation policies and cluster sets to plan.
+var planCodecs = func() serializer.CodecFactory {
+	planScheme := runtime.NewScheme()
+	scheme.AddToScheme(planScheme)
+	federationapi.AddToScheme(planScheme)
+	return serializer.NewCodecFactory(planScheme)
+}()
+
+type planOptions struct {
+	clustersFile    string
+	filenames       []string
+	schedulerConfig string
+}
+
+func (o *planOptions) Bind(flags *pflag.FlagSet) {
+	flags.StringVar(&o.clustersFile, "clusters", "", "File with the snapshot of the member clusters.")
+	flags.StringSliceVarP(&o.filenames, "filename", "f", nil, "Files with the federated replica sets and deployments to place, and the propagation policies and cluster sets of the federation.")
+	flags.StringVar(&o.schedulerConfig, "scheduler-config", "", "Scheduler configuration of the federation controller manager, if any.")
+}
+
+// NewCmdPlan defines the `plan` command that simulates the placement of
+// federated workloads.
+func NewCmdPlan(cmdOut io.Writer) *cobra.Command {
+	opts := &planOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "plan --clusters=FILE -f FILENAME",
+		Short:   "Simulate the placement of federated workloads",
+		Long:    plan_long,
+		Example: plan_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(opts.Run(cmdOut))
+		},
+	}
+
+	opts.Bind(cmd.Flags())
+	return cmd
+}
+
+// Run is the implementation of the `plan` command.
+func (o *planOptions) Run(cmdOut io.Writer) error {
+	if o.clustersFile == "" {
+		return fmt.Errorf("--clusters is required")
+	}
+	if len(o.filenames) == 0 {
+		return fmt.Errorf("at least one workload file must be given with --filename")
+	}
+
+	snapshot := &planClusters{}
+	if err := decodeFile(o.clustersFile, func(decoder *yaml.YAMLOrJSONDecoder) error {
+		return decoder.Decode(snapshot)
+	}); err != nil {
+		return err
+	}
+	clusters := make([]*federationapi.Cluster, 0, len(snapshot.Clusters))
+	for _, c := range snapshot.Clusters {
+		clusters = append(clusters, &federationapi.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        c.Name,
+				Labels:      c.Labels,
+				Annotations: c.Annotations,
+			},
+			Status: federationapi.ClusterStatus{
+				Region: c.Region,
+				Zones:  c.Zones,
+			},
+		})
+	}
+
+	framework, err := scheduler.NewFrameworkFromFile(o.schedulerConfig)
+	if err != nil {
+		return err
+	}
+	adapterSpecificArgs := map[string]interface{}{
+		federatedtypes.ReplicaSetKind: framework,
+		federatedtypes.DeploymentKind: framework,
+	}
+	replicaSetAdapter := federatedtypes.NewReplicaSetAdapter(nil, nil, adapterSpecificArgs)
+	deploymentAdapter := federatedtypes.NewDeploymentAdapter(nil, nil, adapterSpecificArgs)
+
+	type workload struct {
+		filename string
+		obj      runtime.Object
+	}
+	var workloads []workload
+	policies := cache.NewStore(cache.MetaNamespaceKeyFunc)
+	clusterSets := cache.NewStore(cache.MetaNamespaceKeyFunc)
+	for _, filename := range o.filenames {
+		err := decodeFile(filename, func(decoder *yaml.YAMLOrJSONDecoder) error {
+			for {
+				raw := runtime.RawExtension{}
+				if err := decoder.Decode(&raw); err == io.EOF {
+					return nil
+				} else if err != nil {
+					return err
+				}
+				if len(raw.Raw) == 0 {
+					continue
+				}
+				obj, _, err := planCodecs.UniversalDeserializer().Decode(raw.Raw, nil, nil)
+				if err != nil {
+					return err
+				}
+				switch obj := obj.(type) {
+				case *federationapi.PropagationPolicy:
+					err = policies.Add(obj)
+				case *federationapi.ClusterSet:
+					err = clusterSets.Add(obj)
+				default:
+					workloads = append(workloads, workload{filename: filename, obj: obj})
+				}
+				if err != nil {
+					return err
+				}
+			}
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, w := range workloads {
+		var adapter federatedtypes.FederatedTypeAdapter
+		switch w.obj.(type) {
+		case *extensionsv1.ReplicaSet:
+			adapter = replicaSetAdapter
+		case *extensionsv1.Deployment:
+			adapter = deploymentAdapter
+		default:
+			return fmt.Errorf("%s: unsupported object %v, only extensions/v1beta1 replica sets and deployments can be planned",
+				w.filename, w.obj.GetObjectKind().GroupVersionKind())
+		}
+		if err := planWorkload(cmdOut, adapter, w.obj, clusters, snapshot, policies, clusterSets); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// planWorkload prints the simulated schedule of a single workload in the
+// clusters the sync controller would select for it.
+func planWorkload(cmdOut io.Writer, adapter federatedtypes.FederatedTypeAdapter, obj runtime.Object, clusters []*federationapi.Cluster, snapshot *planClusters, policies, clusterSets cache.Store) error {
+	objMeta := adapter.ObjectMeta(obj)
+	if objMeta.Namespace == "" {
+		objMeta.Namespace = metav1.NamespaceDefault
+	}
+	key := adapter.QualifiedName(obj).String()
+
+	snapshots := make(map[string]federatedtypes.ClusterReplicaSnapshot)
+	for _, c := range snapshot.Clusters {
+		if workload, found := c.Workloads[key]; found {
+			snapshots[c.Name] = workload
+		}
+	}
+
+	simulator, ok := adapter.(federatedtypes.ScheduleSimulator)
+	if !ok {
+		return fmt.Errorf("scheduling of %s %q can not be simulated", adapter.Kind(), key)
+	}
+	placementObj, err := synccontroller.PlacementObject(adapter, policies, clusterSets, obj, clusters)
+	if err != nil {
+		return fmt.Errorf("failed to plan %s %q: %v", adapter.Kind(), key, err)
+	}
+	selected, _, err := synccontroller.SelectClusters(clusterSets, adapter.ObjectMeta(placementObj), clusters, func(clusterName string) (bool, error) {
+		_, found := snapshots[clusterName]
+		return found, nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to plan %s %q: %v", adapter.Kind(), key, err)
+	}
+	info, err := simulator.SimulateSchedule(placementObj, key, selected, snapshots)
+	if err != nil {
+		return fmt.Errorf("failed to plan %s %q: %v", adapter.Kind(), key, err)
+	}
+	planned := info.ScheduledReplicas()
+	// The workload is removed from the clusters that are not selected.
+	for clusterName := range snapshots {
+		if _, found := planned[clusterName]; !found {
+			planned[clusterName] = 0
+		}
+	}
+
+	var clusterNames []string
+	for clusterName := range planned {
+		clusterNames = append(clusterNames, clusterName)
+	}
+	sort.Strings(clusterNames)
+
+	fmt.Fprintf(cmdOut, "%s %q\n", adapter.Kind(), key)
+	w := printers.GetNewTabWriter(cmdOut)
+	fmt.Fprintln(w, "CLUSTER\tCURRENT\tREADY\tPLANNED\tCHANGE")
+	added, removed := int64(0), int64(0)
+	for _, clusterName := range clusterNames {
+		current := snapshots[clusterName]
+		change := planned[clusterName] - current.Replicas
+		if change > 0 {
+			added += change
+		} else {
+			removed -= change
+		}
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%+d\n", clusterName, current.Replicas, current.ReadyReplicas, planned[clusterName], change)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	moved := added
+	if removed < moved {
+		moved = removed
+	}
+	fmt.Fprintf(cmdOut, "Replicas moved between clusters: %d\n", moved)
+	if info.Pending {
+		fmt.Fprintf(cmdOut, "The rebalance strategy holds back part of the placement, further steps follow.\n")
+	}
+	fmt.Fprintln(cmdOut)
+	return nil
+}
+
+// decodeFile decodes the yaml or json content of the file with the given function.
+func decodeFile(filename string, decode func(decoder *yaml.YAMLOrJSONDecoder) error) error {
+	file, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err := decode(yaml.NewYAMLOrJSONDecoder(file, 4096)); err != nil {
+		return fmt.Errorf("failed to decode %s: %v", filename, err)
+	}
+	return nil
+}
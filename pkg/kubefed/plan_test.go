@@ -0,0 +1,218 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubefed
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const planTestClusters = `
+clusters:
+- name: onprem
+  region: us
+  workloads:
+    default/frontend: {replicas: 6, readyReplicas: 4, unschedulableReplicas: 2}
+- name: cloud
+  region: us
+`
+
+const planTestWorkloads = `
+apiVersion: extensions/v1beta1
+kind: ReplicaSet
+metadata:
+  name: frontend
+  annotations:
+    federation.kubernetes.io/replica-set-preferences: |
+      {"rebalance": true, "clusters": {"onprem": {"weight": 1, "priority": 1}, "cloud": {"weight": 1}}}
+spec:
+  replicas: 6
+  template:
+    metadata:
+      labels:
+        app: frontend
+    spec:
+      containers:
+      - name: frontend
+        image: nginx
+---
+apiVersion: extensions/v1beta1
+kind: Deployment
+metadata:
+  name: backend
+  namespace: prod
+spec:
+  replicas: 4
+  template:
+    metadata:
+      labels:
+        app: backend
+    spec:
+      containers:
+      - name: backend
+        image: nginx
+`
+
+func TestPlan(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kubefed-plan")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	clustersFile := filepath.Join(dir, "clusters.yaml")
+	workloadsFile := filepath.Join(dir, "workloads.yaml")
+	if err := ioutil.WriteFile(clustersFile, []byte(planTestClusters), 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := ioutil.WriteFile(workloadsFile, []byte(planTestWorkloads), 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	buf := bytes.NewBuffer([]byte{})
+	opts := &planOptions{clustersFile: clustersFile, filenames: []string{workloadsFile}}
+	if err := opts.Run(buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := buf.String()
+
+	// The on-prem cluster only runs 4 of its replicas, so 2 more burst to the cloud.
+	for _, want := range []string{
+		"replicaset \"default/frontend\"",
+		"cloud     0         0         2         +2",
+		"onprem    6         4         6         +0",
+		"deployment \"prod/backend\"",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, got)
+		}
+	}
+
+	opts = &planOptions{filenames: []string{workloadsFile}}
+	if err := opts.Run(buf); err == nil {
+		t.Errorf("expected an error without cluster snapshot")
+	}
+}
+
+const planTestPolicyClusters = `
+clusters:
+- name: prod-a
+  labels: {env: prod}
+- name: prod-b
+  labels: {env: prod}
+- name: dev
+  labels: {env: dev}
+  workloads:
+    default/web: {replicas: 2, readyReplicas: 2}
+`
+
+const planTestPolicyWorkloads = `
+apiVersion: federation/v1beta1
+kind: PropagationPolicy
+metadata:
+  name: prod
+spec:
+  resourceSelectors:
+  - kind: ReplicaSet
+  clusterSelector:
+  - {key: env, operator: in, values: [prod]}
+---
+apiVersion: federation/v1beta1
+kind: ClusterSet
+metadata:
+  name: canary
+spec:
+  clusters: [prod-b]
+---
+apiVersion: extensions/v1beta1
+kind: ReplicaSet
+metadata:
+  name: web
+spec:
+  replicas: 4
+  template:
+    metadata:
+      labels:
+        app: web
+    spec:
+      containers:
+      - name: web
+        image: nginx
+---
+apiVersion: extensions/v1beta1
+kind: Deployment
+metadata:
+  name: api
+  annotations:
+    federation.alpha.kubernetes.io/cluster-set: canary
+spec:
+  replicas: 3
+  template:
+    metadata:
+      labels:
+        app: api
+    spec:
+      containers:
+      - name: api
+        image: nginx
+`
+
+// Checks that the workloads are only planned in the clusters selected by their
+// propagation policy and cluster set, as in the sync controller.
+func TestPlanClusterSelection(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kubefed-plan")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	clustersFile := filepath.Join(dir, "clusters.yaml")
+	workloadsFile := filepath.Join(dir, "workloads.yaml")
+	if err := ioutil.WriteFile(clustersFile, []byte(planTestPolicyClusters), 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := ioutil.WriteFile(workloadsFile, []byte(planTestPolicyWorkloads), 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	buf := bytes.NewBuffer([]byte{})
+	opts := &planOptions{clustersFile: clustersFile, filenames: []string{workloadsFile}}
+	if err := opts.Run(buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := buf.String()
+
+	for _, want := range []string{
+		"replicaset \"default/web\"",
+		"dev       2         2         0         -2",
+		"prod-a    0         0         2         +2",
+		"prod-b    0         0         2         +2",
+		"deployment \"default/api\"",
+		"prod-b    0         0         3         +3",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "prod-a    0         0         3") {
+		t.Errorf("expected the deployment to be planned only in the cluster set, got:\n%s", got)
+	}
+}
@@ -0,0 +1,294 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubefed
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apiserver/pkg/storage/names"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/federation/apis/federation"
+	federationapi "k8s.io/federation/apis/federation/v1beta1"
+	"k8s.io/federation/pkg/kubefed/util"
+	api "k8s.io/kubernetes/pkg/apis/core"
+	"k8s.io/kubernetes/pkg/client/clientset_generated/internalclientset"
+	"k8s.io/kubernetes/pkg/kubectl/cmd/templates"
+	cmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+
+	"github.com/golang/glog"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var (
+	rotate_credentials_long = templates.LongDesc(`
+		Rotate the credentials the federation uses to access a member cluster.
+
+        For clusters joined with a service account, a new token is minted
+        for the service account in the member cluster. For other clusters,
+        the credentials are read again from the cluster's context in the
+        local kubeconfig. The secret holding the credentials in the host
+        cluster is updated, and the federation controllers reconnect to
+        the cluster with the new credentials. Previous service account
+        tokens stay valid until they are revoked with --revoke-previous.
+
+        Current context is assumed to be a federation API
+        server. Please use the --context flag otherwise.`)
+	rotate_credentials_example = templates.Examples(`
+		# Mint new credentials for cluster foo, keeping the previous
+		# ones valid while the federation controllers reconnect.
+		kubefed rotate-credentials foo --host-cluster-context=bar
+
+		# Mint new credentials for cluster foo and revoke the previous
+		# service account tokens.
+		kubefed rotate-credentials foo --host-cluster-context=bar --revoke-previous`)
+)
+
+type rotateCredentials struct {
+	commonOptions util.SubcommandOptions
+	options       rotateCredentialsOptions
+}
+
+type rotateCredentialsOptions struct {
+	clusterContext string
+	revokePrevious bool
+}
+
+func (o *rotateCredentialsOptions) Bind(flags *pflag.FlagSet) {
+	flags.StringVar(&o.clusterContext, "cluster-context", "", "Name of the cluster's context in the local kubeconfig. Defaults to cluster name if unspecified.")
+	flags.BoolVar(&o.revokePrevious, "revoke-previous", false, "Revoke the previous service account tokens of the cluster once the new one is in place. Controllers that have not reconnected yet fail until they do.")
+}
+
+// NewCmdRotateCredentials defines the `rotate-credentials` command that
+// replaces the credentials used to access a member cluster.
+func NewCmdRotateCredentials(f cmdutil.Factory, cmdOut io.Writer, config util.AdminConfig) *cobra.Command {
+	opts := &rotateCredentials{}
+
+	cmd := &cobra.Command{
+		Use:     "rotate-credentials CLUSTER_NAME --host-cluster-context=HOST_CONTEXT",
+		Short:   "Rotate the credentials used to access a member cluster",
+		Long:    rotate_credentials_long,
+		Example: rotate_credentials_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(opts.commonOptions.SetName(cmd, args))
+			cmdutil.CheckErr(opts.Run(f, cmdOut, config))
+		},
+	}
+
+	flags := cmd.Flags()
+	opts.commonOptions.Bind(flags)
+	opts.options.Bind(flags)
+
+	return cmd
+}
+
+// Run is the implementation of the `rotate-credentials` command.
+func (r *rotateCredentials) Run(f cmdutil.Factory, cmdOut io.Writer, config util.AdminConfig) error {
+	if r.options.clusterContext == "" {
+		r.options.clusterContext = r.commonOptions.Name
+	}
+	namespace := r.commonOptions.FederationSystemNamespace
+
+	cluster, rh, err := getCluster(f, r.commonOptions.Name)
+	if err != nil {
+		return err
+	}
+	if cluster == nil {
+		return fmt.Errorf("cluster %q not found in federation", r.commonOptions.Name)
+	}
+	if cluster.Spec.SecretRef == nil || cluster.Spec.SecretRef.Name == "" {
+		return fmt.Errorf("cluster %q has no credentials secret to rotate", cluster.Name)
+	}
+
+	hostFactory := config.ClusterFactory(r.commonOptions.Host, r.commonOptions.Kubeconfig)
+	hostClientset, err := hostFactory.ClientSet()
+	if err != nil {
+		return err
+	}
+	secret, err := hostClientset.Core().Secrets(namespace).Get(cluster.Spec.SecretRef.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	saName := cluster.Annotations[ServiceAccountNameAnnotation]
+	var clusterClientset internalclientset.Interface
+	var token *api.Secret
+	if saName != "" {
+		clusterClientset, err = r.clusterClientset(config, secret, cluster)
+		if err != nil {
+			return err
+		}
+		glog.V(2).Infof("Minting a new token for service account %s in cluster %s", saName, cluster.Name)
+		token, err = mintServiceAccountToken(clusterClientset, namespace, saName)
+		if err != nil {
+			return err
+		}
+		secret.Data = token.Data
+	} else {
+		glog.V(2).Infof("Reading the credentials of cluster %s from context %s", cluster.Name, r.options.clusterContext)
+		data, err := r.kubeconfigCredentials(config)
+		if err != nil {
+			return err
+		}
+		secret.Data = map[string][]byte{util.KubeconfigSecretDataKey: data}
+	}
+
+	if _, err := hostClientset.Core().Secrets(namespace).Update(secret); err != nil {
+		return fmt.Errorf("failed to update the credentials secret %q: %v", secret.Name, err)
+	}
+
+	// Federation controllers reconnect to a cluster when its annotations change.
+	patch := fmt.Sprintf(`{"metadata":{"annotations":{%q:%q}}}`, federation.CredentialsRotatedAnnotation, time.Now().UTC().Format(time.RFC3339))
+	if _, err := rh.Patch("", cluster.Name, types.MergePatchType, []byte(patch)); err != nil {
+		return fmt.Errorf("failed to notify the federation of the new credentials of cluster %q: %v", cluster.Name, err)
+	}
+	fmt.Fprintf(cmdOut, "Rotated the credentials of cluster %q\n", cluster.Name)
+
+	if token != nil && r.options.revokePrevious {
+		revoked, err := revokeServiceAccountTokens(clusterClientset, namespace, saName, token.Name)
+		if err != nil {
+			return err
+		}
+		for _, name := range revoked {
+			fmt.Fprintf(cmdOut, "Revoked previous token %q\n", name)
+		}
+	}
+	return nil
+}
+
+// clusterClientset returns a client for the cluster from its context in the
+// local kubeconfig or, failing that, from its current credentials.
+func (r *rotateCredentials) clusterClientset(config util.AdminConfig, secret *api.Secret, cluster *federationapi.Cluster) (internalclientset.Interface, error) {
+	kubeconfig := r.commonOptions.Kubeconfig
+	if r.commonOptions.CredentialsKubeconfig != "" {
+		kubeconfig = r.commonOptions.CredentialsKubeconfig
+	}
+	clientset, err := config.ClusterFactory(r.options.clusterContext, kubeconfig).ClientSet()
+	if err == nil {
+		return clientset, nil
+	}
+	outerErr := err
+	clientset, err = getClientsetFromCluster(secret, cluster)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get clientset from kubeconfig or cluster: %v, %v", outerErr, err)
+	}
+	return clientset, nil
+}
+
+// kubeconfigCredentials returns the serialized kubeconfig of the cluster's
+// context in the local kubeconfig.
+func (r *rotateCredentials) kubeconfigCredentials(config util.AdminConfig) ([]byte, error) {
+	po := config.PathOptions()
+	po.LoadingRules.ExplicitPath = r.commonOptions.Kubeconfig
+	if r.commonOptions.CredentialsKubeconfig != "" {
+		po.LoadingRules.ExplicitPath = r.commonOptions.CredentialsKubeconfig
+	}
+	clientConfig, err := po.GetStartingConfig()
+	if err != nil {
+		return nil, err
+	}
+	newClientConfig, err := minifyConfig(clientConfig, r.options.clusterContext)
+	if err != nil {
+		return nil, err
+	}
+	if err := clientcmdapi.FlattenConfig(newClientConfig); err != nil {
+		return nil, err
+	}
+	return util.WriteKubeconfig(newClientConfig, po.LoadingRules, r.options.clusterContext)
+}
+
+// mintServiceAccountToken creates a new token for the service account in the
+// cluster and waits for the token controller to populate it.
+func mintServiceAccountToken(clusterClientset internalclientset.Interface, namespace, saName string) (*api.Secret, error) {
+	sa, err := clusterClientset.Core().ServiceAccounts(namespace).Get(saName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	token := &api.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        names.SimpleNameGenerator.GenerateName(saName + "-token-"),
+			Namespace:   namespace,
+			Annotations: map[string]string{api.ServiceAccountNameKey: saName},
+		},
+		Type: api.SecretTypeServiceAccountToken,
+	}
+	if _, err := clusterClientset.Core().Secrets(namespace).Create(token); err != nil {
+		return nil, err
+	}
+
+	err = wait.PollImmediate(1*time.Second, serviceAccountSecretTimeout, func() (bool, error) {
+		secret, err := clusterClientset.Core().Secrets(namespace).Get(token.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, nil
+		}
+		token = secret
+		return len(token.Data[api.ServiceAccountTokenKey]) > 0, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("timed out waiting for token %q to be populated: %v", token.Name, err)
+	}
+
+	sa.Secrets = append(sa.Secrets, api.ObjectReference{Name: token.Name})
+	if _, err := clusterClientset.Core().ServiceAccounts(namespace).Update(sa); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// revokeServiceAccountTokens deletes the tokens of the service account other
+// than the one to keep, and returns their names.
+func revokeServiceAccountTokens(clusterClientset internalclientset.Interface, namespace, saName, keep string) ([]string, error) {
+	sa, err := clusterClientset.Core().ServiceAccounts(namespace).Get(saName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var revoked []string
+	var secrets []api.ObjectReference
+	for _, ref := range sa.Secrets {
+		if ref.Name == keep {
+			secrets = append(secrets, ref)
+			continue
+		}
+		secret, err := clusterClientset.Core().Secrets(namespace).Get(ref.Name, metav1.GetOptions{})
+		if isNotFound(err) {
+			continue
+		} else if err != nil {
+			return revoked, err
+		}
+		if secret.Type != api.SecretTypeServiceAccountToken || secret.Annotations[api.ServiceAccountNameKey] != saName {
+			secrets = append(secrets, ref)
+			continue
+		}
+		if err := deleteSecret(clusterClientset, ref.Name, namespace); err != nil && !isNotFound(err) {
+			return revoked, err
+		}
+		revoked = append(revoked, ref.Name)
+	}
+
+	sa.Secrets = secrets
+	if _, err := clusterClientset.Core().ServiceAccounts(namespace).Update(sa); err != nil {
+		return revoked, err
+	}
+	return revoked, nil
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubefed
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	core "k8s.io/client-go/testing"
+	api "k8s.io/kubernetes/pkg/apis/core"
+	"k8s.io/kubernetes/pkg/client/clientset_generated/internalclientset/fake"
+)
+
+func TestRotateServiceAccountToken(t *testing.T) {
+	const namespace = "federation-system"
+	const saName = "foo-bar"
+
+	clientset := fake.NewSimpleClientset(
+		&api.ServiceAccount{
+			ObjectMeta: metav1.ObjectMeta{Name: saName, Namespace: namespace},
+			Secrets:    []api.ObjectReference{{Name: "foo-bar-token-old"}, {Name: "foo-bar-dockercfg"}},
+		},
+		&api.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "foo-bar-token-old",
+				Namespace:   namespace,
+				Annotations: map[string]string{api.ServiceAccountNameKey: saName},
+			},
+			Type: api.SecretTypeServiceAccountToken,
+			Data: map[string][]byte{api.ServiceAccountTokenKey: []byte("old")},
+		},
+		&api.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo-bar-dockercfg", Namespace: namespace},
+			Type:       api.SecretTypeDockercfg,
+		},
+	)
+	// Stand in for the token controller of the cluster.
+	clientset.PrependReactor("create", "secrets", func(action core.Action) (bool, runtime.Object, error) {
+		secret := action.(core.CreateAction).GetObject().(*api.Secret)
+		secret.Data = map[string][]byte{
+			api.ServiceAccountTokenKey:     []byte("new"),
+			api.ServiceAccountRootCAKey:    []byte("ca"),
+			api.ServiceAccountNamespaceKey: []byte(namespace),
+		}
+		return false, nil, nil
+	})
+
+	token, err := mintServiceAccountToken(clientset, namespace, saName)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(token.Data[api.ServiceAccountTokenKey]) != "new" {
+		t.Errorf("expected the new token, got %v", token.Data)
+	}
+
+	revoked, err := revokeServiceAccountTokens(clientset, namespace, saName, token.Name)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := []string{"foo-bar-token-old"}; !reflect.DeepEqual(revoked, expected) {
+		t.Errorf("expected revoked tokens %v, got %v", expected, revoked)
+	}
+
+	sa, err := clientset.Core().ServiceAccounts(namespace).Get(saName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []api.ObjectReference{{Name: "foo-bar-dockercfg"}, {Name: token.Name}}
+	if !reflect.DeepEqual(sa.Secrets, expected) {
+		t.Errorf("expected service account secrets %v, got %v", expected, sa.Secrets)
+	}
+	if _, err := clientset.Core().Secrets(namespace).Get("foo-bar-token-old", metav1.GetOptions{}); !isNotFound(err) {
+		t.Errorf("expected the previous token to be deleted, got %v", err)
+	}
+}
@@ -188,19 +188,33 @@ func (u *unjoinFederation) Run(f cmdutil.Factory, cmdOut, cmdErr io.Writer, conf
 // popCluster fetches the cluster object with the given name, deletes
 // it and returns the deleted cluster object.
 func popCluster(f cmdutil.Factory, name string) (*federationapi.Cluster, error) {
+	cluster, rh, err := getCluster(f, name)
+	if cluster == nil || err != nil {
+		return nil, err
+	}
+
+	// Remove the cluster resource in the federation API server by
+	// calling rh.Delete()
+	return cluster, rh.Delete("", name)
+}
+
+// getCluster fetches the cluster object with the given name, along with the
+// helper to operate on the cluster resource. It returns a nil cluster if the
+// cluster isn't registered.
+func getCluster(f cmdutil.Factory, name string) (*federationapi.Cluster, *resource.Helper, error) {
 	mapper, typer := f.Object()
 	gvks, _, err := typer.ObjectKinds(&federationapi.Cluster{})
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	gvk := gvks[0]
 	mapping, err := mapper.RESTMapping(schema.GroupKind{Group: gvk.Group, Kind: gvk.Kind}, gvk.Version)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	client, err := f.ClientForMapping(mapping)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	rh := resource.NewHelper(client, mapping)
@@ -208,9 +222,9 @@ func popCluster(f cmdutil.Factory, name string) (*federationapi.Cluster, error)
 
 	if isNotFound(err) {
 		// Cluster isn't registered, there isn't anything to be done here.
-		return nil, nil
+		return nil, nil, nil
 	} else if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// TODO (@irfan): this is a stopgap
@@ -220,19 +234,16 @@ func popCluster(f cmdutil.Factory, name string) (*federationapi.Cluster, error)
 	// the list (which happens to be unversioned federation.Cluster).
 	cluster, ok := obj.(*federation.Cluster)
 	if !ok {
-		return nil, fmt.Errorf("unexpected object type: expected \"federation.Cluster\", got %T: obj: %#v", obj, obj)
+		return nil, nil, fmt.Errorf("unexpected object type: expected \"federation.Cluster\", got %T: obj: %#v", obj, obj)
 	}
 
 	// We then translate it to the type v1beta1 for consumption.
 	v1beta1Cluster := &federationapi.Cluster{}
 	err = federationapi.Convert_federation_Cluster_To_v1beta1_Cluster(cluster, v1beta1Cluster, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error while converting federation cluster to federation v1beta1 cluster: %v", err)
+		return nil, nil, fmt.Errorf("error while converting federation cluster to federation v1beta1 cluster: %v", err)
 	}
-
-	// Remove the cluster resource in the federation API server by
-	// calling rh.Delete()
-	return v1beta1Cluster, rh.Delete("", name)
+	return v1beta1Cluster, rh, nil
 }
 
 func updateConfigMapInCluster(hostClientset, unjoiningClusterClientset internalclientset.Interface, fedSystemNamespace string) error {
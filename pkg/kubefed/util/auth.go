@@ -0,0 +1,129 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+
+	"github.com/ghodss/yaml"
+	restclient "k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	fedutil "k8s.io/federation/pkg/federation-controller/util"
+)
+
+// execClientConfig is a client config that also authenticates with the exec
+// credential plugin of the context's user, which clientcmd does not support.
+type execClientConfig struct {
+	delegate     clientcmd.ClientConfig
+	loadingRules *clientcmd.ClientConfigLoadingRules
+	context      string
+}
+
+func (c *execClientConfig) RawConfig() (clientcmdapi.Config, error) {
+	return c.delegate.RawConfig()
+}
+
+func (c *execClientConfig) Namespace() (string, bool, error) {
+	return c.delegate.Namespace()
+}
+
+func (c *execClientConfig) ConfigAccess() clientcmd.ConfigAccess {
+	return c.delegate.ConfigAccess()
+}
+
+func (c *execClientConfig) ClientConfig() (*restclient.Config, error) {
+	config, err := c.delegate.ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	kubeconfigs, err := readKubeconfigs(c.loadingRules)
+	if err != nil {
+		return nil, err
+	}
+	_, rawExec, err := fedutil.KubeconfigExec(kubeconfigs, c.context)
+	if err != nil || rawExec == nil {
+		return config, err
+	}
+	if err := fedutil.ConfigureExecAuth(config, rawExec); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// WriteKubeconfig serializes the kubeconfig like clientcmd.Write, but keeps the
+// exec credential plugin of the user of the given context, as loaded with the
+// loading rules. clientcmd drops exec credential plugins.
+func WriteKubeconfig(config *clientcmdapi.Config, loadingRules *clientcmd.ClientConfigLoadingRules, context string) ([]byte, error) {
+	data, err := clientcmd.Write(*config)
+	if err != nil {
+		return nil, err
+	}
+	kubeconfigs, err := readKubeconfigs(loadingRules)
+	if err != nil {
+		return nil, err
+	}
+	user, rawExec, err := fedutil.KubeconfigExec(kubeconfigs, context)
+	if err != nil || rawExec == nil {
+		return data, err
+	}
+
+	var execConfig interface{}
+	if err := json.Unmarshal(rawExec, &execConfig); err != nil {
+		return nil, err
+	}
+	var out map[string]interface{}
+	if err := yaml.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	users, _ := out["users"].([]interface{})
+	for _, u := range users {
+		namedUser, ok := u.(map[string]interface{})
+		if !ok || namedUser["name"] != user {
+			continue
+		}
+		authInfo, ok := namedUser["user"].(map[string]interface{})
+		if !ok {
+			authInfo = map[string]interface{}{}
+			namedUser["user"] = authInfo
+		}
+		authInfo["exec"] = execConfig
+	}
+	return yaml.Marshal(out)
+}
+
+// readKubeconfigs returns the content of the kubeconfig files of the loading
+// rules, in order of precedence.
+func readKubeconfigs(loadingRules *clientcmd.ClientConfigLoadingRules) ([][]byte, error) {
+	files := loadingRules.GetLoadingPrecedence()
+	if loadingRules.ExplicitPath != "" {
+		files = []string{loadingRules.ExplicitPath}
+	}
+	var kubeconfigs [][]byte
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		kubeconfigs = append(kubeconfigs, data)
+	}
+	return kubeconfigs, nil
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+	"io"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer/json"
+	"k8s.io/kubernetes/pkg/api/legacyscheme"
+	"k8s.io/kubernetes/pkg/apis/extensions"
+)
+
+// OutputFormatYAML is the output format of the commands that render the
+// objects they would create as manifests, instead of creating them.
+const OutputFormatYAML = "yaml"
+
+// WriteManifests writes the given objects as a stream of YAML documents
+// that can be applied with kubectl. The objects are converted to the
+// preferred version of their API group and the fields set by the API
+// server are cleared. The comment is written first, it should name the
+// cluster the objects have to be applied to.
+func WriteManifests(w io.Writer, comment string, objs ...runtime.Object) error {
+	serializer := json.NewYAMLSerializer(json.DefaultMetaFactory, legacyscheme.Scheme, legacyscheme.Scheme)
+	if _, err := fmt.Fprintf(w, "# %s\n", comment); err != nil {
+		return err
+	}
+	for _, obj := range objs {
+		gvks, _, err := legacyscheme.Scheme.ObjectKinds(obj)
+		if err != nil {
+			return err
+		}
+		// The internal workload types are registered in both the apps and
+		// the extensions groups, kubefed creates them in the latter.
+		gvk := gvks[0]
+		for _, kind := range gvks {
+			if kind.Group == extensions.GroupName {
+				gvk = kind
+			}
+		}
+		group, err := legacyscheme.Registry.Group(gvk.Group)
+		if err != nil {
+			return err
+		}
+
+		obj = obj.DeepCopyObject()
+		accessor, err := meta.Accessor(obj)
+		if err != nil {
+			return err
+		}
+		accessor.SetResourceVersion("")
+		accessor.SetUID("")
+		accessor.SetSelfLink("")
+		accessor.SetGeneration(0)
+		accessor.SetCreationTimestamp(metav1.Time{})
+
+		data, err := runtime.Encode(legacyscheme.Codecs.EncoderForVersion(serializer, group.GroupVersion), obj)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "---\n%s", data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
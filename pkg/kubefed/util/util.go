@@ -18,11 +18,9 @@ package util
 
 import (
 	"fmt"
-	"net"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	utilnet "k8s.io/apimachinery/pkg/util/net"
 	"k8s.io/client-go/discovery"
 	restclient "k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -30,6 +28,7 @@ import (
 	federationapi "k8s.io/federation/apis/federation"
 	fedv1beta1 "k8s.io/federation/apis/federation/v1beta1"
 	fedclient "k8s.io/federation/client/clientset_generated/federation_clientset"
+	fedutil "k8s.io/federation/pkg/federation-controller/util"
 	api "k8s.io/kubernetes/pkg/apis/core"
 	"k8s.io/kubernetes/pkg/apis/rbac"
 	rbacv1 "k8s.io/kubernetes/pkg/apis/rbac/v1"
@@ -131,7 +130,11 @@ func (a *adminConfig) getClientConfig(context, kubeconfigPath string) clientcmd.
 		CurrentContext: context,
 	}
 
-	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(&loadingRules, overrides)
+	return &execClientConfig{
+		delegate:     clientcmd.NewNonInteractiveDeferredLoadingClientConfig(&loadingRules, overrides),
+		loadingRules: &loadingRules,
+		context:      context,
+	}
 }
 
 // SubcommandOptions holds the configuration required by the subcommands of
@@ -167,6 +170,12 @@ func CreateKubeconfigSecret(clientset client.Interface, kubeconfig *clientcmdapi
 	if err != nil {
 		return nil, err
 	}
+	return CreateKubeconfigDataSecret(clientset, configBytes, namespace, name, federationName, clusterName, dryRun)
+}
+
+// CreateKubeconfigDataSecret creates a secret holding the given serialized
+// kubeconfig.
+func CreateKubeconfigDataSecret(clientset client.Interface, configBytes []byte, namespace, name, federationName, clusterName string, dryRun bool) (*api.Secret, error) {
 	annotations := map[string]string{
 		federationapi.FederationNameAnnotation: federationName,
 	}
@@ -215,23 +224,15 @@ func GetClientsetFromSecret(secret *api.Secret, serverAddress string) (*client.C
 	return nil, err
 }
 
+// GetServerAddress returns the address of the active API server of the
+// cluster, or an empty string if none of its addresses match the client CIDR
+// of this host.
 func GetServerAddress(c *fedv1beta1.Cluster) (string, error) {
-	hostIP, err := utilnet.ChooseHostInterface()
+	serverAddresses, err := fedutil.ClusterServerAddresses(c)
 	if err != nil {
 		return "", err
 	}
-
-	for _, item := range c.Spec.ServerAddressByClientCIDRs {
-		_, cidrnet, err := net.ParseCIDR(item.ClientCIDR)
-		if err != nil {
-			return "", err
-		}
-		if cidrnet.Contains(hostIP) {
-			return item.ServerAddress, nil
-		}
-	}
-
-	return "", nil
+	return fedutil.ActiveServerAddress(c, serverAddresses), nil
 }
 
 func buildConfigFromSecret(secret *api.Secret, serverAddress string) (*restclient.Config, error) {
@@ -252,6 +253,9 @@ func buildConfigFromSecret(secret *api.Secret, serverAddress string) (*restclien
 	} else {
 		kubeconfigGetter := kubeconfigGetterForSecret(secret)
 		clusterConfig, err = clientcmd.BuildConfigFromKubeconfigGetter(serverAddress, kubeconfigGetter)
+		if err == nil {
+			err = fedutil.ConfigureClusterAuth(clusterConfig, secret.Data[KubeconfigSecretDataKey], nil)
+		}
 	}
 
 	if err != nil {
@@ -0,0 +1,259 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package federationannotations implements an admission plugin that rejects
+// objects whose federation annotations the controllers could not use.
+package federationannotations
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	pkgruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/federation/apis/federation"
+	federationapi "k8s.io/federation/apis/federation/v1beta1"
+	fedvalidation "k8s.io/federation/apis/federation/validation"
+	federationclientset "k8s.io/federation/client/clientset_generated/federation_clientset"
+	"k8s.io/federation/pkg/federatedtypes"
+	"k8s.io/federation/pkg/federation-controller/job"
+	"k8s.io/federation/pkg/federation-controller/util/eventsink"
+	hpautil "k8s.io/federation/pkg/federation-controller/util/hpa"
+	"k8s.io/federation/plugin/pkg/admission/initializer"
+	"k8s.io/kubernetes/pkg/api/legacyscheme"
+	"k8s.io/kubernetes/pkg/controller"
+
+	"github.com/golang/glog"
+)
+
+const (
+	PluginName = "FederationAnnotations"
+
+	// Annotation naming the static IP of a federated ingress, as read by the
+	// ingress controllers of the clusters.
+	ingressStaticIPAnnotation = "kubernetes.io/ingress.global-static-ip-name"
+)
+
+// replicaPreferencesAnnotations are the annotations holding
+// json-serialized ReplicaAllocationPreferences.
+var replicaPreferencesAnnotations = []string{
+	federatedtypes.FedReplicaSetPreferencesAnnotation,
+	federatedtypes.FedDeploymentPreferencesAnnotation,
+	job.FedJobPreferencesAnnotation,
+}
+
+type admissionController struct {
+	*admission.Handler
+	client federationclientset.Interface
+	// clusterStore caches the clusters of the federation, the names in the
+	// annotations are checked against it.
+	clusterStore      cache.Store
+	clusterController cache.Controller
+	eventRecorder     record.EventRecorder
+}
+
+var _ admission.ValidationInterface = &admissionController{}
+var _ initializer.WantsFederationClientSet = &admissionController{}
+
+// Register registers the plugin.
+func Register(plugins *admission.Plugins) {
+	plugins.Register(PluginName, func(config io.Reader) (admission.Interface, error) {
+		return newAdmissionController(), nil
+	})
+}
+
+func newAdmissionController() *admissionController {
+	return &admissionController{
+		Handler: admission.NewHandler(admission.Create, admission.Update),
+	}
+}
+
+// SetFederationClientSet starts watching the clusters of the federation with
+// the given client, for the lifetime of the apiserver.
+func (c *admissionController) SetFederationClientSet(client federationclientset.Interface) {
+	c.client = client
+	c.clusterStore, c.clusterController = cache.NewInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (pkgruntime.Object, error) {
+				return client.Federation().Clusters().List(options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				return client.Federation().Clusters().Watch(options)
+			},
+		},
+		&federationapi.Cluster{},
+		controller.NoResyncPeriodFunc(),
+		cache.ResourceEventHandlerFuncs{},
+	)
+	go c.clusterController.Run(wait.NeverStop)
+
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartRecordingToSink(eventsink.NewFederatedEventSink(client))
+	c.eventRecorder = broadcaster.NewRecorder(legacyscheme.Scheme, v1.EventSource{Component: "federation-annotations"})
+}
+
+func (c *admissionController) ValidateInitialization() error {
+	if c.client == nil {
+		return fmt.Errorf("%s requires a federation client", PluginName)
+	}
+	return nil
+}
+
+// Validate rejects objects with federation annotations that can not be
+// parsed or hold invalid values. Annotations naming clusters that are not
+// part of the federation are only reported with an event, since clusters
+// may join later.
+func (c *admissionController) Validate(a admission.Attributes) error {
+	if a.GetSubresource() != "" || a.GetObject() == nil {
+		return nil
+	}
+	accessor, err := meta.Accessor(a.GetObject())
+	if err != nil {
+		return nil
+	}
+	annotations := accessor.GetAnnotations()
+	if len(annotations) == 0 {
+		return nil
+	}
+
+	allErrs, clusterNames := validateAnnotations(annotations, field.NewPath("metadata", "annotations"))
+	if len(allErrs) > 0 {
+		return errors.NewInvalid(a.GetKind().GroupKind(), a.GetName(), allErrs)
+	}
+	if len(clusterNames) > 0 {
+		c.checkClustersExist(a, accessor, clusterNames)
+	}
+	return nil
+}
+
+// validateAnnotations validates the federation annotations and returns the
+// names of the clusters they refer to.
+func validateAnnotations(annotations map[string]string, fldPath *field.Path) (field.ErrorList, sets.String) {
+	allErrs := field.ErrorList{}
+	clusterNames := sets.NewString()
+
+	if value, found := annotations[federationapi.FederationClusterSelectorAnnotation]; found {
+		path := fldPath.Key(federationapi.FederationClusterSelectorAnnotation)
+		var requirements []federation.ClusterSelectorRequirement
+		if err := json.Unmarshal([]byte(value), &requirements); err != nil {
+			allErrs = append(allErrs, field.Invalid(path, value, fmt.Sprintf("must be a json list of cluster selector requirements: %v", err)))
+		} else {
+			allErrs = append(allErrs, fedvalidation.ValidateClusterSelector(requirements, path)...)
+		}
+	}
+
+	if value, found := annotations[federationapi.FederationClusterSetAnnotation]; found {
+		allErrs = append(allErrs, fedvalidation.ValidateClusterSetReference(value, fldPath.Key(federationapi.FederationClusterSetAnnotation))...)
+	}
+
+	for _, key := range replicaPreferencesAnnotations {
+		value, found := annotations[key]
+		if !found {
+			continue
+		}
+		path := fldPath.Key(key)
+		var prefs federation.ReplicaAllocationPreferences
+		if err := json.Unmarshal([]byte(value), &prefs); err != nil {
+			allErrs = append(allErrs, field.Invalid(path, value, fmt.Sprintf("must be json-serialized replica allocation preferences: %v", err)))
+			continue
+		}
+		allErrs = append(allErrs, fedvalidation.ValidateReplicaAllocationPreferences(&prefs, path)...)
+		for name := range prefs.Clusters {
+			if name != "*" {
+				clusterNames.Insert(name)
+			}
+		}
+	}
+
+	if value, found := annotations[federatedtypes.FedHpaPreferencesAnnotation]; found {
+		path := fldPath.Key(federatedtypes.FedHpaPreferencesAnnotation)
+		var prefs federation.HpaPreferences
+		if err := json.Unmarshal([]byte(value), &prefs); err != nil {
+			allErrs = append(allErrs, field.Invalid(path, value, fmt.Sprintf("must be json-serialized hpa preferences: %v", err)))
+		} else {
+			allErrs = append(allErrs, fedvalidation.ValidateHpaPreferences(&prefs, path)...)
+		}
+	}
+
+	if value, found := annotations[hpautil.FederatedAnnotationOnHpaTargetObj]; found {
+		path := fldPath.Key(hpautil.FederatedAnnotationOnHpaTargetObj)
+		var targets hpautil.ClusterNames
+		if err := json.Unmarshal([]byte(value), &targets); err != nil {
+			allErrs = append(allErrs, field.Invalid(path, value, fmt.Sprintf("must be a json-serialized cluster name list: %v", err)))
+		} else {
+			for i, name := range targets.Names {
+				if len(name) == 0 {
+					allErrs = append(allErrs, field.Required(path.Child("names").Index(i), ""))
+					continue
+				}
+				clusterNames.Insert(name)
+			}
+		}
+	}
+
+	if value, found := annotations[ingressStaticIPAnnotation]; found {
+		for _, msg := range validation.IsDNS1035Label(value) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Key(ingressStaticIPAnnotation), value, msg))
+		}
+	}
+
+	return allErrs, clusterNames
+}
+
+// checkClustersExist publishes a warning event if any of the given clusters
+// is not part of the federation. Nothing is checked until the clusters have
+// been listed once.
+func (c *admissionController) checkClustersExist(a admission.Attributes, accessor metav1.Object, clusterNames sets.String) {
+	if !c.clusterController.HasSynced() {
+		glog.V(4).Infof("Not checking the clusters of %s %s/%s, the clusters are not synced yet", a.GetKind().Kind, a.GetNamespace(), a.GetName())
+		return
+	}
+	missing := sets.NewString()
+	for _, name := range clusterNames.List() {
+		if _, found, _ := c.clusterStore.GetByKey(name); !found {
+			missing.Insert(name)
+		}
+	}
+	if missing.Len() == 0 {
+		return
+	}
+
+	msg := fmt.Sprintf("Federation annotations refer to unknown clusters: %s", strings.Join(missing.List(), ", "))
+	glog.Warningf("%s %s/%s: %s", a.GetKind().Kind, a.GetNamespace(), a.GetName(), msg)
+	// The recorder sends the event in the background, the request does not
+	// wait for it.
+	ref := &v1.ObjectReference{
+		Kind:       a.GetKind().Kind,
+		APIVersion: a.GetKind().GroupVersion().String(),
+		Namespace:  a.GetNamespace(),
+		Name:       a.GetName(),
+		UID:        accessor.GetUID(),
+	}
+	c.eventRecorder.Event(ref, v1.EventTypeWarning, "UnknownClusters", msg)
+}
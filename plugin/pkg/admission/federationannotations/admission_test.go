@@ -0,0 +1,184 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package federationannotations
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/apiserver/pkg/authentication/user"
+	core "k8s.io/client-go/testing"
+	federationapi "k8s.io/federation/apis/federation/v1beta1"
+	fakefedclientset "k8s.io/federation/client/clientset_generated/federation_clientset/fake"
+	"k8s.io/federation/pkg/federation-controller/job"
+	"k8s.io/kubernetes/pkg/apis/extensions"
+)
+
+func newReplicaSet(annotations map[string]string) *extensions.ReplicaSet {
+	return &extensions.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "frontend",
+			Namespace:   "default",
+			Annotations: annotations,
+		},
+	}
+}
+
+func admit(c *admissionController, obj *extensions.ReplicaSet) error {
+	attrs := admission.NewAttributesRecord(obj, nil, extensions.Kind("ReplicaSet").WithVersion("version"), obj.Namespace, obj.Name,
+		extensions.Resource("replicasets").WithVersion("version"), "", admission.Create, &user.DefaultInfo{})
+	return c.Validate(attrs)
+}
+
+func newTestAdmissionController(t *testing.T) (*admissionController, *fakefedclientset.Clientset) {
+	client := fakefedclientset.NewSimpleClientset(&federationapi.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster1"}})
+	c := newAdmissionController()
+	c.SetFederationClientSet(client)
+	if err := wait.PollImmediate(10*time.Millisecond, wait.ForeverTestTimeout, func() (bool, error) {
+		return c.clusterController.HasSynced(), nil
+	}); err != nil {
+		t.Fatalf("Failed to sync the clusters: %v", err)
+	}
+	return c, client
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		note        string
+		annotations map[string]string
+		wantErr     bool
+	}{
+		{"no annotations", nil, false},
+		{"unrelated annotation", map[string]string{"foo": "{"}, false},
+		{"valid cluster selector", map[string]string{
+			federationapi.FederationClusterSelectorAnnotation: `[{"key": "environment", "operator": "in", "values": ["prod"]}]`,
+		}, false},
+		{"cluster selector with invalid json", map[string]string{
+			federationapi.FederationClusterSelectorAnnotation: `[{"key": "environment"`,
+		}, true},
+		{"cluster selector with unknown operator", map[string]string{
+			federationapi.FederationClusterSelectorAnnotation: `[{"key": "environment", "operator": "like", "values": ["prod"]}]`,
+		}, true},
+		{"valid cluster set", map[string]string{
+			federationapi.FederationClusterSetAnnotation: "production",
+		}, false},
+		{"invalid cluster set", map[string]string{
+			federationapi.FederationClusterSetAnnotation: "Production_Clusters",
+		}, true},
+		{"valid replica set preferences", map[string]string{
+			"federation.kubernetes.io/replica-set-preferences": `{"rebalance": true, "clusters": {"*": {"weight": 1}, "cluster1": {"minReplicas": 2, "maxReplicas": 4}}}`,
+		}, false},
+		{"negative replicas in deployment preferences", map[string]string{
+			"federation.kubernetes.io/deployment-preferences": `{"clusters": {"cluster1": {"minReplicas": -1}}}`,
+		}, true},
+		{"job preferences with invalid json", map[string]string{
+			job.FedJobPreferencesAnnotation: `{"clusters": `,
+		}, true},
+		{"valid hpa preferences", map[string]string{
+			"federation.kubernetes.io/hpa-preferences": `{"mode": "Global", "dampingPercent": 30}`,
+		}, false},
+		{"hpa preferences with unknown mode", map[string]string{
+			"federation.kubernetes.io/hpa-preferences": `{"mode": "Adaptive"}`,
+		}, true},
+		{"valid hpa target list", map[string]string{
+			"federation.kubernetes.io/hpa-target-cluster-list": `{"Names": ["cluster1"]}`,
+		}, false},
+		{"hpa target list with empty name", map[string]string{
+			"federation.kubernetes.io/hpa-target-cluster-list": `{"Names": [""]}`,
+		}, true},
+		{"valid ingress static ip", map[string]string{
+			ingressStaticIPAnnotation: "frontend-ip",
+		}, false},
+		{"invalid ingress static ip", map[string]string{
+			ingressStaticIPAnnotation: "Frontend_IP",
+		}, true},
+	}
+
+	for _, test := range tests {
+		c, _ := newTestAdmissionController(t)
+		err := admit(c, newReplicaSet(test.annotations))
+		if test.wantErr && err == nil {
+			t.Errorf("%s: expected an error", test.note)
+		} else if !test.wantErr && err != nil {
+			t.Errorf("%s: unexpected error: %v", test.note, err)
+		}
+	}
+}
+
+func TestValidateWarnsAboutUnknownClusters(t *testing.T) {
+	c, client := newTestAdmissionController(t)
+	obj := newReplicaSet(map[string]string{
+		"federation.kubernetes.io/replica-set-preferences": `{"clusters": {"cluster1": {"weight": 1}, "cluster2": {"weight": 1}}}`,
+	})
+	if err := admit(c, obj); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// The event is sent in the background.
+	var event *v1.Event
+	err := wait.PollImmediate(10*time.Millisecond, wait.ForeverTestTimeout, func() (bool, error) {
+		for _, action := range client.Actions() {
+			if create, ok := action.(core.CreateAction); ok && action.GetResource().Resource == "events" {
+				event = create.GetObject().(*v1.Event)
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		t.Fatalf("Expected a warning event about unknown clusters")
+	}
+	if event.Type != v1.EventTypeWarning || event.Reason != "UnknownClusters" {
+		t.Errorf("Unexpected event %s/%s", event.Type, event.Reason)
+	}
+	if event.Message != "Federation annotations refer to unknown clusters: cluster2" {
+		t.Errorf("Unexpected event message %q", event.Message)
+	}
+}
+
+func TestValidateReadsClustersFromCache(t *testing.T) {
+	c, client := newTestAdmissionController(t)
+	client.ClearActions()
+	obj := newReplicaSet(map[string]string{
+		"federation.kubernetes.io/replica-set-preferences": `{"clusters": {"cluster1": {"weight": 1}}}`,
+	})
+	for i := 0; i < 3; i++ {
+		if err := admit(c, obj); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	for _, action := range client.Actions() {
+		if action.GetVerb() == "list" {
+			t.Errorf("Expected the clusters to be read from the cache, got action %v", action)
+		}
+	}
+}
+
+func TestValidateInitialization(t *testing.T) {
+	c := newAdmissionController()
+	if err := c.ValidateInitialization(); err == nil {
+		t.Errorf("Expected an error without a federation client")
+	}
+	c.SetFederationClientSet(fakefedclientset.NewSimpleClientset())
+	if err := c.ValidateInitialization(); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
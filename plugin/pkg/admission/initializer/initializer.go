@@ -0,0 +1,50 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package initializer hands federation specific dependencies to the
+// admission plugins of the federation apiserver.
+package initializer
+
+import (
+	"k8s.io/apiserver/pkg/admission"
+	federationclientset "k8s.io/federation/client/clientset_generated/federation_clientset"
+)
+
+// WantsFederationClientSet defines a function which sets the federation
+// clientset for admission plugins that need it.
+type WantsFederationClientSet interface {
+	SetFederationClientSet(federationclientset.Interface)
+	admission.InitializationValidator
+}
+
+type pluginInitializer struct {
+	client federationclientset.Interface
+}
+
+var _ admission.PluginInitializer = pluginInitializer{}
+
+// New creates an instance of the federation admission plugin initializer.
+func New(client federationclientset.Interface) pluginInitializer {
+	return pluginInitializer{client: client}
+}
+
+// Initialize checks the initialization interfaces implemented by a plugin
+// and provides the appropriate initialization data.
+func (i pluginInitializer) Initialize(plugin admission.Interface) {
+	if wants, ok := plugin.(WantsFederationClientSet); ok {
+		wants.SetFederationClientSet(i.client)
+	}
+}
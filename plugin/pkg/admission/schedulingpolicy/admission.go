@@ -15,16 +15,24 @@ limitations under the License.
 */
 
 // Package schedulingpolicy implements a webhook that queries an external API
-// to obtain scheduling decisions for Federated sources.
+// to obtain scheduling decisions for Federated sources. Alternatively, the
+// decisions are taken in-process from declarative rules.
 package schedulingpolicy
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
+	"os"
+	"sort"
+	"strings"
 	"time"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/yaml"
 	"k8s.io/apiserver/pkg/admission"
 	"k8s.io/client-go/dynamic"
@@ -46,17 +54,41 @@ const (
 	// If the admission controller config file does not specify a backoff, this
 	// one is used.
 	defaultRetryBackoff = time.Millisecond * 100
+
+	// auditAnnotation records on the object, in audit mode, the policy decision
+	// that was not enforced.
+	auditAnnotation = "federation.kubernetes.io/scheduling-policy-audit"
+
+	// webhookMode queries an external policy engine for decisions.
+	webhookMode = "webhook"
+	// localMode evaluates declarative rules in-process.
+	localMode = "local"
 )
 
 type admissionConfig struct {
+	// Mode is either webhook, the default, or local.
+	Mode         string        `json:"mode"`
 	Kubeconfig   string        `json:"kubeconfig"`
 	RetryBackoff time.Duration `json:"retryBackoff"`
+	// RulesFile is the file holding the rules of the local mode. If it is
+	// empty, the rules are read from the ConfigMaps in the policy namespace.
+	RulesFile string `json:"rulesFile"`
+	// Audit records what the policy would change or reject, as events and
+	// an annotation on the object, without enforcing it.
+	Audit bool `json:"audit"`
+	// CacheTTL is how long, in milliseconds, decisions of the policy engine
+	// are cached for identical objects. Decisions are not cached if it is 0.
+	CacheTTL time.Duration `json:"cacheTTL"`
 }
 
 type admissionController struct {
 	*admission.Handler
+	mode                     string
 	policyEngineClient       *rest.RESTClient            // client to communicate with policy engine
 	policyEngineRetryBackoff time.Duration               // backoff for policy engine queries
+	policyEngineCache        *decisionCache              // cache of policy engine decisions, may be nil
+	rules                    *policyRules                // rules of the local mode, loaded from the rules file
+	audit                    bool                        // record instead of enforcing decisions
 	client                   internalclientset.Interface // client to communicate with federation-apiserver
 }
 
@@ -73,15 +105,27 @@ func newAdmissionController(file io.Reader) (*admissionController, error) {
 		return nil, err
 	}
 
-	policyEngineClient, err := loadRestClient(config.Kubeconfig)
-	if err != nil {
-		return nil, err
-	}
-
 	c := &admissionController{
 		Handler:                  admission.NewHandler(admission.Create, admission.Update),
-		policyEngineClient:       policyEngineClient,
+		mode:                     config.Mode,
 		policyEngineRetryBackoff: config.RetryBackoff,
+		policyEngineCache:        newDecisionCache(config.CacheTTL),
+		audit:                    config.Audit,
+	}
+
+	switch config.Mode {
+	case webhookMode:
+		c.policyEngineClient, err = loadRestClient(config.Kubeconfig)
+		if err != nil {
+			return nil, err
+		}
+	case localMode:
+		if len(config.RulesFile) > 0 {
+			c.rules, err = loadRulesFile(config.RulesFile)
+			if err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	return c, nil
@@ -99,45 +143,123 @@ func (c *admissionController) SetInternalKubeClientSet(client internalclientset.
 }
 
 func (c *admissionController) Admit(a admission.Attributes) (err error) {
-	exists, err := c.policyExists()
+	obj := a.GetObject()
+	decision, err := c.decide(obj, a.GetKind())
+	if c.audit {
+		return c.auditDecision(a, decision, err)
+	}
+
 	if err != nil {
 		return c.handleError(a, err)
 	}
 
-	if !exists {
+	if decision == nil {
 		return nil
 	}
 
+	if err := decision.Error(); err != nil {
+		return c.handleError(a, err)
+	}
+
+	mergeAnnotations(obj, decision.Annotations)
+
+	return nil
+}
+
+// decide returns the policy decision on obj, or nil if no policy is defined.
+func (c *admissionController) decide(obj runtime.Object, gvk schema.GroupVersionKind) (*policyDecision, error) {
+	if c.mode == localMode && c.rules != nil {
+		return c.rules.evaluate(obj, gvk)
+	}
+
+	lst, err := c.client.Core().ConfigMaps(policyConfigMapNamespace).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(lst.Items) == 0 {
+		return nil, nil
+	}
+
+	if c.mode == localMode {
+		rules, err := loadPolicyRulesFromConfigMaps(lst.Items)
+		if err != nil {
+			return nil, err
+		}
+		return rules.evaluate(obj, gvk)
+	}
+
+	return newPolicyEngineQuery(c.policyEngineClient, c.policyEngineRetryBackoff, c.policyEngineCache, obj, gvk).Do()
+}
+
+// auditDecision records the errors and the annotation changes of the decision,
+// or the error of the query, on the object and in events. The object is
+// admitted regardless.
+func (c *admissionController) auditDecision(a admission.Attributes, decision *policyDecision, err error) error {
 	obj := a.GetObject()
-	decision, err := newPolicyEngineQuery(c.policyEngineClient, c.policyEngineRetryBackoff, obj, a.GetKind()).Do()
+	accessor, accessorErr := meta.Accessor(obj)
+	if accessorErr != nil {
+		return accessorErr
+	}
 
+	findings := &policyDecision{}
 	if err != nil {
-		return c.handleError(a, err)
+		findings.Errors = []string{err.Error()}
+	} else if decision != nil {
+		findings.Errors = decision.Errors
+		for k, v := range decision.Annotations {
+			if current, found := accessor.GetAnnotations()[k]; !found || current != v {
+				if findings.Annotations == nil {
+					findings.Annotations = map[string]string{}
+				}
+				findings.Annotations[k] = v
+			}
+		}
 	}
 
-	if err := decision.Error(); err != nil {
-		return c.handleError(a, err)
+	annotations := accessor.GetAnnotations()
+	if len(findings.Errors) == 0 && len(findings.Annotations) == 0 {
+		// Drop the findings of earlier versions of the object.
+		if _, found := annotations[auditAnnotation]; found {
+			delete(annotations, auditAnnotation)
+			accessor.SetAnnotations(annotations)
+		}
+		return nil
 	}
 
-	mergeAnnotations(obj, decision.Annotations)
+	if err := findings.Error(); err != nil {
+		c.publishEvent(a, api.EventTypeWarning, fmt.Sprintf("Scheduling policy would reject the object: %v", err))
+	}
+	if len(findings.Annotations) > 0 {
+		var keys []string
+		for k := range findings.Annotations {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		c.publishEvent(a, api.EventTypeNormal, fmt.Sprintf("Scheduling policy would set annotations: %v", strings.Join(keys, ", ")))
+	}
 
-	return nil
+	bs, err := json.Marshal(findings)
+	if err != nil {
+		return err
+	}
+	return mergeAnnotations(obj, map[string]string{auditAnnotation: string(bs)})
 }
 
 func (c *admissionController) handleError(a admission.Attributes, err error) error {
 
-	c.publishEvent(a, err.Error())
+	c.publishEvent(a, api.EventTypeWarning, err.Error())
 
 	return admission.NewForbidden(a, err)
 }
 
-func (c *admissionController) publishEvent(a admission.Attributes, msg string) {
+func (c *admissionController) publishEvent(a admission.Attributes, eventType, msg string) {
 
 	obj := a.GetObject()
 
 	ref, err := ref.GetReference(legacyscheme.Scheme, obj)
 	if err != nil {
-		runtime.HandleError(err)
+		utilruntime.HandleError(err)
 		return
 	}
 
@@ -147,23 +269,15 @@ func (c *admissionController) publishEvent(a admission.Attributes, msg string) {
 		Source: api.EventSource{
 			Component: fmt.Sprintf("schedulingpolicy"),
 		},
-		Type: "Warning",
+		Type: eventType,
 	}
 
 	if _, err := c.client.Core().Events(a.GetNamespace()).Create(event); err != nil {
-		runtime.HandleError(err)
+		utilruntime.HandleError(err)
 		return
 	}
 }
 
-func (c *admissionController) policyExists() (bool, error) {
-	lst, err := c.client.Core().ConfigMaps(policyConfigMapNamespace).List(metav1.ListOptions{})
-	if err != nil {
-		return true, err
-	}
-	return len(lst.Items) > 0, nil
-}
-
 func loadConfig(file io.Reader) (*admissionConfig, error) {
 	var cfg admissionConfig
 	if file == nil {
@@ -174,7 +288,15 @@ func loadConfig(file io.Reader) (*admissionConfig, error) {
 		return nil, err
 	}
 
-	if len(cfg.Kubeconfig) == 0 {
+	switch cfg.Mode {
+	case "":
+		cfg.Mode = webhookMode
+	case webhookMode, localMode:
+	default:
+		return nil, fmt.Errorf("mode must be %s or %s, got %q", webhookMode, localMode, cfg.Mode)
+	}
+
+	if cfg.Mode == webhookMode && len(cfg.Kubeconfig) == 0 {
 		return nil, fmt.Errorf("kubeconfig path must not be empty")
 	}
 
@@ -189,9 +311,29 @@ func loadConfig(file io.Reader) (*admissionConfig, error) {
 		return nil, fmt.Errorf("retryBackoff must not be negative")
 	}
 
+	if cfg.CacheTTL < 0 {
+		return nil, fmt.Errorf("cacheTTL must not be negative")
+	}
+	// Scale up value from config (which is unmarshalled as ns).
+	cfg.CacheTTL *= time.Millisecond
+
 	return &cfg, nil
 }
 
+func loadRulesFile(path string) (*policyRules, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	rules, err := loadPolicyRules(file)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rules in %s: %v", path, err)
+	}
+	return rules, nil
+}
+
 func loadRestClient(kubeConfigFile string) (*rest.RESTClient, error) {
 
 	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
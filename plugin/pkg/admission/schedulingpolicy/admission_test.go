@@ -28,10 +28,12 @@ import (
 	"os"
 	"reflect"
 	"testing"
+	"time"
 
 	extensionsv1 "k8s.io/api/extensions/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apiserver/pkg/admission"
 	"k8s.io/apiserver/pkg/authentication/user"
 	core "k8s.io/client-go/testing"
@@ -107,6 +109,50 @@ current-context: default
 				`, p),
 			false,
 		},
+		{
+			"unknown mode",
+			fmt.Sprintf(`
+				{
+					"kubeconfig": %q,
+					"mode": "remote"
+				}
+				`, p),
+			true,
+		},
+		{
+			"bad cache ttl",
+			fmt.Sprintf(`
+				{
+					"kubeconfig": %q,
+					"cacheTTL": -1
+				}
+				`, p),
+			true,
+		},
+		{
+			"a valid config with audit and cache ttl",
+			fmt.Sprintf(`
+				{
+					"kubeconfig": %q,
+					"audit": true,
+					"cacheTTL": 5000
+				}
+				`, p),
+			false,
+		},
+		{
+			"local mode without kubeconfig",
+			`{"mode": "local"}`,
+			false,
+		},
+		{
+			"local mode rules file not found",
+			`{
+				"mode": "local",
+				"rulesFile": "/kube-federation-scheduling-policy-file-not-found-test"
+			}`,
+			true,
+		},
 		{
 			"a valid config with retry backoff",
 			fmt.Sprintf(`
@@ -319,6 +365,180 @@ func TestAdmitSuccessWithAnnotationMerge(t *testing.T) {
 	}
 }
 
+func TestAdmitAuditMode(t *testing.T) {
+	body := `{"errors": ["conflicting replica-set-preferences"], "annotations": {"foo": "bar-2", "bar": "baz"}}`
+	controller, err := newControllerWithTestServer(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	}, true)
+	if err != nil {
+		t.Fatalf("Unexpected error while creating test admission controller/server: %v", err)
+	}
+	controller.audit = true
+
+	var events []*api.Event
+	controller.client.(*fake.Clientset).AddReactor("create", "events", func(action core.Action) (bool, runtime.Object, error) {
+		event := action.(core.CreateAction).GetObject().(*api.Event)
+		events = append(events, event)
+		return true, event, nil
+	})
+
+	obj := makeReplicaSet()
+	obj.Annotations = map[string]string{"foo": "bar", "bar": "baz"}
+	attrs := makeAdmissionRecord(obj)
+	if err := controller.Admit(attrs); err != nil {
+		t.Fatalf("Expected audit mode to admit the object but got error: %v", err)
+	}
+
+	expected := map[string]string{
+		"foo":           "bar",
+		"bar":           "baz",
+		auditAnnotation: `{"errors":["conflicting replica-set-preferences"],"annotations":{"foo":"bar-2"}}`,
+	}
+	if annotations := attrs.GetObject().(*extensionsv1.ReplicaSet).Annotations; !reflect.DeepEqual(annotations, expected) {
+		t.Fatalf("Expected annotations to be %v but got: %v", expected, annotations)
+	}
+	if len(events) != 2 || events[0].Type != api.EventTypeWarning || events[1].Type != api.EventTypeNormal {
+		t.Fatalf("Expected a warning and a normal event but got: %v", events)
+	}
+
+	// The findings of earlier versions are dropped once the policy is met.
+	body = `{"annotations": {"foo": "bar"}}`
+	attrs = makeAdmissionRecord(attrs.GetObject().(*extensionsv1.ReplicaSet))
+	if err := controller.Admit(attrs); err != nil {
+		t.Fatalf("Unexpected error from admission controller: %v", err)
+	}
+	expected = map[string]string{"foo": "bar", "bar": "baz"}
+	if annotations := attrs.GetObject().(*extensionsv1.ReplicaSet).Annotations; !reflect.DeepEqual(annotations, expected) {
+		t.Fatalf("Expected annotations to be %v but got: %v", expected, annotations)
+	}
+}
+
+func TestAdmitCachesDecisions(t *testing.T) {
+	var numQueries int
+
+	controller, err := newControllerWithTestServer(func(w http.ResponseWriter, r *http.Request) {
+		numQueries++
+		w.Write([]byte(`{"annotations": {"foo": "bar"}}`))
+	}, true)
+	if err != nil {
+		t.Fatalf("Unexpected error while creating test admission controller/server: %v", err)
+	}
+	controller.policyEngineCache = newDecisionCache(time.Minute)
+
+	for i := 0; i < 3; i++ {
+		rs := makeReplicaSet()
+		rs.Name = fmt.Sprintf("myapp-%d", i)
+		rs.UID = types.UID(rs.Name)
+		attrs := makeAdmissionRecord(rs)
+		if err := controller.Admit(attrs); err != nil {
+			t.Fatalf("Unexpected error from admission controller: %v", err)
+		}
+		expected := map[string]string{"foo": "bar"}
+		if annotations := attrs.GetObject().(*extensionsv1.ReplicaSet).Annotations; !reflect.DeepEqual(annotations, expected) {
+			t.Fatalf("Expected annotations to be %v but got: %v", expected, annotations)
+		}
+	}
+	if numQueries != 1 {
+		t.Fatalf("Expected a single query for objects with the same policy inputs but got: %v", numQueries)
+	}
+
+	rs := makeReplicaSet()
+	rs.Spec.MinReadySeconds = 10
+	if err := controller.Admit(makeAdmissionRecord(rs)); err != nil {
+		t.Fatalf("Unexpected error from admission controller: %v", err)
+	}
+	if numQueries != 2 {
+		t.Fatalf("Expected a query for a different spec but got (numQueries): %v", numQueries)
+	}
+
+	rs = makeReplicaSet()
+	rs.Annotations = map[string]string{"bar": "baz"}
+	if err := controller.Admit(makeAdmissionRecord(rs)); err != nil {
+		t.Fatalf("Unexpected error from admission controller: %v", err)
+	}
+	if numQueries != 3 {
+		t.Fatalf("Expected a query for different annotations but got (numQueries): %v", numQueries)
+	}
+}
+
+func TestAdmitLocalMode(t *testing.T) {
+	controller, err := newAdmissionController(bytes.NewBufferString(`{"mode": "local"}`))
+	if err != nil {
+		t.Fatalf("Unexpected error while creating test admission controller: %v", err)
+	}
+
+	controller.SetInternalKubeClientSet(fake.NewSimpleClientset(&api.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "rules", Namespace: policyConfigMapNamespace},
+		Data: map[string]string{
+			policyRulesKey: `
+rules:
+- name: europe
+  annotations:
+    foo: bar-2
+- name: no-baz
+  match:
+    kinds: [ReplicaSet]
+  forbiddenAnnotations: [baz]
+`,
+		},
+	}))
+
+	obj := makeReplicaSet()
+	obj.Annotations = map[string]string{"foo": "bar", "bar": "baz"}
+	attrs := makeAdmissionRecord(obj)
+	if err := controller.Admit(attrs); err != nil {
+		t.Fatalf("Unexpected error from admission controller: %v", err)
+	}
+
+	expected := map[string]string{
+		"foo": "bar-2",
+		"bar": "baz",
+	}
+	if annotations := attrs.GetObject().(*extensionsv1.ReplicaSet).Annotations; !reflect.DeepEqual(annotations, expected) {
+		t.Fatalf("Expected annotations to be %v but got: %v", expected, annotations)
+	}
+
+	obj = makeReplicaSet()
+	obj.Annotations = map[string]string{"baz": "qux"}
+	if err := controller.Admit(makeAdmissionRecord(obj)); err == nil {
+		t.Fatalf("Expected admission controller to reject forbidden annotation")
+	}
+}
+
+func TestAdmitLocalModeRulesFile(t *testing.T) {
+	tempfile, err := ioutil.TempFile("", "")
+	if err != nil {
+		t.Fatalf("Unexpected error while creating temporary file: %v", err)
+	}
+	defer os.Remove(tempfile.Name())
+
+	if _, err := tempfile.WriteString(`{"rules": [{"name": "all", "annotations": {"foo": "bar"}}]}`); err != nil {
+		t.Fatalf("Unexpected error while writing test rules file: %v", err)
+	}
+
+	controller, err := newAdmissionController(bytes.NewBufferString(fmt.Sprintf(`{"mode": "local", "rulesFile": %q}`, tempfile.Name())))
+	if err != nil {
+		t.Fatalf("Unexpected error while creating test admission controller: %v", err)
+	}
+
+	// Rules loaded from a file do not depend on ConfigMaps.
+	mockClient := &fake.Clientset{}
+	mockClient.AddReactor("list", "configmaps", func(action core.Action) (bool, runtime.Object, error) {
+		return true, nil, fmt.Errorf("unexpected list of configmaps")
+	})
+	controller.SetInternalKubeClientSet(mockClient)
+
+	attrs := makeAdmissionRecord(makeReplicaSet())
+	if err := controller.Admit(attrs); err != nil {
+		t.Fatalf("Unexpected error from admission controller: %v", err)
+	}
+
+	expected := map[string]string{"foo": "bar"}
+	if annotations := attrs.GetObject().(*extensionsv1.ReplicaSet).Annotations; !reflect.DeepEqual(annotations, expected) {
+		t.Fatalf("Expected annotations to be %v but got: %v", expected, annotations)
+	}
+}
+
 func newControllerWithTestServer(f func(w http.ResponseWriter, r *http.Request), policiesExist bool) (*admissionController, error) {
 	server, err := newTestServer(f)
 	if err != nil {
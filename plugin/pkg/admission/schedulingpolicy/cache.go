@@ -0,0 +1,110 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedulingpolicy
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/cache"
+)
+
+// decisionCacheSize is the maximum number of policy decisions kept in the cache.
+const decisionCacheSize = 1024
+
+// decisionCache remembers the decisions of the policy engine for a while, so
+// that identical objects do not each cost a query.
+type decisionCache struct {
+	cache *cache.LRUExpireCache
+	ttl   time.Duration
+}
+
+// newDecisionCache returns a cache that keeps decisions for ttl. It returns
+// nil, a cache that keeps nothing, if ttl is not positive.
+func newDecisionCache(ttl time.Duration) *decisionCache {
+	if ttl <= 0 {
+		return nil
+	}
+	return &decisionCache{
+		cache: cache.NewLRUExpireCache(decisionCacheSize),
+		ttl:   ttl,
+	}
+}
+
+// policyInputs are the parts of an object that policies decide on. Names,
+// UIDs and other per-object metadata are left out so that identical
+// workloads created in a burst share a decision.
+type policyInputs struct {
+	Metadata struct {
+		Namespace   string            `json:"namespace,omitempty"`
+		Annotations map[string]string `json:"annotations,omitempty"`
+	} `json:"metadata"`
+	Spec json.RawMessage `json:"spec,omitempty"`
+}
+
+// decisionCacheKey returns the key of the decision on an object of the given
+// kind with the given JSON encoding.
+func decisionCacheKey(gvk schema.GroupVersionKind, encoded []byte) (string, error) {
+	var inputs policyInputs
+	if err := json.Unmarshal(encoded, &inputs); err != nil {
+		return "", err
+	}
+	bs, err := json.Marshal(inputs)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(bs)
+	return gvk.String() + "/" + hex.EncodeToString(hash[:]), nil
+}
+
+// get returns a copy of the cached decision for key, which the caller may
+// modify.
+func (c *decisionCache) get(key string) (*policyDecision, bool) {
+	if c == nil {
+		return nil, false
+	}
+	value, found := c.cache.Get(key)
+	if !found {
+		return nil, false
+	}
+	return value.(*policyDecision).copy(), true
+}
+
+// add caches a copy of the decision for key.
+func (c *decisionCache) add(key string, decision *policyDecision) {
+	if c == nil {
+		return
+	}
+	c.cache.Add(key, decision.copy(), c.ttl)
+}
+
+func (d *policyDecision) copy() *policyDecision {
+	out := &policyDecision{}
+	if d.Errors != nil {
+		out.Errors = append([]string(nil), d.Errors...)
+	}
+	if d.Annotations != nil {
+		out.Annotations = make(map[string]string, len(d.Annotations))
+		for k, v := range d.Annotations {
+			out.Annotations[k] = v
+		}
+	}
+	return out
+}
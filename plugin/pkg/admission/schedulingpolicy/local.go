@@ -0,0 +1,185 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedulingpolicy
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	api "k8s.io/kubernetes/pkg/apis/core"
+)
+
+// policyRulesKey is the key of the ConfigMap data holding the rules of the
+// local policy engine.
+const policyRulesKey = "rules"
+
+// policyRules is the declarative policy evaluated by the local policy engine.
+type policyRules struct {
+	Rules []policyRule `json:"rules"`
+}
+
+// policyRule injects or forbids annotations of the objects it matches.
+type policyRule struct {
+	Name  string          `json:"name"`
+	Match policyRuleMatch `json:"match"`
+	// Annotations are set on the matching objects, superseding the
+	// annotations the objects already have.
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// ForbiddenAnnotations are annotations the matching objects must not have.
+	ForbiddenAnnotations []string `json:"forbiddenAnnotations,omitempty"`
+
+	selector labels.Selector
+}
+
+// policyRuleMatch selects the objects a rule applies to. Empty fields match
+// all objects.
+type policyRuleMatch struct {
+	// Kinds are compared case-insensitively.
+	Kinds         []string              `json:"kinds,omitempty"`
+	Namespaces    []string              `json:"namespaces,omitempty"`
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+}
+
+// loadPolicyRules decodes and validates the yaml or json rules read from r.
+func loadPolicyRules(r io.Reader) (*policyRules, error) {
+	rules := &policyRules{}
+	if err := yaml.NewYAMLOrJSONDecoder(r, 4096).Decode(rules); err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	names := map[string]bool{}
+	for i := range rules.Rules {
+		rule := &rules.Rules[i]
+		if len(rule.Name) == 0 {
+			return nil, fmt.Errorf("rules[%d]: name must not be empty", i)
+		}
+		if names[rule.Name] {
+			return nil, fmt.Errorf("rules[%d]: duplicate rule name %q", i, rule.Name)
+		}
+		names[rule.Name] = true
+		if len(rule.Annotations) == 0 && len(rule.ForbiddenAnnotations) == 0 {
+			return nil, fmt.Errorf("rule %q must set annotations or forbiddenAnnotations", rule.Name)
+		}
+
+		rule.selector = labels.Everything()
+		if rule.Match.LabelSelector != nil {
+			selector, err := metav1.LabelSelectorAsSelector(rule.Match.LabelSelector)
+			if err != nil {
+				return nil, fmt.Errorf("rule %q: invalid label selector: %v", rule.Name, err)
+			}
+			rule.selector = selector
+		}
+	}
+	return rules, nil
+}
+
+// loadPolicyRulesFromConfigMaps returns the rules held by the given ConfigMaps,
+// in the order of their names. ConfigMaps without rules are ignored.
+func loadPolicyRulesFromConfigMaps(configMaps []api.ConfigMap) (*policyRules, error) {
+	sort.Slice(configMaps, func(i, j int) bool { return configMaps[i].Name < configMaps[j].Name })
+
+	all := &policyRules{}
+	for _, configMap := range configMaps {
+		data, found := configMap.Data[policyRulesKey]
+		if !found {
+			continue
+		}
+		rules, err := loadPolicyRules(bytes.NewBufferString(data))
+		if err != nil {
+			return nil, fmt.Errorf("invalid rules in ConfigMap %s/%s: %v", configMap.Namespace, configMap.Name, err)
+		}
+		all.Rules = append(all.Rules, rules.Rules...)
+	}
+	return all, nil
+}
+
+// matches returns whether the rule applies to an object of the given kind.
+func (rule *policyRule) matches(kind string, accessor metav1.Object) bool {
+	if len(rule.Match.Kinds) > 0 {
+		found := false
+		for _, k := range rule.Match.Kinds {
+			if strings.EqualFold(k, kind) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(rule.Match.Namespaces) > 0 {
+		found := false
+		for _, ns := range rule.Match.Namespaces {
+			if ns == accessor.GetNamespace() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return rule.selector.Matches(labels.Set(accessor.GetLabels()))
+}
+
+// evaluate returns the decision of the rules on obj, in the same shape as the
+// decisions of an external policy engine. Matching rules that inject different
+// values for the same annotation are reported as errors.
+func (rules *policyRules) evaluate(obj runtime.Object, gvk schema.GroupVersionKind) (*policyDecision, error) {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	decision := &policyDecision{}
+	injectedBy := map[string]string{}
+	for i := range rules.Rules {
+		rule := &rules.Rules[i]
+		if !rule.matches(gvk.Kind, accessor) {
+			continue
+		}
+		for _, key := range rule.ForbiddenAnnotations {
+			if _, found := accessor.GetAnnotations()[key]; found {
+				decision.Errors = append(decision.Errors, fmt.Sprintf("annotation %s is forbidden by rule %q", key, rule.Name))
+			}
+		}
+		for key, value := range rule.Annotations {
+			if other, found := injectedBy[key]; found {
+				if decision.Annotations[key] != value {
+					decision.Errors = append(decision.Errors, fmt.Sprintf("conflicting %s annotation from rules %q and %q", key, other, rule.Name))
+				}
+				continue
+			}
+			if decision.Annotations == nil {
+				decision.Annotations = map[string]string{}
+			}
+			decision.Annotations[key] = value
+			injectedBy[key] = rule.Name
+		}
+	}
+	sort.Strings(decision.Errors)
+	return decision, nil
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedulingpolicy
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	api "k8s.io/kubernetes/pkg/apis/core"
+)
+
+const testRules = `
+rules:
+- name: prod-in-europe
+  match:
+    kinds: [replicaset, Deployment]
+    namespaces: [prod]
+  annotations:
+    federation.alpha.kubernetes.io/cluster-selector: '[{"key": "region", "operator": "in", "values": ["europe"]}]'
+- name: no-preferences
+  match:
+    labelSelector:
+      matchLabels:
+        tier: frontend
+  forbiddenAnnotations:
+  - federation.kubernetes.io/replica-set-preferences
+`
+
+func TestLoadPolicyRules(t *testing.T) {
+	tests := []struct {
+		note    string
+		input   string
+		wantErr bool
+	}{
+		{"empty", "", false},
+		{"valid", testRules, false},
+		{"bad yaml", `rules: [`, true},
+		{"missing name", `rules: [{annotations: {foo: bar}}]`, true},
+		{"duplicate name", `rules: [{name: a, annotations: {foo: bar}}, {name: a, annotations: {foo: baz}}]`, true},
+		{"no effect", `rules: [{name: a}]`, true},
+		{"bad selector", `rules: [{name: a, annotations: {foo: bar}, match: {labelSelector: {matchExpressions: [{key: a, operator: bad}]}}}]`, true},
+	}
+
+	for _, tc := range tests {
+		_, err := loadPolicyRules(bytes.NewBufferString(tc.input))
+		if tc.wantErr && err == nil {
+			t.Errorf("%v: Expected error", tc.note)
+		} else if !tc.wantErr && err != nil {
+			t.Errorf("%v: Unexpected error: %v", tc.note, err)
+		}
+	}
+}
+
+func TestEvaluatePolicyRules(t *testing.T) {
+	rules, err := loadPolicyRules(bytes.NewBufferString(testRules))
+	if err != nil {
+		t.Fatalf("Unexpected error loading rules: %v", err)
+	}
+
+	tests := []struct {
+		note                string
+		namespace           string
+		labels              map[string]string
+		annotations         map[string]string
+		expectedAnnotations map[string]string
+		expectedErrors      int
+	}{
+		{
+			note:      "no matching rule",
+			namespace: "dev",
+		},
+		{
+			note:      "injected cluster selector",
+			namespace: "prod",
+			expectedAnnotations: map[string]string{
+				"federation.alpha.kubernetes.io/cluster-selector": `[{"key": "region", "operator": "in", "values": ["europe"]}]`,
+			},
+		},
+		{
+			note:           "forbidden preferences",
+			namespace:      "dev",
+			labels:         map[string]string{"tier": "frontend"},
+			annotations:    map[string]string{"federation.kubernetes.io/replica-set-preferences": "{}"},
+			expectedErrors: 1,
+		},
+		{
+			note:        "preferences allowed without label",
+			namespace:   "dev",
+			annotations: map[string]string{"federation.kubernetes.io/replica-set-preferences": "{}"},
+		},
+	}
+
+	for _, tc := range tests {
+		rs := makeReplicaSet()
+		rs.Namespace = tc.namespace
+		rs.Labels = tc.labels
+		rs.Annotations = tc.annotations
+
+		decision, err := rules.evaluate(rs, rs.GroupVersionKind())
+		if err != nil {
+			t.Errorf("%v: Unexpected error: %v", tc.note, err)
+			continue
+		}
+		if !reflect.DeepEqual(decision.Annotations, tc.expectedAnnotations) {
+			t.Errorf("%v: Expected annotations %v but got: %v", tc.note, tc.expectedAnnotations, decision.Annotations)
+		}
+		if len(decision.Errors) != tc.expectedErrors {
+			t.Errorf("%v: Expected %d errors but got: %v", tc.note, tc.expectedErrors, decision.Errors)
+		}
+	}
+}
+
+func TestEvaluateConflictingPolicyRules(t *testing.T) {
+	rules, err := loadPolicyRulesFromConfigMaps([]api.ConfigMap{
+		{ObjectMeta: metav1.ObjectMeta{Name: "b"}, Data: map[string]string{policyRulesKey: `rules: [{name: b, annotations: {foo: baz}}]`}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "a"}, Data: map[string]string{policyRulesKey: `rules: [{name: a, annotations: {foo: bar}}]`}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "c"}, Data: map[string]string{"other": "data"}},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error loading rules: %v", err)
+	}
+
+	decision, err := rules.evaluate(makeReplicaSet(), makeReplicaSet().GroupVersionKind())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []string{`conflicting foo annotation from rules "a" and "b"`}
+	if !reflect.DeepEqual(decision.Errors, expected) {
+		t.Fatalf("Expected errors %v but got: %v", expected, decision.Errors)
+	}
+}
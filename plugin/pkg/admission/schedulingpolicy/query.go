@@ -44,15 +44,18 @@ func (policyUndefinedError) Error() string {
 type policyEngineQuery struct {
 	client       *rest.RESTClient
 	retryBackoff time.Duration
+	cache        *decisionCache
 	obj          runtime.Object
 	gvk          schema.GroupVersionKind
 }
 
-// newPolicyEngineQuery returns a policyEngineQuery that can be executed.
-func newPolicyEngineQuery(client *rest.RESTClient, retryBackoff time.Duration, obj runtime.Object, gvk schema.GroupVersionKind) *policyEngineQuery {
+// newPolicyEngineQuery returns a policyEngineQuery that can be executed. The
+// cache may be nil.
+func newPolicyEngineQuery(client *rest.RESTClient, retryBackoff time.Duration, cache *decisionCache, obj runtime.Object, gvk schema.GroupVersionKind) *policyEngineQuery {
 	return &policyEngineQuery{
 		client:       client,
 		retryBackoff: retryBackoff,
+		cache:        cache,
 		obj:          obj,
 		gvk:          gvk,
 	}
@@ -60,7 +63,8 @@ func newPolicyEngineQuery(client *rest.RESTClient, retryBackoff time.Duration, o
 
 // Do returns the result of the policy engine query. If the policy decision is
 // undefined or an unknown error occurs, err is non-nil. Otherwise, result is
-// non-nil and contains the result of policy evaluation.
+// non-nil and contains the result of policy evaluation. Decisions on objects
+// with the same policy inputs are served from the cache, if any.
 func (query *policyEngineQuery) Do() (decision *policyDecision, err error) {
 
 	bs, err := query.encode()
@@ -68,6 +72,14 @@ func (query *policyEngineQuery) Do() (decision *policyDecision, err error) {
 		return nil, err
 	}
 
+	key, err := decisionCacheKey(query.gvk, bs)
+	if err != nil {
+		return nil, err
+	}
+	if decision, found := query.cache.get(key); found {
+		return decision, nil
+	}
+
 	var result rest.Result
 
 	err = webhook.WithExponentialBackoff(query.retryBackoff, func() error {
@@ -84,7 +96,13 @@ func (query *policyEngineQuery) Do() (decision *policyDecision, err error) {
 		return nil, err
 	}
 
-	return decodeResult(result)
+	decision, err = decodeResult(result)
+	if err != nil {
+		return nil, err
+	}
+
+	query.cache.add(key, decision)
+	return decision, nil
 }
 
 // encode returns the encoded version of the query's runtime.Object.
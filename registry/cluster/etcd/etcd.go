@@ -24,6 +24,8 @@ import (
 	"k8s.io/apiserver/pkg/registry/rest"
 	"k8s.io/federation/apis/federation"
 	"k8s.io/federation/registry/cluster"
+	"k8s.io/kubernetes/pkg/printers"
+	printerstorage "k8s.io/kubernetes/pkg/printers/storage"
 )
 
 type REST struct {
@@ -55,6 +57,8 @@ func NewREST(optsGetter generic.RESTOptionsGetter) (*REST, *StatusREST) {
 		UpdateStrategy:      cluster.Strategy,
 		DeleteStrategy:      cluster.Strategy,
 		ReturnDeletedObject: true,
+
+		TableConvertor: printerstorage.TableConvertor{TablePrinter: printers.NewTablePrinter().With(cluster.AddHandlers)},
 	}
 	options := &generic.StoreOptions{RESTOptions: optsGetter, AttrFunc: cluster.GetAttrs}
 	if err := store.CompleteWithOptions(options); err != nil {
@@ -0,0 +1,85 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1alpha1 "k8s.io/apimachinery/pkg/apis/meta/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/federation/apis/federation"
+	fedv1beta1 "k8s.io/federation/apis/federation/v1beta1"
+	"k8s.io/kubernetes/pkg/printers"
+)
+
+// AddHandlers adds the print handlers of the server-side table columns of
+// clusters, used by `kubectl get clusters`.
+func AddHandlers(h printers.PrintHandler) {
+	clusterColumnDefinitions := []metav1alpha1.TableColumnDefinition{
+		{Name: "Name", Type: "string", Format: "name", Description: metav1.ObjectMeta{}.SwaggerDoc()["name"]},
+		{Name: "Ready", Type: "string", Description: "The status of the Ready condition of the cluster"},
+		{Name: "Region", Type: "string", Description: fedv1beta1.ClusterStatus{}.SwaggerDoc()["region"]},
+		{Name: "Zones", Type: "string", Description: fedv1beta1.ClusterStatus{}.SwaggerDoc()["zones"]},
+		{Name: "Age", Type: "string", Description: metav1.ObjectMeta{}.SwaggerDoc()["creationTimestamp"]},
+		{Name: "Version", Type: "string", Description: fedv1beta1.ClusterStatus{}.SwaggerDoc()["version"]},
+	}
+	h.TableHandler(clusterColumnDefinitions, printCluster)
+	h.TableHandler(clusterColumnDefinitions, printClusterList)
+}
+
+func printCluster(obj *federation.Cluster, options printers.PrintOptions) ([]metav1alpha1.TableRow, error) {
+	row := metav1alpha1.TableRow{
+		Object: runtime.RawExtension{Object: obj},
+	}
+	region := obj.Status.Region
+	if len(region) == 0 {
+		region = "<none>"
+	}
+	zones := strings.Join(obj.Status.Zones, ",")
+	if len(zones) == 0 {
+		zones = "<none>"
+	}
+	version := obj.Status.Version
+	if len(version) == 0 {
+		version = "<unknown>"
+	}
+	row.Cells = append(row.Cells, obj.Name, string(conditionStatus(obj, federation.ClusterReady)), region, zones, translateTimestamp(obj.CreationTimestamp), version)
+	return []metav1alpha1.TableRow{row}, nil
+}
+
+func printClusterList(list *federation.ClusterList, options printers.PrintOptions) ([]metav1alpha1.TableRow, error) {
+	rows := make([]metav1alpha1.TableRow, 0, len(list.Items))
+	for i := range list.Items {
+		r, err := printCluster(&list.Items[i], options)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, r...)
+	}
+	return rows, nil
+}
+
+// translateTimestamp returns the elapsed time since timestamp in
+// human-readable approximation.
+func translateTimestamp(timestamp metav1.Time) string {
+	if timestamp.IsZero() {
+		return "<unknown>"
+	}
+	return printers.ShortHumanDuration(time.Now().Sub(timestamp.Time))
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/apimachinery/pkg/util/proxy"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	restclient "k8s.io/client-go/rest"
+	"k8s.io/client-go/transport"
+	"k8s.io/federation/apis/federation"
+	fedv1beta1 "k8s.io/federation/apis/federation/v1beta1"
+	"k8s.io/federation/pkg/federation-controller/util"
+	"k8s.io/kubernetes/pkg/api/legacyscheme"
+)
+
+// ProxyREST implements the proxy subresource for a Cluster. Requests are
+// forwarded to the API server of the cluster with the credentials that the
+// federation stores for it.
+type ProxyREST struct {
+	Store rest.Getter
+	// ConfigGetter returns the client config of a cluster. It must not
+	// block, since it is called for every proxied request.
+	ConfigGetter func(*fedv1beta1.Cluster) (*restclient.Config, error)
+	// Impersonate makes the cluster handle the requests as the federation
+	// user that made them, instead of as the user of the stored credentials.
+	Impersonate bool
+}
+
+// Implement Connecter
+var _ = rest.Connecter(&ProxyREST{})
+
+var proxyMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"}
+
+// NewProxyREST returns a ProxyREST that reads clusters from the given store.
+func NewProxyREST(store rest.Getter, impersonate bool) *ProxyREST {
+	return &ProxyREST{
+		Store:        store,
+		ConfigGetter: util.BuildClusterConfigNoRetry,
+		Impersonate:  impersonate,
+	}
+}
+
+// New returns an empty cluster resource
+func (r *ProxyREST) New() runtime.Object {
+	return &federation.Cluster{}
+}
+
+// ConnectMethods returns the list of HTTP methods that can be proxied
+func (r *ProxyREST) ConnectMethods() []string {
+	return proxyMethods
+}
+
+// NewConnectOptions returns versioned resource that represents proxy parameters
+func (r *ProxyREST) NewConnectOptions() (runtime.Object, bool, string) {
+	return &federation.ClusterProxyOptions{}, true, "path"
+}
+
+// Connect returns a handler for the cluster proxy
+func (r *ProxyREST) Connect(ctx genericapirequest.Context, id string, opts runtime.Object, responder rest.Responder) (http.Handler, error) {
+	proxyOpts, ok := opts.(*federation.ClusterProxyOptions)
+	if !ok {
+		return nil, fmt.Errorf("Invalid options object: %#v", opts)
+	}
+	obj, err := r.Store.Get(ctx, id, &metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	cluster := &fedv1beta1.Cluster{}
+	if err := legacyscheme.Scheme.Convert(obj, cluster, nil); err != nil {
+		return nil, err
+	}
+	if util.IsClusterPullMode(cluster) {
+		return nil, errors.NewBadRequest(fmt.Sprintf("cluster %q is in pull mode and can not be reached by the federation", id))
+	}
+	config, err := r.ConfigGetter(cluster)
+	if err != nil {
+		return nil, errors.NewServiceUnavailable(fmt.Sprintf("failed to get the credentials of cluster %q: %v", id, err))
+	}
+	if r.Impersonate {
+		user, ok := genericapirequest.UserFrom(ctx)
+		if !ok {
+			return nil, errors.NewForbidden(federation.Resource("clusters/proxy"), id, fmt.Errorf("no user found in the request"))
+		}
+		config.Impersonate = restclient.ImpersonationConfig{
+			UserName: user.GetName(),
+			Groups:   user.GetGroups(),
+			Extra:    user.GetExtra(),
+		}
+	}
+	location, _, err := restclient.DefaultServerURL(config.Host, "", schema.GroupVersion{}, restclient.IsConfigTransportTLS(*config))
+	if err != nil {
+		return nil, err
+	}
+	rt, err := restclient.TransportFor(config)
+	if err != nil {
+		return nil, err
+	}
+	location.Path = net.JoinPreservingTrailingSlash(location.Path, proxyOpts.Path)
+	handler := proxy.NewUpgradeAwareHandler(location, rt, false, false, proxy.NewErrorResponder(responder))
+	return &credentialFilter{handler: handler, responder: responder}, nil
+}
+
+// credentialFilter removes the credentials of the federation user from the
+// requests before they are proxied, so they are neither leaked to the cluster
+// nor used in place of the credentials of the cluster.
+type credentialFilter struct {
+	handler   http.Handler
+	responder rest.Responder
+}
+
+func (f *credentialFilter) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	// Upgraded connections bypass the transport that authenticates with the
+	// cluster.
+	if httpstream.IsUpgradeRequest(req) {
+		f.responder.Error(errors.NewBadRequest("upgrade requests can not be proxied to clusters"))
+		return
+	}
+	req.Header.Del("Authorization")
+	req.Header.Del(transport.ImpersonateUserHeader)
+	req.Header.Del(transport.ImpersonateGroupHeader)
+	for key := range req.Header {
+		if strings.HasPrefix(key, transport.ImpersonateUserExtraHeaderPrefix) {
+			req.Header.Del(key)
+		}
+	}
+	f.handler.ServeHTTP(w, req)
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/authentication/user"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	restclient "k8s.io/client-go/rest"
+	"k8s.io/federation/apis/federation"
+	_ "k8s.io/federation/apis/federation/install"
+	fedv1beta1 "k8s.io/federation/apis/federation/v1beta1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeGetter struct {
+	cluster *federation.Cluster
+}
+
+func (g *fakeGetter) Get(ctx genericapirequest.Context, name string, options *metav1.GetOptions) (runtime.Object, error) {
+	return g.cluster, nil
+}
+
+type fakeResponder struct {
+	err error
+}
+
+func (r *fakeResponder) Object(statusCode int, obj runtime.Object) {}
+
+func (r *fakeResponder) Error(err error) {
+	r.err = err
+}
+
+func TestConnect(t *testing.T) {
+	var received *http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		received = req
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	ctx := genericapirequest.WithUser(genericapirequest.NewContext(), &user.DefaultInfo{Name: "alice", Groups: []string{"devs"}})
+	for _, impersonate := range []bool{false, true} {
+		received = nil
+		r := &ProxyREST{
+			Store: &fakeGetter{cluster: &federation.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "foo"}}},
+			ConfigGetter: func(cluster *fedv1beta1.Cluster) (*restclient.Config, error) {
+				return &restclient.Config{Host: server.URL, BearerToken: "member-token"}, nil
+			},
+			Impersonate: impersonate,
+		}
+		responder := &fakeResponder{}
+		handler, err := r.Connect(ctx, "foo", &federation.ClusterProxyOptions{Path: "/api/v1/nodes"}, responder)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest("GET", "/apis/federation/v1beta1/clusters/foo/proxy/api/v1/nodes?limit=1", nil)
+		req.Header.Set("Authorization", "Bearer federation-token")
+		req.Header.Set("Impersonate-User", "admin")
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+		require.NoError(t, responder.err)
+		require.NotNil(t, received)
+
+		assert.Equal(t, "/api/v1/nodes", received.URL.Path)
+		assert.Equal(t, "limit=1", received.URL.RawQuery)
+		assert.Equal(t, "Bearer member-token", received.Header.Get("Authorization"))
+		if impersonate {
+			assert.Equal(t, "alice", received.Header.Get("Impersonate-User"))
+			assert.Equal(t, []string{"devs"}, received.Header["Impersonate-Group"])
+		} else {
+			assert.Empty(t, received.Header.Get("Impersonate-User"))
+		}
+	}
+}
+
+func TestConnectPullCluster(t *testing.T) {
+	r := &ProxyREST{
+		Store: &fakeGetter{cluster: &federation.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+			Spec:       federation.ClusterSpec{ConnectionMode: federation.ClusterConnectionPull},
+		}},
+		ConfigGetter: func(cluster *fedv1beta1.Cluster) (*restclient.Config, error) {
+			t.Fatalf("unexpected request for the config of a cluster in pull mode")
+			return nil, nil
+		},
+	}
+	_, err := r.Connect(genericapirequest.NewContext(), "foo", &federation.ClusterProxyOptions{}, &fakeResponder{})
+	assert.Error(t, err)
+}
+
+func TestConnectConfigError(t *testing.T) {
+	r := &ProxyREST{
+		Store: &fakeGetter{cluster: &federation.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "foo"}}},
+		ConfigGetter: func(cluster *fedv1beta1.Cluster) (*restclient.Config, error) {
+			return nil, fmt.Errorf("secret not found")
+		},
+	}
+	_, err := r.Connect(genericapirequest.NewContext(), "foo", &federation.ClusterProxyOptions{}, &fakeResponder{})
+	assert.True(t, errors.IsServiceUnavailable(err), "expected a service unavailable error, got %v", err)
+}
@@ -30,6 +30,7 @@ import (
 	"k8s.io/federation/apis/federation"
 	"k8s.io/federation/apis/federation/validation"
 	"k8s.io/kubernetes/pkg/api/legacyscheme"
+	api "k8s.io/kubernetes/pkg/apis/core"
 )
 
 type clusterStrategy struct {
@@ -43,8 +44,32 @@ func (clusterStrategy) NamespaceScoped() bool {
 	return false
 }
 
+// ClusterToSelectableFields returns the fields of a cluster that can be used in
+// field selectors. The conditions are selected by their status, e.g.
+// status.ready=True. Each zone of the cluster has its own field, which the
+// status.zones selector is converted to.
 func ClusterToSelectableFields(cluster *federation.Cluster) fields.Set {
-	return generic.ObjectMetaFieldsSet(&cluster.ObjectMeta, false)
+	objectMetaFieldsSet := generic.ObjectMetaFieldsSet(&cluster.ObjectMeta, false)
+	specificFieldsSet := fields.Set{
+		"status.region":  cluster.Status.Region,
+		"status.ready":   string(conditionStatus(cluster, federation.ClusterReady)),
+		"status.offline": string(conditionStatus(cluster, federation.ClusterOffline)),
+	}
+	for _, zone := range cluster.Status.Zones {
+		specificFieldsSet[federation.ClusterZoneFieldLabel(zone)] = "true"
+	}
+	return generic.MergeFieldsSets(objectMetaFieldsSet, specificFieldsSet)
+}
+
+// conditionStatus returns the status of the given condition of the cluster,
+// Unknown if the cluster does not report it.
+func conditionStatus(cluster *federation.Cluster, conditionType federation.ClusterConditionType) api.ConditionStatus {
+	for _, condition := range cluster.Status.Conditions {
+		if condition.Type == conditionType {
+			return condition.Status
+		}
+	}
+	return api.ConditionUnknown
 }
 
 // GetAttrs returns labels and fields of a given object for filtering purposes.
@@ -158,6 +158,54 @@ func TestMatchCluster(t *testing.T) {
 	}
 }
 
+func TestClusterToSelectableFields(t *testing.T) {
+	cluster := validNewCluster()
+	cluster.Status.Region = "us-east1"
+	cluster.Status.Zones = []string{"us-east1-b", "us-east1-c"}
+	expected := fields.Set{
+		"metadata.name":           "foo",
+		"status.region":           "us-east1",
+		"status.ready":            "True",
+		"status.offline":          "Unknown",
+		"status.zones.us-east1-b": "true",
+		"status.zones.us-east1-c": "true",
+	}
+	if e, a := expected, ClusterToSelectableFields(cluster); !reflect.DeepEqual(e, a) {
+		t.Errorf("expected %v, got %v", e, a)
+	}
+
+	for selector, matches := range map[string]bool{
+		"status.ready=True":                        true,
+		"status.ready!=True":                       false,
+		"status.region=us-east1":                   true,
+		"status.region=us-west1,status.ready=True": false,
+		"status.zones=us-east1-b":                  true,
+		"status.zones=us-east1-d":                  false,
+		"status.zones!=us-east1-c":                 false,
+		"status.zones!=us-west1-a":                 true,
+	} {
+		s, err := fields.ParseSelector(selector)
+		if err != nil {
+			t.Fatalf("unexpected error parsing %q: %v", selector, err)
+		}
+		// Convert the selector as the API server does.
+		s, err = s.Transform(func(label, value string) (string, string, error) {
+			return legacyscheme.Scheme.ConvertFieldLabel(legacyscheme.Registry.GroupOrDie(federation.GroupName).GroupVersion.String(), "Cluster", label, value)
+		})
+		if err != nil {
+			t.Fatalf("unexpected error converting %q: %v", selector, err)
+		}
+		predicate := MatchCluster(labels.Everything(), s)
+		m, err := predicate.Matches(cluster)
+		if err != nil {
+			t.Fatalf("unexpected error matching %q: %v", selector, err)
+		}
+		if m != matches {
+			t.Errorf("%q: expected %v, got %v", selector, matches, m)
+		}
+	}
+}
+
 func TestSelectableFieldLabelConversions(t *testing.T) {
 	apitesting.TestSelectableFieldLabelConversionsOfKind(t,
 		legacyscheme.Registry.GroupOrDie(federation.GroupName).GroupVersion.String(),
@@ -0,0 +1,130 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package clusterset
+
+import (
+	"fmt"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/generic"
+	apistorage "k8s.io/apiserver/pkg/storage"
+	"k8s.io/apiserver/pkg/storage/names"
+	"k8s.io/federation/apis/federation"
+	"k8s.io/federation/apis/federation/validation"
+	"k8s.io/kubernetes/pkg/api/legacyscheme"
+)
+
+type clusterSetStrategy struct {
+	runtime.ObjectTyper
+	names.NameGenerator
+}
+
+var Strategy = clusterSetStrategy{legacyscheme.Scheme, names.SimpleNameGenerator}
+
+func (clusterSetStrategy) NamespaceScoped() bool {
+	return false
+}
+
+func ClusterSetToSelectableFields(set *federation.ClusterSet) fields.Set {
+	return generic.ObjectMetaFieldsSet(&set.ObjectMeta, false)
+}
+
+// GetAttrs returns labels and fields of a given object for filtering purposes.
+func GetAttrs(obj runtime.Object) (labels.Set, fields.Set, bool, error) {
+	set, ok := obj.(*federation.ClusterSet)
+	if !ok {
+		return nil, nil, false, fmt.Errorf("given object is not a cluster set.")
+	}
+	return labels.Set(set.ObjectMeta.Labels), ClusterSetToSelectableFields(set), set.Initializers != nil, nil
+}
+
+func MatchClusterSet(label labels.Selector, field fields.Selector) apistorage.SelectionPredicate {
+	return apistorage.SelectionPredicate{
+		Label:    label,
+		Field:    field,
+		GetAttrs: GetAttrs,
+	}
+}
+
+// PrepareForCreate clears fields that are not allowed to be set by end users on creation.
+func (clusterSetStrategy) PrepareForCreate(ctx genericapirequest.Context, obj runtime.Object) {
+	set := obj.(*federation.ClusterSet)
+	set.Status = federation.ClusterSetStatus{}
+	set.Generation = 1
+}
+
+// Validate validates a new cluster set.
+func (clusterSetStrategy) Validate(ctx genericapirequest.Context, obj runtime.Object) field.ErrorList {
+	set := obj.(*federation.ClusterSet)
+	return validation.ValidateClusterSet(set)
+}
+
+// Canonicalize normalizes the object after validation.
+func (clusterSetStrategy) Canonicalize(obj runtime.Object) {
+}
+
+// AllowCreateOnUpdate is false for cluster sets.
+func (clusterSetStrategy) AllowCreateOnUpdate() bool {
+	return false
+}
+
+// PrepareForUpdate keeps the status of the cluster set and bumps its
+// generation when its spec changes.
+func (clusterSetStrategy) PrepareForUpdate(ctx genericapirequest.Context, obj, old runtime.Object) {
+	set := obj.(*federation.ClusterSet)
+	oldSet := old.(*federation.ClusterSet)
+	set.Status = oldSet.Status
+	set.Generation = oldSet.Generation
+	if !apiequality.Semantic.DeepEqual(set.Spec, oldSet.Spec) {
+		set.Generation = oldSet.Generation + 1
+	}
+}
+
+// ValidateUpdate is the default update validation for an end user.
+func (clusterSetStrategy) ValidateUpdate(ctx genericapirequest.Context, obj, old runtime.Object) field.ErrorList {
+	return validation.ValidateClusterSetUpdate(obj.(*federation.ClusterSet), old.(*federation.ClusterSet))
+}
+
+func (clusterSetStrategy) AllowUnconditionalUpdate() bool {
+	return true
+}
+
+type clusterSetStatusStrategy struct {
+	clusterSetStrategy
+}
+
+var StatusStrategy = clusterSetStatusStrategy{Strategy}
+
+func (clusterSetStatusStrategy) PrepareForCreate(ctx genericapirequest.Context, obj runtime.Object) {
+	_ = obj.(*federation.ClusterSet)
+}
+
+func (clusterSetStatusStrategy) PrepareForUpdate(ctx genericapirequest.Context, obj, old runtime.Object) {
+	set := obj.(*federation.ClusterSet)
+	oldSet := old.(*federation.ClusterSet)
+	set.Spec = oldSet.Spec
+	set.Generation = oldSet.Generation
+}
+
+// ValidateUpdate is the default update validation for an end user.
+func (clusterSetStatusStrategy) ValidateUpdate(ctx genericapirequest.Context, obj, old runtime.Object) field.ErrorList {
+	return validation.ValidateClusterSetStatusUpdate(obj.(*federation.ClusterSet), old.(*federation.ClusterSet))
+}
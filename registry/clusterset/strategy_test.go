@@ -0,0 +1,141 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package clusterset
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/federation/apis/federation"
+	"k8s.io/kubernetes/pkg/api/legacyscheme"
+	apitesting "k8s.io/kubernetes/pkg/api/testing"
+
+	// install all api groups for testing
+	_ "k8s.io/federation/test/testapi"
+)
+
+func validNewClusterSet() *federation.ClusterSet {
+	return &federation.ClusterSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "prod-eu",
+			ResourceVersion: "4",
+		},
+		Spec: federation.ClusterSetSpec{
+			Clusters: []string{"eu-west"},
+			ClusterSelector: []federation.ClusterSelectorRequirement{
+				{Key: "environment", Operator: "in", Values: []string{"prod"}},
+			},
+		},
+		Status: federation.ClusterSetStatus{
+			Clusters: []string{"eu-north", "eu-west"},
+		},
+	}
+}
+
+func TestClusterSetStrategy(t *testing.T) {
+	ctx := genericapirequest.NewDefaultContext()
+	if Strategy.NamespaceScoped() {
+		t.Errorf("ClusterSet should not be namespace scoped")
+	}
+	if Strategy.AllowCreateOnUpdate() {
+		t.Errorf("ClusterSet should not allow create on update")
+	}
+
+	set := validNewClusterSet()
+	Strategy.PrepareForCreate(ctx, set)
+	if len(set.Status.Clusters) != 0 {
+		t.Errorf("ClusterSet should not allow setting status on create")
+	}
+	if set.Generation != 1 {
+		t.Errorf("Expected generation 1 on create, got %d", set.Generation)
+	}
+	if errs := Strategy.Validate(ctx, set); len(errs) != 0 {
+		t.Errorf("Unexpected error validating %v", errs)
+	}
+
+	old := validNewClusterSet()
+	old.Generation = 1
+	invalidSet := validNewClusterSet()
+	invalidSet.Spec.Clusters = []string{"Not_A_Cluster"}
+	invalidSet.Status.Clusters = nil
+	Strategy.PrepareForUpdate(ctx, invalidSet, old)
+	if !reflect.DeepEqual(invalidSet.Status, old.Status) {
+		t.Errorf("ClusterSet should not allow setting status on update")
+	}
+	if invalidSet.Generation != 2 {
+		t.Errorf("Expected generation to be bumped when the spec changes, got %d", invalidSet.Generation)
+	}
+	if errs := Strategy.ValidateUpdate(ctx, invalidSet, old); len(errs) == 0 {
+		t.Errorf("Expected a validation error")
+	}
+	if invalidSet.ResourceVersion != "4" {
+		t.Errorf("Incoming resource version on update should not be mutated")
+	}
+}
+
+func TestClusterSetStatusStrategy(t *testing.T) {
+	ctx := genericapirequest.NewDefaultContext()
+
+	old := validNewClusterSet()
+	old.Generation = 1
+	set := validNewClusterSet()
+	set.Spec.Clusters = nil
+	set.Status.Clusters = []string{"eu-west"}
+	StatusStrategy.PrepareForUpdate(ctx, set, old)
+	if !reflect.DeepEqual(set.Spec, old.Spec) {
+		t.Errorf("ClusterSet status update should not change the spec")
+	}
+	if set.Generation != 1 {
+		t.Errorf("Expected generation to be kept on status update, got %d", set.Generation)
+	}
+	if errs := StatusStrategy.ValidateUpdate(ctx, set, old); len(errs) != 0 {
+		t.Errorf("Unexpected error validating %v", errs)
+	}
+}
+
+func TestMatchClusterSet(t *testing.T) {
+	testFieldMap := map[bool][]fields.Set{
+		true: {
+			{"metadata.name": "foo"},
+		},
+		false: {
+			{"foo": "bar"},
+		},
+	}
+
+	for expectedResult, fieldSet := range testFieldMap {
+		for _, field := range fieldSet {
+			m := MatchClusterSet(labels.Everything(), field.AsSelector())
+			_, matchesSingle := m.MatchesSingle()
+			if e, a := expectedResult, matchesSingle; e != a {
+				t.Errorf("%+v: expected %v, got %v", fieldSet, e, a)
+			}
+		}
+	}
+}
+
+func TestSelectableFieldLabelConversions(t *testing.T) {
+	apitesting.TestSelectableFieldLabelConversionsOfKind(t,
+		legacyscheme.Registry.GroupOrDie(federation.GroupName).GroupVersion.String(),
+		"ClusterSet",
+		ClusterSetToSelectableFields(&federation.ClusterSet{}),
+		nil,
+	)
+}
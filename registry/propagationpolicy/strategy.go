@@ -0,0 +1,106 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package propagationpolicy
+
+import (
+	"fmt"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/generic"
+	apistorage "k8s.io/apiserver/pkg/storage"
+	"k8s.io/apiserver/pkg/storage/names"
+	"k8s.io/federation/apis/federation"
+	"k8s.io/federation/apis/federation/validation"
+	"k8s.io/kubernetes/pkg/api/legacyscheme"
+)
+
+type propagationPolicyStrategy struct {
+	runtime.ObjectTyper
+	names.NameGenerator
+}
+
+var Strategy = propagationPolicyStrategy{legacyscheme.Scheme, names.SimpleNameGenerator}
+
+func (propagationPolicyStrategy) NamespaceScoped() bool {
+	return false
+}
+
+func PropagationPolicyToSelectableFields(policy *federation.PropagationPolicy) fields.Set {
+	return generic.ObjectMetaFieldsSet(&policy.ObjectMeta, false)
+}
+
+// GetAttrs returns labels and fields of a given object for filtering purposes.
+func GetAttrs(obj runtime.Object) (labels.Set, fields.Set, bool, error) {
+	policy, ok := obj.(*federation.PropagationPolicy)
+	if !ok {
+		return nil, nil, false, fmt.Errorf("given object is not a propagation policy.")
+	}
+	return labels.Set(policy.ObjectMeta.Labels), PropagationPolicyToSelectableFields(policy), policy.Initializers != nil, nil
+}
+
+func MatchPropagationPolicy(label labels.Selector, field fields.Selector) apistorage.SelectionPredicate {
+	return apistorage.SelectionPredicate{
+		Label:    label,
+		Field:    field,
+		GetAttrs: GetAttrs,
+	}
+}
+
+// PrepareForCreate clears fields that are not allowed to be set by end users on creation.
+func (propagationPolicyStrategy) PrepareForCreate(ctx genericapirequest.Context, obj runtime.Object) {
+	policy := obj.(*federation.PropagationPolicy)
+	policy.Generation = 1
+}
+
+// Validate validates a new propagation policy.
+func (propagationPolicyStrategy) Validate(ctx genericapirequest.Context, obj runtime.Object) field.ErrorList {
+	policy := obj.(*federation.PropagationPolicy)
+	return validation.ValidatePropagationPolicy(policy)
+}
+
+// Canonicalize normalizes the object after validation.
+func (propagationPolicyStrategy) Canonicalize(obj runtime.Object) {
+}
+
+// AllowCreateOnUpdate is false for propagation policies.
+func (propagationPolicyStrategy) AllowCreateOnUpdate() bool {
+	return false
+}
+
+// PrepareForUpdate bumps the generation of the policy when its spec changes.
+func (propagationPolicyStrategy) PrepareForUpdate(ctx genericapirequest.Context, obj, old runtime.Object) {
+	policy := obj.(*federation.PropagationPolicy)
+	oldPolicy := old.(*federation.PropagationPolicy)
+	policy.Generation = oldPolicy.Generation
+	if !apiequality.Semantic.DeepEqual(policy.Spec, oldPolicy.Spec) {
+		policy.Generation = oldPolicy.Generation + 1
+	}
+}
+
+// ValidateUpdate is the default update validation for an end user.
+func (propagationPolicyStrategy) ValidateUpdate(ctx genericapirequest.Context, obj, old runtime.Object) field.ErrorList {
+	return validation.ValidatePropagationPolicyUpdate(obj.(*federation.PropagationPolicy), old.(*federation.PropagationPolicy))
+}
+
+func (propagationPolicyStrategy) AllowUnconditionalUpdate() bool {
+	return true
+}
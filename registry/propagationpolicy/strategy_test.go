@@ -0,0 +1,117 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package propagationpolicy
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/federation/apis/federation"
+	"k8s.io/kubernetes/pkg/api/legacyscheme"
+	apitesting "k8s.io/kubernetes/pkg/api/testing"
+
+	// install all api groups for testing
+	_ "k8s.io/federation/test/testapi"
+)
+
+func validNewPropagationPolicy() *federation.PropagationPolicy {
+	return &federation.PropagationPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "foo",
+			ResourceVersion: "4",
+		},
+		Spec: federation.PropagationPolicySpec{
+			ResourceSelectors: []federation.ResourceSelector{
+				{Kind: "ReplicaSet"},
+			},
+			ClusterSelector: []federation.ClusterSelectorRequirement{
+				{Key: "environment", Operator: "in", Values: []string{"prod"}},
+			},
+		},
+	}
+}
+
+func TestPropagationPolicyStrategy(t *testing.T) {
+	ctx := genericapirequest.NewDefaultContext()
+	if Strategy.NamespaceScoped() {
+		t.Errorf("PropagationPolicy should not be namespace scoped")
+	}
+	if Strategy.AllowCreateOnUpdate() {
+		t.Errorf("PropagationPolicy should not allow create on update")
+	}
+
+	policy := validNewPropagationPolicy()
+	Strategy.PrepareForCreate(ctx, policy)
+	if policy.Generation != 1 {
+		t.Errorf("Expected generation 1 on create, got %d", policy.Generation)
+	}
+	if errs := Strategy.Validate(ctx, policy); len(errs) != 0 {
+		t.Errorf("Unexpected error validating %v", errs)
+	}
+
+	unchanged := validNewPropagationPolicy()
+	Strategy.PrepareForUpdate(ctx, unchanged, policy)
+	if unchanged.Generation != 1 {
+		t.Errorf("Expected generation to be kept when the spec is unchanged, got %d", unchanged.Generation)
+	}
+
+	invalidPolicy := validNewPropagationPolicy()
+	invalidPolicy.Spec.ResourceSelectors = nil
+	Strategy.PrepareForUpdate(ctx, invalidPolicy, policy)
+	if invalidPolicy.Generation != 2 {
+		t.Errorf("Expected generation to be bumped when the spec changes, got %d", invalidPolicy.Generation)
+	}
+	if errs := Strategy.ValidateUpdate(ctx, invalidPolicy, policy); len(errs) == 0 {
+		t.Errorf("Expected a validation error")
+	}
+	if policy.ResourceVersion != "4" {
+		t.Errorf("Incoming resource version on update should not be mutated")
+	}
+}
+
+func TestMatchPropagationPolicy(t *testing.T) {
+	testFieldMap := map[bool][]fields.Set{
+		true: {
+			{"metadata.name": "foo"},
+		},
+		false: {
+			{"foo": "bar"},
+		},
+	}
+
+	for expectedResult, fieldSet := range testFieldMap {
+		for _, field := range fieldSet {
+			m := MatchPropagationPolicy(labels.Everything(), field.AsSelector())
+			_, matchesSingle := m.MatchesSingle()
+			if e, a := expectedResult, matchesSingle; e != a {
+				t.Errorf("%+v: expected %v, got %v", fieldSet, e, a)
+			}
+		}
+	}
+}
+
+func TestSelectableFieldLabelConversions(t *testing.T) {
+	apitesting.TestSelectableFieldLabelConversionsOfKind(t,
+		legacyscheme.Registry.GroupOrDie(federation.GroupName).GroupVersion.String(),
+		"PropagationPolicy",
+		PropagationPolicyToSelectableFields(&federation.PropagationPolicy{}),
+		nil,
+	)
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	autoscaling_v1 "k8s.io/api/autoscaling/v1"
+	autoscaling_v2b1 "k8s.io/api/autoscaling/v2beta1"
 	batch_v1 "k8s.io/api/batch/v1"
 	"k8s.io/api/core/v1"
 	ext_v1b1 "k8s.io/api/extensions/v1beta1"
@@ -45,6 +46,7 @@ var enabledGroupVersions = []schema.GroupVersion{
 var disabledGroupVersions = []schema.GroupVersion{
 	batch_v1.SchemeGroupVersion,
 	autoscaling_v1.SchemeGroupVersion,
+	autoscaling_v2b1.SchemeGroupVersion,
 }
 
 type apiTestFunc func(t *testing.T, host string, expectedGroupVersions []schema.GroupVersion)
@@ -128,13 +130,7 @@ func findGroup(groups []metav1.APIGroup, groupName string) *metav1.APIGroup {
 }
 
 func testAPIGroupList(t *testing.T, host string, expectedGroupVersions []schema.GroupVersion) {
-	groupVersionForDiscoveryMap := make(map[string]metav1.GroupVersionForDiscovery)
-	for _, groupVersion := range expectedGroupVersions {
-		groupVersionForDiscoveryMap[groupVersion.Group] = metav1.GroupVersionForDiscovery{
-			GroupVersion: groupVersion.String(),
-			Version:      groupVersion.Version,
-		}
-	}
+	groupVersionsForDiscovery := groupVersionsForDiscovery(expectedGroupVersions)
 
 	serverURL := host + "/apis"
 	contents, err := readResponse(serverURL)
@@ -147,21 +143,20 @@ func testAPIGroupList(t *testing.T, host string, expectedGroupVersions []schema.
 		t.Fatalf("Error in unmarshalling response from server %s: %v", serverURL, err)
 	}
 
-	assert.Equal(t, len(apiGroupList.Groups), len(expectedGroupVersions), "expected: %v, actual: %v", expectedGroupVersions, apiGroupList.Groups)
-	for _, groupVersion := range expectedGroupVersions {
-		found := findGroup(apiGroupList.Groups, groupVersion.Group)
+	assert.Equal(t, len(apiGroupList.Groups), len(groupVersionsForDiscovery), "expected: %v, actual: %v", expectedGroupVersions, apiGroupList.Groups)
+	for group, versions := range groupVersionsForDiscovery {
+		found := findGroup(apiGroupList.Groups, group)
 		assert.NotNil(t, found)
-		assert.Equal(t, groupVersion.Group, found.Name)
-		assert.Equal(t, 1, len(found.Versions))
-		groupVersionForDiscovery := groupVersionForDiscoveryMap[groupVersion.Group]
-		assert.Equal(t, groupVersionForDiscovery, found.Versions[0])
-		assert.Equal(t, groupVersionForDiscovery, found.PreferredVersion)
+		assert.Equal(t, group, found.Name)
+		assert.Equal(t, versions, found.Versions)
+		// The first of the expected versions is the preferred one.
+		assert.Equal(t, versions[0], found.PreferredVersion)
 	}
 }
 
 func testAPIGroup(t *testing.T, host string, expectedGroupVersions []schema.GroupVersion) {
-	for _, groupVersion := range expectedGroupVersions {
-		serverURL := host + "/apis/" + groupVersion.Group
+	for group, versions := range groupVersionsForDiscovery(expectedGroupVersions) {
+		serverURL := host + "/apis/" + group
 		contents, err := readResponse(serverURL)
 		if err != nil {
 			t.Fatalf("%v", err)
@@ -172,21 +167,32 @@ func testAPIGroup(t *testing.T, host string, expectedGroupVersions []schema.Grou
 			t.Fatalf("Error in unmarshalling response from server %s: %v", serverURL, err)
 		}
 		// empty APIVersion for extensions group
-		if groupVersion.Group == "extensions" {
+		if group == "extensions" {
 			assert.Equal(t, "", apiGroup.APIVersion)
 		} else {
 			assert.Equal(t, "v1", apiGroup.APIVersion)
 		}
-		assert.Equal(t, apiGroup.Name, groupVersion.Group)
-		assert.Equal(t, 1, len(apiGroup.Versions))
-		assert.Equal(t, groupVersion.String(), apiGroup.Versions[0].GroupVersion)
-		assert.Equal(t, groupVersion.Version, apiGroup.Versions[0].Version)
+		assert.Equal(t, apiGroup.Name, group)
+		assert.Equal(t, versions, apiGroup.Versions)
 		assert.Equal(t, apiGroup.PreferredVersion, apiGroup.Versions[0])
 	}
 
 	testCoreAPIGroup(t, host)
 }
 
+// groupVersionsForDiscovery groups the given group versions by group, in the
+// order they are listed.
+func groupVersionsForDiscovery(groupVersions []schema.GroupVersion) map[string][]metav1.GroupVersionForDiscovery {
+	groups := make(map[string][]metav1.GroupVersionForDiscovery)
+	for _, groupVersion := range groupVersions {
+		groups[groupVersion.Group] = append(groups[groupVersion.Group], metav1.GroupVersionForDiscovery{
+			GroupVersion: groupVersion.String(),
+			Version:      groupVersion.Version,
+		})
+	}
+	return groups
+}
+
 func testCoreAPIGroup(t *testing.T, host string) {
 	serverURL := host + "/api"
 	contents, err := readResponse(serverURL)
@@ -220,7 +226,10 @@ func testAPIResourceList(t *testing.T, host string, expectedGroupVersions []sche
 		testBatchResourceList(t, host)
 	}
 	if contains(expectedGroupVersions, autoscaling_v1.SchemeGroupVersion) {
-		testAutoscalingResourceList(t, host)
+		testAutoscalingResourceList(t, host, autoscaling_v1.SchemeGroupVersion)
+	}
+	if contains(expectedGroupVersions, autoscaling_v2b1.SchemeGroupVersion) {
+		testAutoscalingResourceList(t, host, autoscaling_v2b1.SchemeGroupVersion)
 	}
 }
 
@@ -246,8 +255,8 @@ func testFederationResourceList(t *testing.T, host string) {
 	}
 	assert.Equal(t, "v1", apiResourceList.APIVersion)
 	assert.Equal(t, fed_v1b1.SchemeGroupVersion.String(), apiResourceList.GroupVersion)
-	// Assert that there are exactly 2 resources.
-	assert.Equal(t, 2, len(apiResourceList.APIResources))
+	// Assert that there are exactly 6 resources.
+	assert.Equal(t, 6, len(apiResourceList.APIResources))
 
 	found := findResource(apiResourceList.APIResources, "clusters")
 	assert.NotNil(t, found)
@@ -255,6 +264,18 @@ func testFederationResourceList(t *testing.T, host string) {
 	found = findResource(apiResourceList.APIResources, "clusters/status")
 	assert.NotNil(t, found)
 	assert.False(t, found.Namespaced)
+	found = findResource(apiResourceList.APIResources, "clusters/proxy")
+	assert.NotNil(t, found)
+	assert.False(t, found.Namespaced)
+	found = findResource(apiResourceList.APIResources, "propagationpolicies")
+	assert.NotNil(t, found)
+	assert.False(t, found.Namespaced)
+	found = findResource(apiResourceList.APIResources, "clustersets")
+	assert.NotNil(t, found)
+	assert.False(t, found.Namespaced)
+	found = findResource(apiResourceList.APIResources, "clustersets/status")
+	assert.NotNil(t, found)
+	assert.False(t, found.Namespaced)
 }
 
 func testCoreResourceList(t *testing.T, host string) {
@@ -390,8 +411,8 @@ func testBatchResourceList(t *testing.T, host string) {
 	assert.True(t, found.Namespaced)
 }
 
-func testAutoscalingResourceList(t *testing.T, host string) {
-	serverURL := host + "/apis/" + autoscaling_v1.SchemeGroupVersion.String()
+func testAutoscalingResourceList(t *testing.T, host string, groupVersion schema.GroupVersion) {
+	serverURL := host + "/apis/" + groupVersion.String()
 	contents, err := readResponse(serverURL)
 	if err != nil {
 		t.Fatalf("%v", err)
@@ -403,7 +424,7 @@ func testAutoscalingResourceList(t *testing.T, host string) {
 	}
 	// empty APIVersion for extensions group
 	assert.Equal(t, "v1", apiResourceList.APIVersion)
-	assert.Equal(t, autoscaling_v1.SchemeGroupVersion.String(), apiResourceList.GroupVersion)
+	assert.Equal(t, groupVersion.String(), apiResourceList.GroupVersion)
 	// Assert that there are exactly this number of resources.
 	assert.Equal(t, 2, len(apiResourceList.APIResources))
 
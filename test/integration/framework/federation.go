@@ -69,7 +69,7 @@ func (f *FederationFixture) SetUp(t *testing.T) {
 
 	f.stopChan = make(chan struct{})
 	monitorPeriod := 1 * time.Second
-	clustercontroller.StartClusterController(f.APIFixture.NewConfig(), f.stopChan, monitorPeriod)
+	clustercontroller.StartClusterController(f.APIFixture.NewConfig(), f.stopChan, monitorPeriod, clustercontroller.ProbeOptions{}, nil)
 
 	f.fedClient = f.APIFixture.NewClient("federation-fixture")
 	for i := 0; i < f.DesiredClusterCount; i++ {